@@ -5,13 +5,25 @@ import (
 	"RISK-CodeConflict/remote"
 	"image/color"
 	"math/rand"
+	"slices"
 	"time"
 )
 
-// Play runs the AI logic for a specified player in the game world.
-// The function continuously monitors if it's the player's turn to act.
-// If it's the player's turn, the AI will reinforce its territories, send move/attack commands, and end the turn.
-func Play(host, port string, player string, clr color.RGBA) {
+// Timing controls how long the AI waits (see Play). Short intervals let headless tournaments run at full speed,
+// long intervals make demo games observable for humans.
+type Timing struct {
+	Think time.Duration // The pause before the AI ends its turn (0 = no pause).
+	Poll  time.Duration // The interval at which the AI checks whether it is its turn (at least minPoll).
+}
+
+// minPoll is the shortest polling interval, so a zero or negative Timing.Poll does not busy-spin the server.
+const minPoll = 10 * time.Millisecond
+
+// DefaultTiming is the timing used by the AIs unless configured otherwise.
+var DefaultTiming = Timing{Think: 400 * time.Millisecond, Poll: 200 * time.Millisecond}
+
+// PlayRemote connects to the game server at host:port and runs the AI logic for a specified player (see Play).
+func PlayRemote(host, port string, player string, clr color.RGBA, timing Timing) {
 
 	// init client
 	client, err := remote.NewClient(host, port)
@@ -20,22 +32,57 @@ func Play(host, port string, player string, clr color.RGBA) {
 		return // exit
 	}
 
+	// optional protocol features (old servers reject the handshake and are used without them)
+	_, _ = client.Hello(remote.FeatureGzip, remote.FeatureEnvelope, remote.FeatureFrame)
+
+	Play(client, player, clr, timing)
+}
+
+// PlayLocal runs the AI logic for a specified player directly on the given world without a network connection
+// (see Play and remote.LocalClient). The game starts as soon as maxPlayerCount players have joined.
+func PlayLocal(world *core.World, maxPlayerCount int, player string, clr color.RGBA, timing Timing) {
+	Play(remote.NewLocalClient(world, maxPlayerCount), player, clr, timing)
+}
+
+// Play runs the AI logic for a specified player in the game world, using any transport (see remote.GameClient).
+// The function continuously monitors if it's the player's turn to act.
+// If it's the player's turn, the AI will reinforce its territories, send move/attack commands, and end the turn.
+// The timing defines the pause before the end of the turn and the polling interval (see DefaultTiming).
+func Play(client remote.GameClient, player string, clr color.RGBA, timing Timing) {
+
 	// add player
 	if err := client.AddPlayer(player, clr); err != nil {
 		println(err.Error())
 		return // exit
 	}
 
+	// confirm in the lobby (an error only means the game has already started)
+	_ = client.Ready()
+
+	Takeover(client, player, timing)
+}
+
+// Takeover runs the AI logic for a player who is already in the game, e.g. a human who has resigned
+// (see remote.Server.Substitute and remote.AttachLocalClient). It is the main loop of Play without joining.
+func Takeover(client remote.GameClient, player string, timing Timing) {
 	// Loop indefinitely, checking if it's the player's turn.
 	for {
+		// Check if it's the specified player's turn (the server knows the player of the connection).
+		myTurn, _, err := client.MyTurn()
+		if err != nil {
+			println(err.Error())
+		}
+
 		// load world
 		world := new(core.World)
-		if err := client.Status(world); err != nil {
-			println(err.Error())
+		if myTurn {
+			if err := client.Status(world); err != nil {
+				println(err.Error())
+				myTurn = false
+			}
 		}
 
-		// Check if it's the specified player's turn.
-		if !world.Freeze && len(world.PlayerQueue) > 1 && world.PlayerQueue[0].Name == player {
+		if myTurn {
 			// --------- RUN AI ---------
 
 			// Calculate distances of countries relative to enemy territories.
@@ -47,48 +94,64 @@ func Play(host, port string, player string, clr color.RGBA) {
 				rand.Shuffle(len(d), func(i, j int) { d[i], d[j] = d[j], d[i] })
 			}
 
-			// Reinforce phase: Add units to territories until the player's reinforcement points are exhausted.
-			// A maximum of 600 reinforcement attempts will be made to avoid long loops.
-			for i := 0; i < 600; i++ {
-				// Select a random country to receive a reinforcement unit.
-				for _, c := range world.RndCountryList() {
-					// Try to reinforce one unit in the selected country.
-					// If reinforcement is successful (no error), break out of the loop.
-					if err := client.AttackOrMove(c.Name, c.Name, 1); err == nil {
-						break
-					}
+			// Reinforce phase: Distribute the reinforcement points randomly among the own recruiting regions
+			// and deploy them with a single command.
+			var recruiting []string
+			for _, c := range world.RndCountryList() {
+				if c.RecruitingRegion && c.Occupier != nil && c.Occupier.Player == player {
+					recruiting = append(recruiting, c.Name)
 				}
-
-				// Check if the player has no reinforcement points left.
-				if world.PlayerQueue[0].Reinforcement < 1 {
-					break
+			}
+			if reinforcement := world.PlayerQueue[0].Reinforcement; reinforcement > 0 && len(recruiting) > 0 {
+				amounts := make(map[string]int)
+				for i := 0; i < reinforcement; i++ {
+					amounts[recruiting[rand.Intn(len(recruiting))]]++
+				}
+				orders := make([]core.Recruitment, 0, len(amounts))
+				for name, strength := range amounts {
+					orders = append(orders, core.Recruitment{Country: name, Strength: strength})
+				}
+				if _, err := client.ReinforceAll(orders); err != nil {
+					println(err.Error())
 				}
 			}
 
 			// Movement and attack phase: For each distance group, move or attack neighboring countries.
+			// Every own country can send all units except the garrison (see core.Country.Garrison). Moved units only arrive
+			// at the end of the turn, so the movable strength is known up front and sent with a single command.
+			available := make(map[string]int)
+			for _, c := range world.Countries {
+				if c.Occupier != nil && c.Occupier.Player == player {
+					available[c.Name] = c.Occupier.Strength - c.Garrison()
+				}
+			}
 			for _, d := range distance {
 				for _, c := range d {
-					// Try to attack or move units to each neighboring country.
+					// Attack or move with all available units of each neighboring country.
 					for _, n := range c.Neighbors {
-						var err error = nil
+						// Skip neighbors without units or that cannot reach the country at all (see Army.LegalTargets).
+						if available[n] < 1 || !slices.Contains(world.Country(n).Occupier.LegalTargets(), c) {
+							continue
+						}
 
-						// Continue sending units until an error occurs (e.g., no more units to move).
-						for err == nil {
-							err = client.AttackOrMove(n, c.Name, 1)
+						// A rejected command (e.g. a truce or the MaxCountryStrength limit) keeps the units
+						// available for the next target.
+						if err := client.AttackOrMove(n, c.Name, available[n]); err == nil {
+							available[n] = 0
 						}
 					}
 				}
 			}
 
 			// End the turn and wait briefly before continuing.
-			time.Sleep(400 * time.Millisecond)
+			time.Sleep(timing.Think)
 			if err := client.EndTurn(); err != nil {
 				println(err.Error())
 			}
 
 		} else {
 			// If it's not the player's turn, wait a short time before checking again.
-			time.Sleep(200 * time.Millisecond)
+			time.Sleep(max(timing.Poll, minPoll))
 		}
 	}
 }
@@ -0,0 +1,27 @@
+package core
+
+//--------  SETTER  --------------------------------------------------------------------------------------------------//
+
+// AdminEndTurn ends the turn of the active player, whoever it is, e.g. for tests, debugging or a host that skips
+// a stalled player. Players use EndTurn, which only ends their own turn.
+// The function is thread-safe.
+//
+// Returns:
+//   - The summary of the turn (see EndTurn). TurnResult.Player is empty.
+//   - An error if the world is frozen or has less than two players.
+func (w *World) AdminEndTurn() (TurnResult, error) {
+	return w.endTurn("")
+}
+
+// AdminAttackOrMove gives an order to any army, even if it is not the turn of its owner, e.g. for tests or debugging.
+// All other rules of AttackOrMove apply. Players use AttackOrMove, which only commands their own armies.
+// The function is thread-safe.
+//
+// Parameters:
+//   - attacker, defender, strength: The same parameters as for AttackOrMove.
+//
+// Returns:
+//   - An error if any validation of AttackOrMove fails.
+func (w *World) AdminAttackOrMove(attacker, defender string, strength int) error {
+	return w.attackOrMove(attacker, defender, strength, "")
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestWorld_Admin(t *testing.T) {
+	w := twoPlayerWorld(t)
+	w.NoLog = true
+	active := w.PlayerQueue[0].Name
+	other := w.PlayerQueue[1].Name
+
+	// the empty player is not an admin
+	if _, err := w.EndTurn(""); err == nil || err.Error() != "no player" {
+		t.Fatal(err)
+	}
+	country, neighbor := w.Country("Alaska"), w.Country("Alberta")
+	country.Occupier = NewArmy(w, 5, other, country.Name)
+	neighbor.Occupier = NewArmy(w, 1, other, neighbor.Name)
+	for _, err := range []error{
+		w.AttackOrMove(country.Name, neighbor.Name, 2, ""),
+		w.AttackOrMoveRounds(country.Name, neighbor.Name, 2, 0, ""),
+		w.CanAttackOrMove(country.Name, neighbor.Name, 2, ""),
+		w.ReinforceAll("", []Recruitment{{Country: country.Name, Strength: 1}})[0],
+	} {
+		if err == nil || err.Error() != "no player" {
+			t.Fatal(err)
+		}
+	}
+	if moves := w.LegalMoves(""); moves != nil {
+		t.Fatal(moves)
+	}
+
+	// admin: orders for an army that is not on turn
+	if err := w.AttackOrMove(country.Name, neighbor.Name, 2, active); err == nil {
+		t.Fatal("enemy army commanded")
+	}
+	if err := w.AdminAttackOrMove(country.Name, neighbor.Name, 2); err != nil {
+		t.Fatal(err)
+	}
+	turn, err := w.AdminEndTurn()
+	if err != nil || turn.Player != "" {
+		t.Fatal(err, turn.Player)
+	}
+	if p, _, _ := w.Turn(); p != other {
+		t.Fatal(p, active)
+	}
+	if country.Occupier.Strength != 3 || neighbor.Occupier.Strength != 3 {
+		t.Fatal(country.Occupier.Strength, neighbor.Occupier.Strength)
+	}
+}
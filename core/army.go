@@ -23,6 +23,30 @@ type Army struct {
 	// HomeBase is the name of the country where the army is currently stationed.
 	// This should match a Country.Name value in the game, indicating the army's current location (see World.Countries).
 	HomeBase string // value: Country.Name
+
+	// RoundLimit is the maximum number of dice rounds the army fights as an attacker (see Battle).
+	// If neither side is destroyed after these rounds, the attacker breaks off the battle (see World.AttackOrMoveRounds).
+	// 0 fights until one side is destroyed (default).
+	RoundLimit int `json:",omitempty"`
+}
+
+// BattleResult is the outcome of a battle between two armies (see Army.Battle).
+// The totals are always counted, even without the step-by-step log.
+type BattleResult struct {
+	Rounds         int           // The number of dice rounds (0 if there was no battle).
+	AttackerLosses int           // The total number of units lost by the attacker.
+	DefenderLosses int           // The total number of units lost by the defender.
+	AttackerWon    bool          // The defender has no units left.
+	Log            []string      // The step-by-step log of the battle (nil if the log is disabled).
+	Details        []BattleRound // The dice of every round, the structured form of the log (nil if the log is disabled).
+}
+
+// BattleRound is a single dice round of a battle (see BattleResult.Details).
+type BattleRound struct {
+	AttackerDice   []int // The dice of the attacker, sorted in descending order.
+	DefenderDice   []int // The dice of the defender, sorted in descending order.
+	AttackerLosses int   // The units lost by the attacker in this round.
+	DefenderLosses int   // The units lost by the defender in this round.
 }
 
 // NewArmy creates and returns a new Army instance with the specified strength, player name, and home base country.
@@ -32,6 +56,7 @@ type Army struct {
 // Parameters:
 //   - world: A pointer to the game world (`*World`) that the army is part of.
 //   - strength: The number of units in the army, indicating its initial combat power.
+//     A negative strength is clamped to 0, as the combat and merge code relies on non-negative strengths.
 //   - player: The name of the player controlling the army.
 //   - homeBase: The name of the country where the army is stationed.
 //
@@ -46,7 +71,7 @@ func NewArmy(world *World, strength int, player, homeBase string) *Army {
 	}
 	return &Army{
 		world:    world,
-		Strength: strength,
+		Strength: max(0, strength),
 		Player:   player,
 		HomeBase: homeBase,
 	}
@@ -84,6 +109,65 @@ func (a *Army) Description() string {
 	return fmt.Sprintf("%s's %s Army with %d men", a.Player, a.HomeBase, a.Strength)
 }
 
+// LegalTargets returns the countries this army could attack, move into or reinforce with AttackOrMove, so an AI
+// does not have to find valid commands by trial and error. The army must be the occupier of its HomeBase.
+//   - All neighbors of the HomeBase, as long as the army can leave at least one unit behind
+//     and the neighbor is not occupied by a player with an active truce.
+//     Enemy neighbors are left out in the first rounds (see World.PeacefulRounds).
+//   - The HomeBase itself if it is a recruiting region and the player has reinforcement for it
+//     (see World.ContinentReinforcementPools).
+//
+// Own countries that cannot take another unit are left out (see World.MaxCountryStrength).
+//
+// The turn order and the freeze state of the world are not checked. The function only reads the world
+// and does not lock it, like the other getters of Army.
+//
+// Returns:
+//   - The legal target countries (neighbors in the order of Country.Neighbors, then the HomeBase).
+//     nil if the army is nil or has no legal target.
+func (a *Army) LegalTargets() []*Country {
+	if a == nil || a.world == nil {
+		return nil
+	}
+	home := a.world.Countries[a.HomeBase]
+	if home == nil {
+		return nil
+	}
+
+	var targets []*Country
+
+	// attack or move (the garrison must stay behind, see Country.Garrison)
+	if a.Strength > home.Garrison() {
+		for _, n := range home.NeighborsObj() {
+			enemy := n.Occupier == nil || n.Occupier.Player != a.Player
+			if enemy && a.world.Round < a.world.PeacefulRounds {
+				continue // no attacks in peaceful rounds
+			}
+			if enemy && n.Occupier != nil && a.world.HasTruce(a.Player, n.Occupier.Player) {
+				continue // attack violates truce
+			}
+			if a.world.exceedsStrengthLimit(n, a.Player, 1) {
+				continue // country strength limit reached
+			}
+			targets = append(targets, n)
+		}
+	}
+
+	// reinforcement
+	if home.RecruitingRegion {
+		p := a.PlayerObj()
+		pool := p.Reinforcement
+		if a.world.ContinentReinforcementPools {
+			pool = p.ContinentReinforcement[home.Continent]
+		}
+		if pool > 0 && !a.world.exceedsStrengthLimit(home, a.Player, 1) {
+			targets = append(targets, home)
+		}
+	}
+
+	return targets
+}
+
 //--------  SETTER  --------------------------------------------------------------------------------------------------//
 
 // Attack simulates a battle between two armies, determining the outcome based on their respective strengths,
@@ -96,7 +180,8 @@ func (a *Army) Description() string {
 //   - Dice are rolled for each side, sorted in descending order, and compared pairwise.
 //   - For each pair of dice, the side with the lower roll loses one unit of strength.
 //   - If the dice values are equal in a comparison, the defender always wins the tie.
-//   - The battle continues in rounds until one army is defeated (i.e., its strength reaches 0).
+//   - The battle continues in rounds until one army is defeated (i.e., its strength reaches 0),
+//     or until the RoundLimit of the attacker is reached (both armies survive).
 //
 // Parameters:
 //   - `defender`: A pointer to the `Army` instance representing the defending army.
@@ -104,9 +189,16 @@ func (a *Army) Description() string {
 //
 // Returns:
 //   - A slice of strings (if `noLog` is false) that contains a step-by-step log of the battle, including dice rolls,
-//     losses, and the final outcome of the battle. If `noLog` is true, the function returns an empty slice.
+//     losses, the final outcome and the total losses of the battle. If `noLog` is true, the function returns an empty slice.
 func (a *Army) Attack(defender *Army, noLog bool) (log []string) {
+	return a.Battle(defender, noLog).Log
+}
+
+// Battle is like Attack, but also returns the total losses of both sides (see BattleResult).
+// The totals are cheap to count, so they are available even if noLog is true.
+func (a *Army) Battle(defender *Army, noLog bool) (result BattleResult) {
 	// Initialize the log slice if logging is enabled.
+	var log []string
 	if !noLog {
 		log = make([]string, 0, 20)
 	}
@@ -117,6 +209,7 @@ func (a *Army) Attack(defender *Army, noLog bool) (log []string) {
 		if !noLog {
 			log = append(log, "Not all armies were ready to fight. There was no battle.")
 		}
+		result.Log = log
 		return
 	}
 
@@ -126,19 +219,22 @@ func (a *Army) Attack(defender *Army, noLog bool) (log []string) {
 	}
 
 	// Conduct battle rounds until one army is defeated.
+	startAttacker, startDefender := attacker.Strength, defender.Strength
 	for round := 1; true; round++ {
+		result.Rounds = round
+
 		// Log the current round number.
 		if !noLog {
 			log = append(log, fmt.Sprintf("--- ROUND %d ---", round))
 		}
 
 		// Determine the number of dice each army will roll based on their strengths.
-		attackDiceCount := minInt(3, attacker.Strength)
-		defendDiceCount := minInt(2, defender.Strength)
+		attackDiceCount := minInt(AttackDice, attacker.Strength)
+		defendDiceCount := minInt(DefendDice, defender.Strength)
 
 		// Check if the defender is in a fortified region and adjust their dice count.
 		if defender.HomeBaseObj().FortressRegion {
-			defendDiceCount = minInt(3, defender.Strength) // Defender receives a bonus.
+			defendDiceCount = minInt(FortressDefendDice, defender.Strength) // Defender receives a bonus.
 
 			// Log the defender's advantage if in a fortress region.
 			if !noLog {
@@ -175,6 +271,8 @@ func (a *Army) Attack(defender *Army, noLog bool) (log []string) {
 		if !noLog {
 			log = append(log, fmt.Sprintf("The attacker lost %d units.", oldAttackerStr-attacker.Strength))
 			log = append(log, fmt.Sprintf("The defender lost %d units.", oldDefenderStr-defender.Strength))
+			result.Details = append(result.Details, BattleRound{AttackerDice: attackDice, DefenderDice: defendDice,
+				AttackerLosses: oldAttackerStr - attacker.Strength, DefenderLosses: oldDefenderStr - defender.Strength})
 		}
 
 		// Determine if the battle should end based on remaining strengths.
@@ -190,7 +288,22 @@ func (a *Army) Attack(defender *Army, noLog bool) (log []string) {
 			}
 			break
 		}
+		if attacker.RoundLimit > 0 && round >= attacker.RoundLimit {
+			if !noLog {
+				log = append(log, fmt.Sprintf("The attacker broke off the battle with %d men left.", attacker.Strength))
+			}
+			break
+		}
 	}
+
+	// Count the total losses of the battle.
+	result.AttackerLosses = startAttacker - attacker.Strength
+	result.DefenderLosses = startDefender - defender.Strength
+	result.AttackerWon = defender.Strength <= 0
+	if !noLog {
+		log = append(log, fmt.Sprintf("Total losses: attacker %d, defender %d units in %d rounds.", result.AttackerLosses, result.DefenderLosses, result.Rounds))
+	}
+	result.Log = log
 	return
 }
 
@@ -217,7 +330,7 @@ func rollDice(rnd *rand.Rand, count int) []int {
 
 	// Roll the specified number of dice, generating a random value between 1 and 6 for each die.
 	for i := 0; i < count; i++ {
-		dice[i] = rnd.Intn(6) + 1
+		dice[i] = rnd.Intn(DiceSides) + 1
 	}
 	return dice
 }
@@ -236,3 +349,14 @@ func minInt(a, b int) int {
 	}
 	return b
 }
+
+// copyTo returns a copy of the army that belongs to the given world (see World.DeepCopy).
+// A nil army results in nil.
+func (a *Army) copyTo(w *World) *Army {
+	if a == nil {
+		return nil
+	}
+	cp := *a
+	cp.world = w
+	return &cp
+}
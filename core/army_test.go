@@ -3,6 +3,8 @@ package core
 import (
 	"fmt"
 	"image/color"
+	"math/rand"
+	"slices"
 	"testing"
 )
 
@@ -35,8 +37,8 @@ func TestNewArmy(t *testing.T) {
 	p = ""
 	h = ""
 	a = NewArmy(w, s, p, h)
-	if a == nil || a.world != w || a.Strength != s || a.Player != p || a.HomeBase != h {
-		t.Fatalf("wrong init")
+	if a == nil || a.world != w || a.Strength != 0 || a.Player != p || a.HomeBase != h {
+		t.Fatalf("negative strength must be clamped to 0")
 	}
 }
 
@@ -88,6 +90,94 @@ func TestDescription(t *testing.T) {
 	}
 }
 
+func TestLegalTargets(t *testing.T) {
+	// nil-safe
+	var nilArmy *Army
+	if targets := nilArmy.LegalTargets(); targets != nil {
+		t.Fatal(targets)
+	}
+
+	w := NewWorld()
+	w.PlayerQueue = []*Player{{Name: "P1"}, {Name: "P2"}}
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 1, "P2", c.Name)
+	}
+	names := func(targets []*Country) []string {
+		var s []string
+		for _, c := range targets {
+			s = append(s, c.Name)
+		}
+		return s
+	}
+
+	// find a recruiting region
+	var home *Country
+	for _, c := range w.sortedCountryList() {
+		if c.RecruitingRegion {
+			home = c
+			break
+		}
+	}
+	home.Occupier.Player = "P1"
+	army := home.Occupier
+
+	// one unit, no reinforcement: nothing to do
+	if targets := army.LegalTargets(); targets != nil {
+		t.Fatal(names(targets))
+	}
+
+	// neighbors and reinforcement
+	army.Strength = 2
+	w.Player("P1").Reinforcement = 1
+	want := append(slices.Clone(home.Neighbors), home.Name)
+	if got := names(army.LegalTargets()); !slices.Equal(got, want) {
+		t.Fatal(got, want)
+	}
+
+	// every target is accepted by CanAttackOrMove
+	for _, c := range army.LegalTargets() {
+		if err := w.CanAttackOrMove(home.Name, c.Name, 1, "P1"); err != nil {
+			t.Fatal(c.Name, err)
+		}
+	}
+
+	// truce
+	w.Truces = []*Truce{{Players: [2]string{"P1", "P2"}, Rounds: 1, Active: true}}
+	if got := names(army.LegalTargets()); !slices.Equal(got, []string{home.Name}) {
+		t.Fatal(got)
+	}
+	w.Truces = nil
+
+	// peaceful rounds: only own neighbors
+	w.PeacefulRounds = w.Round + 1
+	own := w.Country(home.Neighbors[0])
+	own.Occupier.Player = "P1"
+	if got := names(army.LegalTargets()); !slices.Equal(got, []string{own.Name, home.Name}) {
+		t.Fatal(got)
+	}
+	for _, c := range army.LegalTargets() {
+		if err := w.CanAttackOrMove(home.Name, c.Name, 1, "P1"); err != nil {
+			t.Fatal(c.Name, err)
+		}
+	}
+	w.Round = w.PeacefulRounds
+	if got := names(army.LegalTargets()); !slices.Equal(got, want) {
+		t.Fatal(got, want)
+	}
+
+	// strength limit: the full own neighbor and the full HomeBase are left out
+	w.MaxCountryStrength = 2
+	own.Invader = NewArmy(w, 1, "P1", home.Name)
+	if got := names(army.LegalTargets()); !slices.Equal(got, want[1:len(want)-1]) {
+		t.Fatal(got)
+	}
+	for _, c := range []*Country{own, home} {
+		if err := w.CanAttackOrMove(home.Name, c.Name, 1, "P1"); err == nil {
+			t.Fatal(c.Name, "limit not reached")
+		}
+	}
+}
+
 func Test_rollDice(t *testing.T) {
 	// get random from world
 	world := NewWorld()
@@ -211,6 +301,95 @@ func TestAttack(t *testing.T) {
 	}
 }
 
+func TestBattle(t *testing.T) {
+	w := NewWorld()
+
+	for _, noLog := range []bool{true, false} {
+		att := NewArmy(w, 30, "Attacker", "AttBase")
+		def := NewArmy(w, 10, "Defender", "DefBase")
+		result := att.Battle(def, noLog)
+
+		// the losses are counted with and without log
+		if result.AttackerLosses != 30-att.Strength || result.DefenderLosses != 10-def.Strength {
+			t.Fatal(noLog, result)
+		}
+		if result.AttackerWon != (def.Strength == 0) || result.Rounds < 1 {
+			t.Fatal(noLog, result)
+		}
+
+		// the log ends with the summary
+		if noLog && len(result.Log) != 0 {
+			t.Fatal(result.Log)
+		}
+		want := fmt.Sprintf("Total losses: attacker %d, defender %d units in %d rounds.", result.AttackerLosses, result.DefenderLosses, result.Rounds)
+		if !noLog && result.Log[len(result.Log)-1] != want {
+			t.Fatal(result.Log)
+		}
+
+		// the details add up to the totals
+		if noLog && result.Details != nil {
+			t.Fatal(result.Details)
+		}
+		if !noLog {
+			attLosses, defLosses := 0, 0
+			for _, r := range result.Details {
+				if len(r.AttackerDice) < 1 || len(r.DefenderDice) < 1 || r.AttackerLosses+r.DefenderLosses != min(len(r.AttackerDice), len(r.DefenderDice)) {
+					t.Fatal(r)
+				}
+				attLosses += r.AttackerLosses
+				defLosses += r.DefenderLosses
+			}
+			if len(result.Details) != result.Rounds || attLosses != result.AttackerLosses || defLosses != result.DefenderLosses {
+				t.Fatal(result.Details)
+			}
+		}
+	}
+
+	// no battle
+	att := NewArmy(w, 30, "Attacker", "AttBase")
+	result := att.Battle(nil, false)
+	if result.Rounds != 0 || result.AttackerLosses != 0 || result.DefenderLosses != 0 || result.AttackerWon || len(result.Log) != 1 {
+		t.Fatal(result)
+	}
+
+	// round limit: both armies survive
+	att = NewArmy(w, 30, "Attacker", "AttBase")
+	att.RoundLimit = 2
+	def := NewArmy(w, 30, "Defender", "DefBase")
+	result = att.Battle(def, true)
+	if result.Rounds != 2 || result.AttackerLosses+result.DefenderLosses != 4 || result.AttackerWon || att.Strength < 1 || def.Strength < 1 {
+		t.Fatal(result)
+	}
+}
+
+func TestAttack_deterministic(t *testing.T) {
+	battle := func(seed int64) ([]string, int, int) {
+		w := NewWorld()
+		w.SetRNG(rand.New(rand.NewSource(seed)))
+		attacker := NewArmy(w, 10, "Attacker", "Alaska")
+		defender := NewArmy(w, 8, "Defender", "Alberta")
+		log := attacker.Attack(defender, false)
+		return log, attacker.Strength, defender.Strength
+	}
+
+	// the same generator results in the same battle
+	log1, att1, def1 := battle(42)
+	log2, att2, def2 := battle(42)
+	if !slices.Equal(log1, log2) || att1 != att2 || def1 != def2 {
+		t.Fatal(log1, log2)
+	}
+
+	// another seed results in other dice
+	different := false
+	for seed := int64(0); seed < 10 && !different; seed++ {
+		log3, _, _ := battle(seed)
+		different = !slices.Equal(log1, log3)
+	}
+	if !different {
+		t.Fatal("battles do not depend on the generator")
+	}
+}
+
 func TestAttack_Table(t *testing.T) {
 	w := NewWorld()
 
@@ -279,3 +458,19 @@ func TestAttack_Table(t *testing.T) {
 		}
 	} //-----------------------------------------
 }
+
+func BenchmarkAttack(b *testing.B) {
+	w := NewWorld()
+	w.SetSeed(1)
+	for _, size := range []int{1, 10, 100, 1000, 10000} {
+		for _, noLog := range []bool{true, false} {
+			b.Run(fmt.Sprintf("%d/noLog=%v", size, noLog), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					att := NewArmy(w, size, "Attacker", "Alaska")
+					def := NewArmy(w, size, "Defender", "Alberta")
+					_ = att.Attack(def, noLog)
+				}
+			})
+		}
+	}
+}
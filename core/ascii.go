@@ -0,0 +1,129 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+//--------  GETTER  --------------------------------------------------------------------------------------------------//
+
+// RenderASCII returns a compact text view of the board for headless debugging (servers, SSH sessions, CI logs).
+// The countries are grouped by continent and list the initial of the occupier, the strength of the army
+// and a pending invader (e.g. "Alberta    P    5  <- P2   3"). A legend maps the initials to the players.
+// Continents and countries are sorted by name, so the output is deterministic and can be used in golden-file tests.
+// The function is thread-safe.
+//
+// Example:
+//
+//	Round 2, SubRound 1, active: Player2
+//	Players: P2=Player2 (reinforcement 4), P=Player1 (reinforcement 0)
+//
+//	Africa (3 points)
+//	  Congo                    P    5
+//	  East Africa              P2   2  <- P    3
+//	  ...
+//	Australia (2 points, owner: P2)
+//	  ...
+func (w *World) RenderASCII() string {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	sb := new(strings.Builder)
+
+	// header
+	active := "-"
+	if len(w.PlayerQueue) > 0 {
+		active = w.PlayerQueue[0].Name
+	}
+	fmt.Fprintf(sb, "Round %d, SubRound %d, active: %s\n", w.Round, w.SubRound, active)
+
+	// legend
+	initials := w.playerInitials()
+	players := make([]string, 0, len(w.PlayerQueue))
+	for _, p := range w.PlayerQueue {
+		players = append(players, fmt.Sprintf("%s=%s (reinforcement %d)", initials[p.Name], p.Name, p.Reinforcement))
+	}
+	fmt.Fprintf(sb, "Players: %s\n", strings.Join(players, ", "))
+
+	// continents
+	continents := make([]string, 0, len(w.Continents))
+	for name := range w.Continents {
+		continents = append(continents, name)
+	}
+	sort.Strings(continents)
+
+	for _, name := range continents {
+		continent := w.Continents[name]
+		fmt.Fprintf(sb, "\n%s (%d points", continent.Name, w.continentPoints(continent))
+		if owner := w.ContinentOwner(name); owner != "" {
+			fmt.Fprintf(sb, ", owner: %s", initials[owner])
+		}
+		sb.WriteString(")\n")
+
+		countries := slices.Clone(continent.Countries)
+		sort.Strings(countries)
+		for _, countryName := range countries {
+			c := w.Country(countryName)
+			fmt.Fprintf(sb, "  %-24s %s", c.Name, asciiArmy(c.Occupier, initials))
+			if c.Invader != nil {
+				fmt.Fprintf(sb, "  <- %s", asciiArmy(c.Invader, initials))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// playerInitials assigns a short symbol to every player of the PlayerQueue (and every other occupier).
+// The symbol is the first letter of the name in upper case; if it is already taken, a number is appended (e.g. "P2").
+// The players are processed in alphabetical order, so the symbols do not change with the turn order.
+// The caller must hold the world lock.
+func (w *World) playerInitials() map[string]string {
+	names := make(map[string]bool)
+	for _, p := range w.PlayerQueue {
+		names[p.Name] = true
+	}
+	for _, c := range w.Countries {
+		if c.Occupier != nil {
+			names[c.Occupier.Player] = true
+		}
+		if c.Invader != nil {
+			names[c.Invader.Player] = true
+		}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	initials := make(map[string]string, len(sorted))
+	used := make(map[string]bool, len(sorted))
+	for _, name := range sorted {
+		first := "?"
+		for _, r := range name {
+			first = strings.ToUpper(string(r))
+			break
+		}
+		initial := first
+		for i := 2; used[initial]; i++ {
+			initial = fmt.Sprintf("%s%d", first, i)
+		}
+		initials[name] = initial
+		used[initial] = true
+	}
+	return initials
+}
+
+// asciiArmy formats the owner initial and strength of an army ("-" for no army).
+func asciiArmy(a *Army, initials map[string]string) string {
+	if a == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%-2s %3d", initials[a.Player], a.Strength)
+}
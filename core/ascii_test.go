@@ -0,0 +1,45 @@
+package core
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestWorld_RenderASCII(t *testing.T) {
+	w := NewWorld()
+	_ = w.AddPlayer("Player1", color.RGBA{R: 255, A: 255})
+	_ = w.AddPlayer("Player2", color.RGBA{B: 255, A: 255})
+	for _, c := range w.Countries {
+		player := "Player1"
+		if c.Continent == "Australia" {
+			player = "Player2"
+		}
+		c.Occupier = NewArmy(w, 2, player, c.Name)
+	}
+	w.Country("Congo").Invader = NewArmy(w, 3, "Player2", "Egypt")
+
+	out := w.RenderASCII()
+	for _, want := range []string{
+		"Players: ",
+		"P=Player1 (reinforcement 0)",
+		"P2=Player2 (reinforcement 0)",
+		"\nAustralia (2 points, owner: P2)\n",
+		"\n  Congo                    P    2  <- P2   3\n",
+		"\n  Alaska                   P    2\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("missing %q", want)
+		}
+	}
+
+	// deterministic
+	for i := 0; i < 10; i++ {
+		if again := w.RenderASCII(); again != out {
+			t.Fatal(again)
+		}
+	}
+	if strings.Index(out, "\nAfrica") > strings.Index(out, "\nAsia") {
+		t.Fatal("continents not sorted")
+	}
+}
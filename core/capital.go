@@ -0,0 +1,101 @@
+package core
+
+import (
+	"errors"
+)
+
+// CapitalRule selects what happens when a capital is captured (see World.CapitalRule).
+type CapitalRule string
+
+// The capital rules. The zero value disables capitals (except for the VictoryCapital mode).
+const (
+	CapitalOff       CapitalRule = ""          // no capitals (default)
+	CapitalEliminate CapitalRule = "eliminate" // the owner is eliminated, their countries become neutral
+	CapitalTransfer  CapitalRule = "transfer"  // all countries of the owner go to the conqueror
+	CapitalBonus     CapitalRule = "bonus"     // the conqueror gets CapitalBonus reinforcement units
+)
+
+//--------  SETTER  --------------------------------------------------------------------------------------------------//
+
+// SetCapital moves the capital of a player to another of their countries (see Player.Capital).
+// InitPopulation chooses a capital for every player; it can be changed during the first round of the game.
+// Capitals are only used with a CapitalRule or in the VictoryCapital mode.
+// The function is thread-safe.
+//
+// Parameters:
+//   - player: The name of the player.
+//   - country: The name of the new capital. It must be a country of the player.
+//
+// Returns:
+//   - An error if any validation fails.
+//
+// Error cases:
+//   - Capitals are disabled, or the world is frozen.
+//   - The game is not running, or the first round is over.
+//   - Unknown player or country, or a country of another player.
+func (w *World) SetCapital(player, country string) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if !w.capitalsEnabled() {
+		return errors.New("capitals disabled") // ERROR EXIT
+	}
+	if w.Freeze {
+		return errors.New("world is frozen") // ERROR EXIT
+	}
+	if w.Phase != PhasePlaying || w.Round > 0 {
+		return errors.New("capital can only be set in the first round") // ERROR EXIT
+	}
+	if !w.playerExists(player) {
+		return errors.New("player not found") // ERROR EXIT
+	}
+	c := w.Countries[country]
+	if c == nil {
+		return errors.New("country not found") // ERROR EXIT
+	}
+	if c.Occupier == nil || c.Occupier.Player != player {
+		return errors.New("not your country") // ERROR EXIT
+	}
+
+	w.Player(player).Capital = country
+	return nil // SUCCESS EXIT
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// capitalsEnabled reports whether the players have capitals (see CapitalRule and VictoryCapital).
+// The caller must hold the world lock.
+func (w *World) capitalsEnabled() bool {
+	return w.CapitalRule != CapitalOff || w.VictoryCondition.Mode == VictoryCapital
+}
+
+// captureCapital applies the CapitalRule after the country has been captured by its new occupier (see EndTurn).
+// Nothing happens if the country is not the capital of the old owner.
+// With CapitalEliminate, the old owner loses all countries, so they are removed like any eliminated player
+// (see removeEliminated). With CapitalTransfer, the conqueror takes over all countries of the old owner with
+// their armies; pending attacks of the conqueror on these countries become moves.
+// The caller must hold the world lock.
+func (w *World) captureCapital(c *Country, oldOwner string) {
+	if w.CapitalRule == CapitalOff || w.Player(oldOwner).Capital != c.Name {
+		return
+	}
+	conqueror := c.Occupier.PlayerObj()
+	w.Logger().Info("capital captured", "country", c.Name, "owner", oldOwner, "by", conqueror.Name, "rule", string(w.CapitalRule))
+
+	switch w.CapitalRule {
+	case CapitalEliminate:
+		for _, other := range w.sortedCountryList() {
+			if other.Occupier != nil && other.Occupier.Player == oldOwner {
+				w.neutralize(other)
+			}
+		}
+	case CapitalTransfer:
+		for _, other := range w.sortedCountryList() {
+			if other.Occupier != nil && other.Occupier.Player == oldOwner {
+				other.Occupier.Player = conqueror.Name
+			}
+		}
+	case CapitalBonus:
+		conqueror.addReinforcement(c.Continent, w.CapitalBonus, w.ContinentReinforcementPools)
+	}
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"image/color"
+	"testing"
+)
+
+// capitalWorld returns a running game of three players with the given CapitalRule.
+// P1 is active and can attack the capital of P2 (Alberta, 1 unit) from Alaska (100 units).
+// P2 also owns Ontario; all other countries belong to P3.
+func capitalWorld(t *testing.T, rule CapitalRule) *World {
+	t.Helper()
+	w := twoPlayerWorld(t, func(w *World) {
+		w.CapitalRule = rule
+		w.CapitalBonus = 7
+		_ = w.AddPlayer("P3", color.RGBA{B: 255, A: 255})
+	})
+	for name := range w.Countries {
+		_ = w.SetCountryOwner(name, "P3", 1)
+	}
+	_ = w.SetCountryOwner("Alaska", "P1", 100)
+	_ = w.SetCountryOwner("Alberta", "P2", 1)
+	_ = w.SetCountryOwner("Ontario", "P2", 5)
+	_ = w.SetCapital("P2", "Alberta")
+	return w
+}
+
+func TestWorld_SetCapital(t *testing.T) {
+	w := capitalWorld(t, CapitalBonus)
+	if w.Player("P1").Capital == "" {
+		t.Fatal("InitPopulation must assign capitals")
+	}
+
+	// errors
+	tests := []struct {
+		player  string
+		country string
+		want    string
+	}{
+		{player: "Nobody", country: "Alaska", want: "player not found"},
+		{player: "P1", country: "Atlantis", want: "country not found"},
+		{player: "P1", country: "Alberta", want: "not your country"},
+	}
+	for _, tt := range tests {
+		if err := w.SetCapital(tt.player, tt.country); err == nil || err.Error() != tt.want {
+			t.Fatalf("%s: got %v, want %q", tt.country, err, tt.want)
+		}
+	}
+
+	// success
+	if err := w.SetCapital("P1", "Alaska"); err != nil || w.Player("P1").Capital != "Alaska" {
+		t.Fatal(err)
+	}
+
+	// only in the first round
+	w.Round = 1
+	if err := w.SetCapital("P1", "Alaska"); err == nil || err.Error() != "capital can only be set in the first round" {
+		t.Fatal(err)
+	}
+
+	// disabled
+	w = NewWorld()
+	if err := w.SetCapital("P1", "Alaska"); err == nil || err.Error() != "capitals disabled" {
+		t.Fatal(err)
+	}
+}
+
+func TestWorld_CapitalRule(t *testing.T) {
+	capture := func(w *World) {
+		t.Helper()
+		if err := w.AttackOrMove("Alaska", "Alberta", 99, "P1"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.EndTurn("P1"); err != nil {
+			t.Fatal(err)
+		}
+		if w.Country("Alberta").Occupier.Player != "P1" {
+			t.Fatal("capital not captured")
+		}
+	}
+
+	// eliminate: the countries of P2 become neutral and P2 is removed
+	w := capitalWorld(t, CapitalEliminate)
+	capture(w)
+	if w.Country("Ontario").Occupier.Player != NeutralPlayer || w.playerExists("P2") {
+		t.Fatal("P2 not eliminated")
+	}
+
+	// transfer: the conqueror gets all countries of P2
+	w = capitalWorld(t, CapitalTransfer)
+	capture(w)
+	if o := w.Country("Ontario").Occupier; o.Player != "P1" || o.Strength != 5 || w.playerExists("P2") {
+		t.Fatal("countries not transferred")
+	}
+
+	// bonus: the conqueror gets reinforcements, P2 keeps Ontario
+	w = capitalWorld(t, CapitalBonus)
+	before := w.Player("P1").Reinforcement
+	capture(w)
+	if w.Player("P1").Reinforcement != before+7 || w.Country("Ontario").Occupier.Player != "P2" {
+		t.Fatal("no bonus")
+	}
+
+	// off: nothing happens
+	w = capitalWorld(t, CapitalOff)
+	w.Player("P2").Capital = "Alberta"
+	before = w.Player("P1").Reinforcement
+	capture(w)
+	if w.Country("Ontario").Occupier.Player != "P2" || w.Player("P1").Reinforcement != before {
+		t.Fatal("capital rule applied without capitals")
+	}
+}
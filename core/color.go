@@ -0,0 +1,117 @@
+package core
+
+import (
+	"hash/fnv"
+	"image/color"
+	"math"
+	"strings"
+)
+
+// PlayerColors is the curated palette from which player colors are assigned.
+// ColorForName maps a player name onto one of these entries, and AddPlayer falls back to
+// the next free entry if that color is already taken by another player.
+//
+// The entries are bright enough for the black army labels and pairwise distinguishable
+// (see colorDistance), so games with up to 14 players stay readable.
+var PlayerColors = []color.RGBA{
+	{R: 255, G: 0, B: 0, A: 255},     // Red
+	{R: 0, G: 255, B: 0, A: 255},     // Green
+	{R: 0, G: 0, B: 255, A: 255},     // Blue
+	{R: 255, G: 255, B: 0, A: 255},   // Yellow
+	{R: 255, G: 0, B: 255, A: 255},   // Magenta
+	{R: 254, G: 255, B: 255, A: 255}, // White
+	{R: 0, G: 255, B: 255, A: 255},   // Cyan
+	{R: 255, G: 140, B: 0, A: 255},   // Orange
+	{R: 128, G: 0, B: 255, A: 255},   // Purple
+	{R: 140, G: 70, B: 20, A: 255},   // Brown
+	{R: 0, G: 128, B: 128, A: 255},   // Teal
+	{R: 128, G: 128, B: 0, A: 255},   // Olive
+	{R: 128, G: 128, B: 128, A: 255}, // Grey
+	{R: 255, G: 128, B: 192, A: 255}, // Pink
+}
+
+// ColorForName returns a deterministic color for the given player name.
+// The name is trimmed and hashed (FNV-1a), and the hash selects an entry of PlayerColors.
+// The same name therefore always results in the same color, across sessions and servers.
+//
+// Parameters:
+//   - name: The name of the player.
+//
+// Returns:
+//   - A color from PlayerColors.
+func ColorForName(name string) color.RGBA {
+	return PlayerColors[nameHash(name)%uint32(len(PlayerColors))]
+}
+
+// SuggestPlayerColor returns the color that AddPlayer would assign to a player with the given name
+// if no color is supplied. The color is taken from PlayerColors and is not yet used by any other player.
+//
+// Parameters:
+//   - name: The name of the player.
+//
+// Returns:
+//   - A free color, preferably ColorForName(name).
+func (w *World) SuggestPlayerColor(name string) color.RGBA {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.freeColorForName(name)
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// nameHash returns the FNV-1a hash of the trimmed player name.
+func nameHash(name string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strings.TrimSpace(name)))
+	return h.Sum32()
+}
+
+// freeColorForName returns the first color that is not used by any player in the PlayerQueue.
+// The search starts at ColorForName(name) and walks through PlayerColors. If the whole palette
+// is taken, a color is derived from the name hash and shifted until it is unique.
+// The caller must hold the world lock.
+func (w *World) freeColorForName(name string) color.RGBA {
+	hash := nameHash(name)
+
+	// walk through the palette, starting at the name color
+	for i := 0; i < len(PlayerColors); i++ {
+		clr := PlayerColors[(hash+uint32(i))%uint32(len(PlayerColors))]
+		if !w.colorUsed(clr) {
+			return clr
+		}
+	}
+
+	// palette exhausted: derive a color from the hash
+	clr := color.RGBA{R: uint8(hash >> 16), G: uint8(hash >> 8), B: uint8(hash), A: 255}
+	for w.colorUsed(clr) {
+		clr.R += 97
+		clr.G += 37
+		clr.B += 157
+	}
+	return clr
+}
+
+// colorUsed reports whether the RGB value of the color is already used by a player in the PlayerQueue.
+// The caller must hold the world lock.
+func (w *World) colorUsed(clr color.RGBA) bool {
+	r0, g0, b0, _ := clr.RGBA()
+	for _, p := range w.PlayerQueue {
+		r1, g1, b1, _ := p.Color.RGBA()
+		if r0 == r1 && g0 == g1 && b0 == b1 {
+			return true
+		}
+	}
+	return false
+}
+
+// colorDistance returns the perceptual distance between two colors.
+// It uses the "redmean" approximation, a weighted euclidean distance in RGB space
+// that follows the human color perception much closer than the plain euclidean distance.
+func colorDistance(a, b color.RGBA) float64 {
+	rMean := (float64(a.R) + float64(b.R)) / 2
+	dr := float64(a.R) - float64(b.R)
+	dg := float64(a.G) - float64(b.G)
+	db := float64(a.B) - float64(b.B)
+	return math.Sqrt((2+rMean/256)*dr*dr + 4*dg*dg + (2+(255-rMean)/256)*db*db)
+}
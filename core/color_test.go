@@ -0,0 +1,45 @@
+package core
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestPlayerColors(t *testing.T) {
+	const minDistance = 100
+
+	if len(PlayerColors) < 12 {
+		t.Fatalf("palette too small: %d", len(PlayerColors))
+	}
+	for i, a := range PlayerColors {
+		if a.A != 255 {
+			t.Fatalf("color %d is not opaque", i)
+		}
+		for j, b := range PlayerColors[i+1:] {
+			if d := colorDistance(a, b); d < minDistance {
+				t.Fatalf("colors %d and %d are too similar: %.1f", i, i+1+j, d)
+			}
+		}
+	}
+}
+
+func TestWorld_SuggestPlayerColor(t *testing.T) {
+	w := NewWorld()
+
+	// empty world -> name color
+	if clr := w.SuggestPlayerColor("Player1"); clr != ColorForName("Player1") {
+		t.Fatalf("invalid color: %#v", clr)
+	}
+
+	// taken -> other palette color
+	if err := w.AddPlayer("Other", ColorForName("Player1")); err != nil {
+		t.Fatal(err)
+	}
+	clr := w.SuggestPlayerColor("Player1")
+	if clr == ColorForName("Player1") || clr == (color.RGBA{}) {
+		t.Fatalf("invalid color: %#v", clr)
+	}
+	if err := w.AddPlayer("Player1", clr); err != nil {
+		t.Fatal(err)
+	}
+}
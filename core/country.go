@@ -56,6 +56,10 @@ type Country struct {
 	// to be a recruiting region, while border regions cannot be designated recruiting regions to prevent overpowered troop generation.
 	RecruitingRegion bool
 
+	// MinGarrison overrides World.MinGarrison for this country, e.g. a fortress that must keep 2 units (see Garrison).
+	// 0 uses the value of the world.
+	MinGarrison int
+
 	// Occupier is a pointer to the army currently occupying and controlling this country.
 	// This value indicates which player owns the country and can defend it against attacks.
 	// There must always be an occupier.
@@ -90,3 +94,30 @@ func (c *Country) NeighborsObj() []*Country {
 func (c *Country) ContinentObj() *Continent {
 	return c.world.Continent(c.Continent)
 }
+
+// Garrison returns the number of units that must stay behind when the army of the country attacks or moves:
+// the MinGarrison of the country, or otherwise the one of the world, but at least one.
+func (c *Country) Garrison() int {
+	if c.MinGarrison > 0 {
+		return c.MinGarrison
+	}
+	if c.world != nil {
+		return max(1, c.world.MinGarrison)
+	}
+	return 1
+}
+
+// IsFrontline reports whether the country is occupied by the given player and borders at least one
+// country occupied by another player. Unoccupied neighbors do not count as enemies.
+// Frontline countries are the ones that can attack or be attacked in the next turn.
+func (c *Country) IsFrontline(player string) bool {
+	if c.Occupier == nil || c.Occupier.Player != player {
+		return false
+	}
+	for _, n := range c.NeighborsObj() {
+		if n.Occupier != nil && n.Occupier.Player != player {
+			return true
+		}
+	}
+	return false
+}
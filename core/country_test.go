@@ -43,3 +43,32 @@ func TestContinentObj(t *testing.T) {
 		t.Error("wrong continent")
 	}
 }
+
+func TestIsFrontline(t *testing.T) {
+	world := core.NewWorld()
+	alaska := world.Country("Alaska")
+
+	// unoccupied
+	if alaska.IsFrontline("P1") {
+		t.Error("unoccupied country is no frontline")
+	}
+
+	// own neighbors and unoccupied neighbors
+	alaska.Occupier = core.NewArmy(world, 3, "P1", "Alaska")
+	world.Country("Alberta").Occupier = core.NewArmy(world, 3, "P1", "Alberta")
+	if alaska.IsFrontline("P1") {
+		t.Error("no enemy neighbor")
+	}
+
+	// enemy neighbor
+	world.Country("Kamchatka").Occupier = core.NewArmy(world, 3, "P2", "Kamchatka")
+	if !alaska.IsFrontline("P1") {
+		t.Error("Kamchatka is an enemy neighbor")
+	}
+	if alaska.IsFrontline("P2") {
+		t.Error("Alaska is not occupied by P2")
+	}
+	if !world.Country("Kamchatka").IsFrontline("P2") {
+		t.Error("Alaska is an enemy neighbor")
+	}
+}
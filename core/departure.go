@@ -0,0 +1,102 @@
+package core
+
+import (
+	"image/color"
+	"slices"
+)
+
+// DeparturePolicy decides what happens to the countries of a player who leaves a running game (see World.RemovePlayer).
+type DeparturePolicy string
+
+// The departure policies. The zero value keeps the classic rules: a player only leaves the game
+// by losing their last country.
+const (
+	DepartureRefuse   DeparturePolicy = ""         // Players cannot leave a running game (default).
+	DepartureNeutral  DeparturePolicy = "neutral"  // The countries become neutral obstacles (see NeutralGarrison).
+	DepartureTransfer DeparturePolicy = "transfer" // Every country goes to the strongest neighboring enemy, otherwise it becomes neutral.
+	DepartureRemove   DeparturePolicy = "remove"   // The armies vanish; the countries are taken by the first invader without a battle.
+)
+
+// NeutralPlayer is the occupier of the neutral countries left behind by a departed player (see DeparturePolicy).
+// Neutral countries never attack and get no reinforcements; they are conquered like any other country.
+// The name is reserved and cannot be used by a player (see AddPlayer).
+const NeutralPlayer = "Neutral"
+
+// NeutralColor is the color of neutral countries (see NeutralPlayer).
+var NeutralColor = color.RGBA{R: 128, G: 128, B: 128, A: 255}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// depart removes a player from a running game and hands their countries over according to the DeparturePolicy.
+// Pending orders of the player are cancelled. If all remaining players have already played in this round,
+// the round ends (see newRound), and if only one player remains, the game is finished.
+// The caller must hold the world lock.
+func (w *World) depart(i int) {
+	player := w.PlayerQueue[i]
+
+	// countries
+	for _, c := range w.sortedCountryList() {
+		if c.Invader != nil && c.Invader.Player == player.Name {
+			c.Invader = nil // cancel the pending order
+		}
+		if c.Occupier == nil || c.Occupier.Player != player.Name {
+			continue
+		}
+		switch w.DeparturePolicy {
+		case DepartureTransfer:
+			if heir := w.heir(c, player.Name); heir != "" {
+				c.Occupier.Player = heir
+				break
+			}
+			w.neutralize(c) // no enemy neighbor
+		case DepartureRemove:
+			c.Occupier = NewArmy(w, 0, NeutralPlayer, c.Name)
+		default:
+			w.neutralize(c)
+		}
+	}
+
+	// queue (see removeEliminated)
+	if i >= len(w.PlayerQueue)-w.SubRound {
+		w.SubRound-- // the player has already played in this round
+	}
+	w.PlayerQueue = slices.Delete(w.PlayerQueue, i, i+1)
+	w.Eliminated = append(w.Eliminated, player)
+	w.Logger().Info("player departed", "player", player.Name, "policy", string(w.DeparturePolicy), "round", w.Round)
+
+	switch {
+	case len(w.PlayerQueue) == 1:
+		w.Phase = PhaseFinished
+	case len(w.PlayerQueue) > 1 && w.SubRound >= len(w.PlayerQueue):
+		w.newRound() // the departed player was the last one of the round
+	}
+}
+
+// heir returns the player who inherits a country of a departing player (DepartureTransfer):
+// the occupier of the strongest neighboring country of another player, or "" if there is none.
+// Ties are broken by the order of the neighbors, so the result is deterministic.
+// The caller must hold the world lock.
+func (w *World) heir(c *Country, departing string) string {
+	heir, strength := "", 0
+	for _, n := range c.Neighbors {
+		o := w.Countries[n].Occupier
+		if o == nil || o.Player == departing || o.Player == NeutralPlayer {
+			continue
+		}
+		if o.Strength > strength {
+			heir, strength = o.Player, o.Strength
+		}
+	}
+	return heir
+}
+
+// neutralize hands a country over to the NeutralPlayer, defended by NeutralGarrison units
+// (or the units of the departed player if NeutralGarrison is 0).
+// The caller must hold the world lock.
+func (w *World) neutralize(c *Country) {
+	strength := c.Occupier.Strength
+	if w.NeutralGarrison > 0 {
+		strength = w.NeutralGarrison
+	}
+	c.Occupier = NewArmy(w, strength, NeutralPlayer, c.Name)
+}
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+)
+
+// departureWorld returns a running game with the players P1 to P<players> and the given departure policy.
+func departureWorld(t *testing.T, policy DeparturePolicy, players int) *World {
+	t.Helper()
+	return twoPlayerWorld(t, func(w *World) {
+		w.NoLog = true
+		w.SetSeed(1)
+		w.DeparturePolicy = policy
+		for i := 3; i <= players; i++ {
+			if err := w.AddPlayer(fmt.Sprintf("P%d", i), color.RGBA{}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestWorld_RemovePlayer_refuse(t *testing.T) {
+	w := departureWorld(t, DepartureRefuse, 3)
+	if err := w.RemovePlayer("P1"); err == nil || err.Error() != "game already started" {
+		t.Fatal(err)
+	}
+	if len(w.PlayerQueue) != 3 {
+		t.Fatal(len(w.PlayerQueue))
+	}
+	if err := w.AddPlayer(NeutralPlayer, color.RGBA{}); err == nil || err.Error() != "player name is reserved" {
+		t.Fatal(err)
+	}
+}
+
+func TestWorld_RemovePlayer_neutral(t *testing.T) {
+	w := departureWorld(t, DepartureNeutral, 3)
+	w.NeutralGarrison = 3
+	active := w.PlayerQueue[0].Name
+	departing := w.PlayerQueue[1].Name
+	countries := w.countryCount(departing)
+
+	// a pending order of the departing player is cancelled
+	var order *Country
+	for _, c := range w.Countries {
+		if c.Occupier.Player == departing {
+			c.Invader = NewArmy(w, 1, departing, c.Name)
+			order = c
+			break
+		}
+	}
+
+	if err := w.RemovePlayer(departing); err != nil {
+		t.Fatal(err)
+	}
+	if len(w.PlayerQueue) != 2 || w.PlayerQueue[0].Name != active || w.Phase != PhasePlaying {
+		t.Fatal(w.PlayerQueue, w.Phase)
+	}
+	if order.Invader != nil {
+		t.Fatal("order not cancelled")
+	}
+	if n := w.countryCount(NeutralPlayer); n != countries {
+		t.Fatalf("%d neutral countries, want %d", n, countries)
+	}
+	for _, c := range w.Countries {
+		if c.Occupier.Player == NeutralPlayer && c.Occupier.Strength != 3 {
+			t.Fatalf("%s: garrison %d", c.Name, c.Occupier.Strength)
+		}
+	}
+	if w.Player(NeutralPlayer).Color != NeutralColor {
+		t.Fatal("neutral color")
+	}
+	if err := w.RemovePlayer(departing); err == nil || err.Error() != "player not found" {
+		t.Fatal(err)
+	}
+
+	// the last player standing has won
+	if err := w.RemovePlayer(w.PlayerQueue[1].Name); err != nil {
+		t.Fatal(err)
+	}
+	if w.Phase != PhaseFinished {
+		t.Fatal(w.Phase)
+	}
+	if err := w.RemovePlayer(active); err == nil || err.Error() != "game already started" {
+		t.Fatal(err)
+	}
+}
+
+func TestWorld_RemovePlayer_transfer(t *testing.T) {
+	w := departureWorld(t, DepartureTransfer, 3)
+	departing := w.PlayerQueue[0].Name
+
+	// expected heirs
+	want := make(map[string]string)
+	for _, c := range w.Countries {
+		if c.Occupier.Player == departing {
+			want[c.Name] = w.heir(c, departing)
+		}
+	}
+
+	if err := w.RemovePlayer(departing); err != nil {
+		t.Fatal(err)
+	}
+	for name, heir := range want {
+		got := w.Country(name).Occupier.Player
+		if heir == "" {
+			heir = NeutralPlayer
+		}
+		if got != heir || got == departing {
+			t.Fatalf("%s: %s, want %s", name, got, heir)
+		}
+	}
+}
+
+func TestWorld_RemovePlayer_remove(t *testing.T) {
+	w := departureWorld(t, DepartureRemove, 3)
+	active := w.PlayerQueue[0].Name
+	departing := w.PlayerQueue[2].Name
+
+	// an attack of the active player on a country of the departing player
+	var from, to *Country
+	for _, c := range w.sortedCountryList() {
+		if c.Occupier.Player != active {
+			continue
+		}
+		for _, n := range c.NeighborsObj() {
+			if n.Occupier.Player == departing {
+				from, to = c, n
+			}
+		}
+	}
+	if from == nil {
+		t.Fatal("no neighbor of the departing player")
+	}
+	from.Occupier.Strength = 5
+
+	if err := w.RemovePlayer(departing); err != nil {
+		t.Fatal(err)
+	}
+	if to.Occupier.Player != NeutralPlayer || to.Occupier.Strength != 0 {
+		t.Fatal(to.Occupier)
+	}
+
+	// the empty country is taken without a battle
+	if err := w.AttackOrMove(from.Name, to.Name, 4, active); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.EndTurn(active); err != nil {
+		t.Fatal(err)
+	}
+	if to.Occupier.Player != active || to.Occupier.Strength != 4 {
+		t.Fatal(to.Occupier)
+	}
+}
+
+func TestWorld_RemovePlayer_roundEnd(t *testing.T) {
+	w := departureWorld(t, DepartureNeutral, 3)
+
+	// the first two players have played
+	_, _ = w.EndTurn(w.PlayerQueue[0].Name)
+	_, _ = w.EndTurn(w.PlayerQueue[0].Name)
+	if w.Round != 0 || w.SubRound != 2 {
+		t.Fatal(w.Round, w.SubRound)
+	}
+
+	// the last player of the round departs: the next round begins
+	if err := w.RemovePlayer(w.PlayerQueue[0].Name); err != nil {
+		t.Fatal(err)
+	}
+	if w.Round != 1 || w.SubRound != 0 || len(w.PlayerQueue) != 2 {
+		t.Fatal(w.Round, w.SubRound, len(w.PlayerQueue))
+	}
+}
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"slices"
+)
+
+// Event is a game event that is published to all listeners registered with World.Subscribe.
+// Use a type switch to handle the concrete event types (e.g. CaptureEvent).
+type Event interface {
+
+	// EventRound returns the round in which the event occurred.
+	EventRound() int
+}
+
+// CaptureEvent is published by EndTurn whenever a country changes its owner,
+// i.e. an invader has defeated the occupier of the country.
+type CaptureEvent struct {
+	Country  string // The name of the captured country (Country.Name)
+	OldOwner string // The player who lost the country (Player.Name)
+	NewOwner string // The player who captured the country (Player.Name)
+	Round    int    // The round in which the country was captured
+}
+
+// EventRound returns the round in which the country was captured.
+func (e CaptureEvent) EventRound() int {
+	return e.Round
+}
+
+// BattleEvent is published by EndTurn for every battle, i.e. an invader has attacked the occupier of a country.
+// If the invader has won, a CaptureEvent follows.
+type BattleEvent struct {
+	Country        string        // The name of the attacked country (Country.Name)
+	Attacker       string        // The attacking player (Player.Name)
+	Defender       string        // The defending player (Player.Name)
+	AttackerLosses int           // The units lost by the attacker (see BattleResult)
+	DefenderLosses int           // The units lost by the defender (see BattleResult)
+	Captured       bool          // The attacker has captured the country
+	Round          int           // The round in which the battle took place
+	Details        []BattleRound // The dice of every round, nil without battle log (see World.BattleDetails)
+}
+
+// EventRound returns the round in which the battle took place.
+func (e BattleEvent) EventRound() int {
+	return e.Round
+}
+
+// GameOverEvent is published when the game is finished (see Phase), either by EndTurn or by the departure
+// of the second to last player (see RemovePlayer). It is published once per game, after the events of the last turn.
+type GameOverEvent struct {
+	Winner string // The winner of the game (see World.Winner), "" if the game ended without a winner
+	Round  int    // The round in which the game ended
+}
+
+// EventRound returns the round in which the game ended.
+func (e GameOverEvent) EventRound() int {
+	return e.Round
+}
+
+// listener is a registered event handler (see World.Subscribe).
+type listener struct {
+	id int
+	fn func(Event)
+}
+
+//--------  SETTER  --------------------------------------------------------------------------------------------------//
+
+// Subscribe registers a function that is called for every event of the world (e.g. CaptureEvent).
+// This is the extension point for features outside the core game rules, such as campaign objectives or statistics.
+//
+// The listeners are called synchronously in the order of subscription, after the world lock has been released.
+// They may therefore call methods of the world, but must not block for long, as they delay the caller of EndTurn.
+// Listeners are not copied by Clone.
+//
+// Parameters:
+//   - fn: The function that is called for each event.
+//
+// Returns:
+//   - A function that removes the listener again.
+func (w *World) Subscribe(fn func(Event)) (unsubscribe func()) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.listenerID++
+	id := w.listenerID
+	w.listeners = append(w.listeners, listener{id: id, fn: fn})
+
+	return func() {
+		w.lock.Lock()
+		defer w.lock.Unlock()
+
+		w.listeners = slices.DeleteFunc(w.listeners, func(l listener) bool {
+			return l.id == id
+		})
+	}
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// publish calls all listeners for each of the events.
+// The caller must NOT hold the world lock.
+func (w *World) publish(events []Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	w.lock.Lock()
+	listeners := slices.Clone(w.listeners)
+	w.lock.Unlock()
+
+	for _, e := range events {
+		for _, l := range listeners {
+			l.fn(e)
+		}
+	}
+}
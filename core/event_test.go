@@ -0,0 +1,72 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestWorld_Subscribe(t *testing.T) {
+	w := twoPlayerWorld(t)
+	attacker := w.PlayerQueue[0].Name
+
+	// find a frontline country and prepare an overwhelming attack
+	front := w.FrontlineCountries(attacker)[0]
+	var target *Country
+	for _, n := range front.NeighborsObj() {
+		if n.Occupier.Player != attacker {
+			target = n
+			break
+		}
+	}
+	defender := target.Occupier.Player
+	front.Occupier.Strength = 200
+	target.Occupier.Strength = 1
+
+	// subscribe (listeners may call world methods)
+	events := make([]Event, 0)
+	unsubscribe := w.Subscribe(func(e Event) {
+		_ = w.Json()
+		events = append(events, e)
+	})
+
+	if err := w.AttackOrMove(front.Name, target.Name, 199, attacker); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.EndTurn(attacker); err != nil {
+		t.Fatal(err)
+	}
+
+	// check events
+	if len(events) != 2 {
+		t.Fatal(events)
+	}
+	battle, ok := events[0].(BattleEvent)
+	if !ok {
+		t.Fatal(events[0])
+	}
+	if battle.Country != target.Name || battle.Attacker != attacker || battle.Defender != defender ||
+		battle.DefenderLosses != 1 || !battle.Captured || battle.EventRound() != w.Round {
+		t.Fatal(battle)
+	}
+	capture, ok := events[1].(CaptureEvent)
+	if !ok {
+		t.Fatal(events[1])
+	}
+	if capture.Country != target.Name || capture.OldOwner != defender || capture.NewOwner != attacker || capture.EventRound() != w.Round {
+		t.Fatal(capture)
+	}
+
+	// unsubscribe
+	unsubscribe()
+	front.Occupier.Strength = 200
+	target.Occupier.Strength = 1
+	target.Occupier.Player = defender
+	if err := w.AdminAttackOrMove(front.Name, target.Name, 199); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AdminEndTurn(); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatal(events)
+	}
+}
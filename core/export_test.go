@@ -0,0 +1,17 @@
+package core
+
+import (
+	"math/rand"
+)
+
+// This file is only compiled by "go test". It exports internals of the core package for tests,
+// including the black-box tests of package core_test, so production code cannot rely on them.
+
+// SetRNG replaces the random number generator of the world, e.g. to replay a known dice sequence in a
+// combat test. Unlike SetSeed, the generator state is not tracked, so Save does not restore it.
+func (w *World) SetRNG(r *rand.Rand) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.rnd = r
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"sort"
+)
+
+// SetupFairness measures how balanced the distribution of the countries between the players is.
+// It is intended to be called after InitPopulation, e.g. by tournament organizers to reject unfair starts.
+//
+// The score is the sum of two variances over all players:
+//   - the number of recruiting regions of each player (where reinforcements can be deployed)
+//   - the continent potential of each player: for every continent, the share of its countries
+//     held by the player multiplied with the continent points
+//
+// Returns:
+//   - The fairness score. 0 is perfectly balanced; the higher the score, the more lopsided the start.
+func (w *World) SetupFairness() float64 {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.setupFairness()
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// setupFairness calculates the score of SetupFairness.
+// The caller must hold the world lock.
+func (w *World) setupFairness() float64 {
+	if len(w.PlayerQueue) < 1 {
+		return 0
+	}
+
+	recruiting := make(map[string]float64, len(w.PlayerQueue))
+	potential := make(map[string]float64, len(w.PlayerQueue))
+
+	// recruiting regions
+	for _, c := range w.Countries {
+		if c.Occupier != nil && c.RecruitingRegion {
+			recruiting[c.Occupier.Player]++
+		}
+	}
+
+	// continent potential (in a fixed order, so the floating point sum is reproducible)
+	names := make([]string, 0, len(w.Continents))
+	for name := range w.Continents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		continent := w.Continents[name]
+		if len(continent.Countries) == 0 {
+			continue
+		}
+		share := float64(w.continentPoints(continent)) / float64(len(continent.Countries))
+		for _, name := range continent.Countries {
+			if occupier := w.Country(name).Occupier; occupier != nil {
+				potential[occupier.Player] += share
+			}
+		}
+	}
+
+	return w.playerVariance(recruiting) + w.playerVariance(potential)
+}
+
+// playerVariance returns the variance of the values of all players in the PlayerQueue (missing values count as 0).
+// The caller must hold the world lock.
+func (w *World) playerVariance(values map[string]float64) float64 {
+	n := float64(len(w.PlayerQueue))
+
+	mean := 0.0
+	for _, p := range w.PlayerQueue {
+		mean += values[p.Name]
+	}
+	mean /= n
+
+	variance := 0.0
+	for _, p := range w.PlayerQueue {
+		d := values[p.Name] - mean
+		variance += d * d
+	}
+	return variance / n
+}
+
+// occupiers returns the occupying player of every country (country name -> player name).
+// The caller must hold the world lock.
+func (w *World) occupiers() map[string]string {
+	m := make(map[string]string, len(w.Countries))
+	for name, c := range w.Countries {
+		if c.Occupier != nil {
+			m[name] = c.Occupier.Player
+		}
+	}
+	return m
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestWorld_SetupFairness(t *testing.T) {
+	w := NewWorld()
+
+	// no players
+	if f := w.SetupFairness(); f != 0 {
+		t.Fatal(f)
+	}
+
+	// one player owns everything
+	_ = w.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	_ = w.AddPlayer("P2", color.RGBA{G: 255, A: 255})
+	for name, c := range w.Countries {
+		c.Occupier = NewArmy(w, 1, "P1", name)
+	}
+	lopsided := w.SetupFairness()
+	if lopsided <= 0 {
+		t.Fatal(lopsided)
+	}
+
+	// a random start is fairer than that
+	w.InitPopulation()
+	if f := w.SetupFairness(); f >= lopsided {
+		t.Fatal(f, lopsided)
+	}
+}
+
+func TestWorld_SetupRerolls(t *testing.T) {
+	// the rerolled start is never less fair than the first roll of the same seed
+	for seed := int64(0); seed < 20; seed++ {
+		score := func(rerolls int) (float64, *World) {
+			w := NewWorld()
+			w.SetSeed(seed)
+			w.SetupRerolls = rerolls
+			_ = w.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+			_ = w.AddPlayer("P2", color.RGBA{G: 255, A: 255})
+			_ = w.AddPlayer("P3", color.RGBA{B: 255, A: 255})
+			w.InitPopulation()
+			return w.SetupFairness(), w
+		}
+		first, _ := score(0)
+		best, w := score(10)
+		if best > first {
+			t.Fatal(seed, best, first)
+		}
+
+		// still a valid start
+		for _, c := range w.Countries {
+			if c.Occupier == nil || c.Occupier.Strength != 1 || c.Occupier.world != w {
+				t.Fatal(c.Name)
+			}
+		}
+	}
+}
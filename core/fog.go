@@ -0,0 +1,231 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+)
+
+// FogStrength is the strength of an army in a country the player cannot see (see World.FogOfWar).
+const FogStrength = -1
+
+//--------  GETTER  --------------------------------------------------------------------------------------------------//
+
+// Fogged reports whether the country is hidden from the player by the fog of war (see World.FogOfWar).
+// A player sees their own countries, all countries within FogRadius hops of them and the countries
+// they have scouted (see Scout). Without fog of war, no country is fogged.
+// The function is thread-safe.
+func (w *World) Fogged(player, country string) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.fogged(player, country)
+}
+
+// JsonFor is like Json, but returns the world as seen by the player: in fogged countries (see Fogged),
+// the strength of the occupier is FogStrength and foreign invaders are removed.
+// The revelations of other players are hidden as well. Without fog of war, it is the same as Json.
+// This method uses locking to ensure thread safety.
+//
+// Parameters:
+//   - player: The name of the player who views the world.
+//
+// Returns:
+//   - The JSON string representing the view of the player.
+//     In case of an error, it returns the error message as a string.
+func (w *World) JsonFor(player string) string {
+	if !w.FogOfWar {
+		return w.Json()
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	// a shallow copy with masked countries and players
+	visible := w.visible(player)
+	view := *w
+	view.Countries = make(map[string]*Country, len(w.Countries))
+	for name, c := range w.Countries {
+		view.Countries[name] = fogView(c, player, visible)
+	}
+	view.PlayerQueue = make([]*Player, 0, len(w.PlayerQueue))
+	for _, p := range w.PlayerQueue {
+		if p.Name != player {
+			cp := *p
+			cp.Revealed = nil
+			p = &cp
+		}
+		view.PlayerQueue = append(view.PlayerQueue, p)
+	}
+	view.Eliminated = make([]*Player, 0, len(w.Eliminated))
+	for _, p := range w.Eliminated {
+		if p.Name != player {
+			cp := *p
+			cp.Revealed = nil
+			p = &cp
+		}
+		view.Eliminated = append(view.Eliminated, p)
+	}
+
+	b, err := json.Marshal(&view)
+	if err != nil {
+		return err.Error()
+	}
+	return string(b)
+}
+
+// CountryJsonFor is like CountryJson, but returns the country as seen by the player (see JsonFor).
+//
+// Parameters:
+//   - name: The name of the country.
+//   - player: The name of the player who views the country.
+//
+// Returns:
+//   - The JSON string representing the country.
+//   - An error if the country does not exist.
+func (w *World) CountryJsonFor(name, player string) (string, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	c := w.Countries[name]
+	if c == nil {
+		return "", errors.New("country not found") // ERROR EXIT
+	}
+	var visible map[string]bool
+	if w.FogOfWar {
+		visible = w.visible(player)
+	}
+	b, err := json.Marshal(fogView(c, player, visible))
+	if err != nil {
+		return "", err // ERROR EXIT
+	}
+	return string(b), nil // SUCCESS EXIT
+}
+
+//--------  SETTER  --------------------------------------------------------------------------------------------------//
+
+// Scout sends one unit from an own neighboring country to reveal the true strength of the target country
+// for ScoutRounds rounds (see Fogged). The unit is consumed. It is taken from the strongest own neighbor,
+// which must keep at least one unit. Scouting is only possible with fog of war.
+//
+// Parameters:
+//   - target: The name of the country to reveal.
+//   - player: The name of the scouting player. It must be their turn.
+//
+// Returns:
+//   - An error if any validation fails.
+//
+// Error cases:
+//   - The world is frozen, or fog of war is disabled.
+//   - Unknown player or country, or the country of the player.
+//   - It is not the turn of the player.
+//   - No own neighbor of the target has a unit to spare.
+func (w *World) Scout(target, player string) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	// check freeze
+	if w.Freeze {
+		return errors.New("world is frozen") // ERROR EXIT
+	}
+	if !w.FogOfWar {
+		return errors.New("fog of war disabled") // ERROR EXIT
+	}
+
+	//------  validate input  -----------------------------------------//
+
+	if !w.playerExists(player) {
+		return errors.New("player not found") // ERROR EXIT
+	}
+	if w.PlayerQueue[0].Name != player {
+		return errors.New("not your turn") // ERROR EXIT
+	}
+	c := w.Countries[target]
+	if c == nil {
+		return errors.New("country not found") // ERROR EXIT
+	}
+	if c.Occupier != nil && c.Occupier.Player == player {
+		return errors.New("cannot scout own country") // ERROR EXIT
+	}
+
+	// the strongest own neighbor sends the scout
+	var source *Country
+	for _, n := range c.NeighborsObj() {
+		if n.Occupier != nil && n.Occupier.Player == player && n.Occupier.Strength > 1 {
+			if source == nil || n.Occupier.Strength > source.Occupier.Strength {
+				source = n
+			}
+		}
+	}
+	if source == nil {
+		return errors.New("no own neighbor with a unit to spare") // ERROR EXIT
+	}
+
+	//------  scout  --------------------------------------------------//
+
+	source.Occupier.Strength--
+	w.invalidateAround(source.Name) // see LegalMoves
+	p := w.Player(player)
+	if p.Revealed == nil {
+		p.Revealed = make(map[string]int)
+	}
+	p.Revealed[target] = w.Round + max(1, w.ScoutRounds)
+	return nil // SUCCESS EXIT
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// fogged is the implementation of Fogged.
+// The caller must hold the world lock.
+func (w *World) fogged(player, country string) bool {
+	return w.FogOfWar && !w.visible(player)[country]
+}
+
+// visible returns the countries the player can see in fog of war: the own countries,
+// the countries within FogRadius hops of them (see distances) and the scouted countries.
+// The caller must hold the world lock.
+func (w *World) visible(player string) map[string]bool {
+	var own []*Country
+	for _, c := range w.Countries {
+		if c.Occupier != nil && c.Occupier.Player == player {
+			own = append(own, c)
+		}
+	}
+	seen := make(map[string]bool)
+	for name := range w.distances(own, max(0, w.FogRadius)) {
+		seen[name] = true
+	}
+	for name := range w.Player(player).Revealed {
+		seen[name] = true
+	}
+	return seen
+}
+
+// fogView returns the country as seen by the player: a masked copy if it is not visible (see visible),
+// otherwise the country itself. Without fog of war, visible is nil and all countries are returned unchanged.
+func fogView(c *Country, player string, visible map[string]bool) *Country {
+	if visible == nil || visible[c.Name] {
+		return c
+	}
+	cp := *c
+	if c.Occupier != nil {
+		occupier := *c.Occupier
+		occupier.Strength = FogStrength
+		cp.Occupier = &occupier
+	}
+	if c.Invader != nil && c.Invader.Player != player {
+		cp.Invader = nil
+	}
+	return &cp
+}
+
+// expireRevelations is called at the start of every round and removes the revelations of all players
+// that have expired (see Scout).
+// The caller must hold the world lock.
+func (w *World) expireRevelations() {
+	for _, p := range w.PlayerQueue {
+		maps.DeleteFunc(p.Revealed, func(_ string, expires int) bool {
+			return expires <= w.Round
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package core
+
+import (
+	"testing"
+)
+
+// fogWorld returns a running game with fog of war in which the active player only owns Alaska (10 units).
+func fogWorld(t *testing.T) (w *World, player, other string) {
+	t.Helper()
+	w = twoPlayerWorld(t)
+	w.FogOfWar = true
+	w.ScoutRounds = 2
+
+	player, other = w.PlayerQueue[0].Name, w.PlayerQueue[1].Name
+	for _, c := range w.Countries {
+		c.Occupier.Player, c.Occupier.Strength = other, 5
+	}
+	w.Country("Alaska").Occupier.Player = player
+	w.Country("Alaska").Occupier.Strength = 10
+	return w, player, other
+}
+
+func TestWorld_Fogged(t *testing.T) {
+	w, player, other := fogWorld(t)
+
+	if w.Fogged(player, "Alaska") || !w.Fogged(player, "Alberta") || !w.Fogged(player, "Brazil") {
+		t.Fatal("wrong fog of the player")
+	}
+	if !w.Fogged(other, "Alaska") || w.Fogged(other, "Alberta") {
+		t.Fatal("wrong fog of the other player")
+	}
+
+	// without fog of war
+	w.FogOfWar = false
+	if w.Fogged(player, "Brazil") {
+		t.Fatal("fog without fog of war")
+	}
+}
+
+func TestWorld_FogRadius(t *testing.T) {
+	w, player, _ := fogWorld(t)
+
+	// radius 1: the neighbors of Alaska
+	w.FogRadius = 1
+	if w.Fogged(player, "Alberta") || w.Fogged(player, "Kamchatka") || !w.Fogged(player, "Ontario") {
+		t.Fatal("wrong fog with radius 1")
+	}
+
+	// radius 2: two hops
+	w.FogRadius = 2
+	if w.Fogged(player, "Ontario") || !w.Fogged(player, "Brazil") {
+		t.Fatal("wrong fog with radius 2")
+	}
+
+	// a large radius reveals everything
+	w.FogRadius = 100
+	for name := range w.Countries {
+		if w.Fogged(player, name) {
+			t.Fatal(name)
+		}
+	}
+}
+
+func TestWorld_JsonFor(t *testing.T) {
+	w, player, other := fogWorld(t)
+
+	view := NewWorld()
+	if err := view.FromViewJson(w.JsonFor(player)); err != nil {
+		t.Fatal(err)
+	}
+	if view.Country("Alaska").Occupier.Strength != 10 || view.Country("Brazil").Occupier.Strength != FogStrength {
+		t.Fatal("wrong view")
+	}
+	if view.Country("Brazil").Occupier.Player != other {
+		t.Fatal("the owner must stay visible")
+	}
+
+	// the world itself is not changed
+	if w.Country("Brazil").Occupier.Strength != 5 {
+		t.Fatal("world changed")
+	}
+
+	// single country
+	if js, err := w.CountryJsonFor("Brazil", player); err != nil || js == "" {
+		t.Fatal(js, err)
+	} else if c, _ := w.CountryJson("Brazil"); c == js {
+		t.Fatal("country not fogged")
+	}
+	if _, err := w.CountryJsonFor("Atlantis", player); err == nil {
+		t.Fatal("unknown country")
+	}
+
+	// without fog of war
+	w.FogOfWar = false
+	if w.JsonFor(player) != w.Json() {
+		t.Fatal("fog without fog of war")
+	}
+}
+
+func TestWorld_Scout(t *testing.T) {
+	w, player, other := fogWorld(t)
+
+	// errors
+	tests := []struct {
+		target string
+		player string
+		want   string
+	}{
+		{target: "Alberta", player: "Nobody", want: "player not found"},
+		{target: "Alberta", player: other, want: "not your turn"},
+		{target: "Atlantis", player: player, want: "country not found"},
+		{target: "Alaska", player: player, want: "cannot scout own country"},
+		{target: "Brazil", player: player, want: "no own neighbor with a unit to spare"},
+	}
+	for _, tt := range tests {
+		if err := w.Scout(tt.target, tt.player); err == nil || err.Error() != tt.want {
+			t.Fatalf("%s: got %v, want %q", tt.target, err, tt.want)
+		}
+	}
+
+	// scout a neighbor: one unit is consumed and the country is revealed
+	if err := w.Scout("Alberta", player); err != nil {
+		t.Fatal(err)
+	}
+	if w.Country("Alaska").Occupier.Strength != 9 || w.Fogged(player, "Alberta") {
+		t.Fatal("scout failed")
+	}
+	if w.Player(player).Revealed["Alberta"] != w.Round+2 {
+		t.Fatal(w.Player(player).Revealed)
+	}
+
+	// the revelation expires after ScoutRounds rounds
+	for i := 0; i < 2; i++ {
+		if w.Fogged(player, "Alberta") {
+			t.Fatal("expired too early", i)
+		}
+		for range w.PlayerQueue {
+			if _, err := w.AdminEndTurn(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if !w.Fogged(player, "Alberta") || len(w.Player(player).Revealed) != 0 {
+		t.Fatal("revelation not expired")
+	}
+
+	// without fog of war, frozen
+	w.FogOfWar = false
+	if err := w.Scout("Alberta", player); err == nil || err.Error() != "fog of war disabled" {
+		t.Fatal(err)
+	}
+	w.Freeze = true
+	if err := w.Scout("Alberta", player); err == nil || err.Error() != "world is frozen" {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,225 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+)
+
+// Limits of GenerateRandomWorld.
+const (
+	MinGeneratedCountries = 3   // The minimum number of countries of a generated map.
+	MaxGeneratedCountries = 200 // The maximum number of countries of a generated map (they must fit on the image).
+)
+
+// GenerateRandomWorld creates a world with a procedurally generated map instead of the classic map of NewWorld.
+// The same seed and sizes always produce the same map. The random number generator of the world gets a
+// random seed like NewWorld, so a public map seed does not reveal the dice; use SetSeed for a reproducible game.
+// The result passes Validate, and the GUI, the AIs and the protocol work unchanged.
+//
+// Generation:
+//   - The countries are placed in distinct cells of a grid covering CountryPosScaleWidth x CountryPosScaleHeight,
+//     with a random offset inside the cell, so they never overlap.
+//   - Every country is connected to its two nearest countries, and a minimum spanning tree of all countries
+//     guarantees a connected map.
+//   - The continents grow from spread-out seed countries along the neighbor graph, so each continent is contiguous.
+//   - Countries with a neighbor in another continent are border regions; all others are recruiting regions,
+//     and about a third of them are also fortress regions. The points of a continent depend on its size and borders.
+//
+// Parameters:
+//   - seed: The seed of the map.
+//   - countryCount: The number of countries (MinGeneratedCountries to MaxGeneratedCountries).
+//   - continentCount: The number of continents (at least 1, at most countryCount/2).
+//
+// Returns:
+//   - The new world. Like NewWorld, it has no players yet (see AddPlayer and InitPopulation).
+//   - An error if the sizes are out of range.
+func GenerateRandomWorld(seed int64, countryCount, continentCount int) (*World, error) {
+	if countryCount < MinGeneratedCountries || countryCount > MaxGeneratedCountries {
+		return nil, fmt.Errorf("country count must be between %d and %d", MinGeneratedCountries, MaxGeneratedCountries) // ERROR EXIT
+	}
+	if continentCount < 1 || continentCount > countryCount/2 {
+		return nil, errors.New("continent count must be between 1 and half the country count") // ERROR EXIT
+	}
+	rnd := rand.New(rand.NewSource(seed))
+
+	//------  countries and positions  --------------------------------//
+
+	positions := generatePositions(rnd, countryCount)
+	names := make([]string, countryCount)
+	countries := make(map[string]*Country, countryCount)
+	for i := range names {
+		names[i] = fmt.Sprintf("Country %03d", i+1)
+		countries[names[i]] = &Country{Name: names[i], Position: positions[i]}
+	}
+
+	//------  neighbors  ----------------------------------------------//
+
+	connect := func(a, b int) {
+		ca, cb := countries[names[a]], countries[names[b]]
+		if a != b && !slices.Contains(ca.Neighbors, cb.Name) {
+			ca.Neighbors = append(ca.Neighbors, cb.Name)
+			cb.Neighbors = append(cb.Neighbors, ca.Name)
+		}
+	}
+	dist := func(a, b int) float64 {
+		return math.Hypot(float64(positions[a][0]-positions[b][0]), float64(positions[a][1]-positions[b][1]))
+	}
+
+	// the two nearest countries
+	for i := range names {
+		others := make([]int, 0, countryCount-1)
+		for j := range names {
+			if j != i {
+				others = append(others, j)
+			}
+		}
+		sort.SliceStable(others, func(x, y int) bool { return dist(i, others[x]) < dist(i, others[y]) })
+		connect(i, others[0])
+		connect(i, others[1])
+	}
+
+	// minimum spanning tree (Prim), so the map is connected
+	inTree := make([]bool, countryCount)
+	best := make([]float64, countryCount)
+	parent := make([]int, countryCount)
+	for i := range best {
+		best[i] = math.Inf(1)
+	}
+	best[0] = 0
+	for range names {
+		next := -1
+		for i := range names {
+			if !inTree[i] && (next < 0 || best[i] < best[next]) {
+				next = i
+			}
+		}
+		inTree[next] = true
+		if next != 0 {
+			connect(next, parent[next])
+		}
+		for i := range names {
+			if d := dist(next, i); !inTree[i] && d < best[i] {
+				best[i], parent[i] = d, next
+			}
+		}
+	}
+
+	//------  continents  ---------------------------------------------//
+
+	continentNames := make([]string, continentCount)
+	continents := make(map[string]*Continent, continentCount)
+	for i := range continentNames {
+		continentNames[i] = fmt.Sprintf("Continent %c", 'A'+i%26)
+		if i >= 26 {
+			continentNames[i] += fmt.Sprint(i / 26)
+		}
+		continents[continentNames[i]] = &Continent{Name: continentNames[i]}
+	}
+
+	// spread-out seeds (farthest point sampling, starting with a random country)
+	seeds := []int{rnd.Intn(countryCount)}
+	for len(seeds) < continentCount {
+		far, farDist := -1, -1.0
+		for i := range names {
+			d := math.Inf(1)
+			for _, s := range seeds {
+				d = math.Min(d, dist(i, s))
+			}
+			if d > farDist {
+				far, farDist = i, d
+			}
+		}
+		seeds = append(seeds, far)
+	}
+
+	// grow the continents along the neighbor graph (breadth-first, in turns)
+	index := make(map[string]int, countryCount)
+	for i, name := range names {
+		index[name] = i
+	}
+	owner := make([]int, countryCount)
+	for i := range owner {
+		owner[i] = -1
+	}
+	frontiers := make([][]int, continentCount)
+	for k, s := range seeds {
+		owner[s] = k
+		frontiers[k] = []int{s}
+	}
+	for grown := true; grown; {
+		grown = false
+		for k := range frontiers {
+			var next []int
+			for _, i := range frontiers[k] {
+				for _, n := range countries[names[i]].Neighbors {
+					if j := index[n]; owner[j] < 0 {
+						owner[j] = k
+						next = append(next, j)
+						grown = true
+					}
+				}
+			}
+			frontiers[k] = next
+		}
+	}
+	for i, name := range names {
+		ctt := continents[continentNames[owner[i]]]
+		ctt.Countries = append(ctt.Countries, name)
+		countries[name].Continent = ctt.Name
+	}
+
+	//------  region flags and points  --------------------------------//
+
+	for _, name := range names {
+		c := countries[name]
+		for _, n := range c.Neighbors {
+			if countries[n].Continent != c.Continent {
+				c.BorderRegion = true
+				break
+			}
+		}
+		if !c.BorderRegion {
+			c.RecruitingRegion = true
+			c.FortressRegion = rnd.Intn(3) == 0
+		}
+	}
+	for _, ctt := range continents {
+		borders := 0
+		for _, name := range ctt.Countries {
+			if countries[name].BorderRegion {
+				borders++
+			}
+		}
+		ctt.Points = max(1, len(ctt.Countries)/2+borders/2)
+	}
+
+	//------  world  --------------------------------------------------//
+
+	world := &World{Continents: continents, Countries: countries}
+	world.init(cryptoSeed()) // the dice are independent of the map (see SetSeed)
+
+	return world, nil // SUCCESS EXIT
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// generatePositions returns count positions in distinct cells of a grid covering the world image.
+// Each position lies in the inner half of its cell, so two countries are at least half a cell apart.
+func generatePositions(rnd *rand.Rand, count int) [][2]int {
+	cols := int(math.Ceil(math.Sqrt(float64(count) * CountryPosScaleWidth / CountryPosScaleHeight)))
+	rows := (count + cols - 1) / cols
+	cellW, cellH := CountryPosScaleWidth/cols, CountryPosScaleHeight/rows
+
+	cells := rnd.Perm(cols * rows)[:count]
+	positions := make([][2]int, count)
+	for i, cell := range cells {
+		x := (cell%cols)*cellW + cellW/4 + rnd.Intn(cellW/2+1)
+		y := (cell/cols)*cellH + cellH/4 + rnd.Intn(cellH/2+1)
+		positions[i] = [2]int{x, y}
+	}
+	return positions
+}
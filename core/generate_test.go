@@ -0,0 +1,79 @@
+package core
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestGenerateRandomWorld(t *testing.T) {
+	for _, size := range [][2]int{{3, 1}, {10, 2}, {42, 6}, {100, 12}, {MaxGeneratedCountries, 40}} {
+		for seed := int64(0); seed < 5; seed++ {
+			w, err := GenerateRandomWorld(seed, size[0], size[1])
+			if err != nil {
+				t.Fatal(size, err)
+			}
+			if err := w.Validate(); err != nil {
+				t.Fatal(size, seed, err)
+			}
+			if len(w.Countries) != size[0] || len(w.Continents) != size[1] {
+				t.Fatal(size, len(w.Countries), len(w.Continents))
+			}
+
+			// no overlapping positions
+			seen := make(map[[2]int]bool)
+			for _, c := range w.Countries {
+				if seen[c.Position] {
+					t.Fatal("overlapping position", c.Position)
+				}
+				seen[c.Position] = true
+			}
+		}
+	}
+}
+
+func TestGenerateRandomWorld_deterministic(t *testing.T) {
+	w1, _ := GenerateRandomWorld(42, 50, 5)
+	w2, _ := GenerateRandomWorld(42, 50, 5)
+	w3, _ := GenerateRandomWorld(43, 50, 5)
+	if w1.Json() != w2.Json() {
+		t.Fatal("same seed, different maps")
+	}
+	if w1.Json() == w3.Json() {
+		t.Fatal("different seeds, same map")
+	}
+	if w1.rnd.Int63() == w2.rnd.Int63() {
+		t.Fatal("the map seed predicts the dice")
+	}
+}
+
+func TestGenerateRandomWorld_invalid(t *testing.T) {
+	for _, size := range [][2]int{{2, 1}, {MaxGeneratedCountries + 1, 1}, {10, 0}, {10, 6}} {
+		if _, err := GenerateRandomWorld(1, size[0], size[1]); err == nil {
+			t.Fatal(size)
+		}
+	}
+}
+
+func TestGenerateRandomWorld_play(t *testing.T) {
+	w, err := GenerateRandomWorld(7, 30, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.NoLog = true
+	_ = w.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	_ = w.AddPlayer("P2", color.RGBA{G: 255, A: 255})
+	w.InitPopulation()
+	for _, c := range w.Countries {
+		if c.Occupier == nil || c.world != w {
+			t.Fatal(c.Name)
+		}
+	}
+	for i := 0; i < 4; i++ {
+		if _, err := w.AdminEndTurn(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if w.Round != 2 {
+		t.Fatal(w.Round)
+	}
+}
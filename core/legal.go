@@ -0,0 +1,115 @@
+package core
+
+import "slices"
+
+// Move is a legal order of a player (see LegalMoves): an attack or move from the attacker to a neighboring
+// country, or the deployment of reinforcements if attacker and defender are the same country.
+// It is legal with a strength of 1; the maximum strength depends on the rules (see CanAttackOrMove).
+type Move struct {
+	Attacker string // The name of the country that sends the units (Country.Name)
+	Defender string // The name of the target country (Country.Name)
+}
+
+// legalCache keeps the legal moves of the active player per attacker country (see LegalMoves).
+// Most of the board does not change during a turn, so applied orders only invalidate the countries
+// around them, and everything else that changes the board resets the whole cache.
+type legalCache struct {
+	player   string
+	round    int
+	subRound int
+	moves    map[string][]Move // Key: Move.Attacker; only attacks and moves, no reinforcements
+}
+
+//--------  GETTER  --------------------------------------------------------------------------------------------------//
+
+// LegalMoves returns all orders the player can give right now with a strength of 1: the same orders
+// CanAttackOrMove accepts. Reinforcements are included as moves with attacker and defender being the same country.
+// The moves are sorted by attacker; the reinforcement of a country comes first, followed by the neighbors
+// in alphabetical order.
+// The attacks and moves are cached for the turn, so AIs can call it after every order;
+// the world must only be changed with its methods (and not by writing its fields) while the cache is used.
+// The function is thread-safe.
+//
+// Parameters:
+//   - player: The name of the player.
+//
+// Returns:
+//   - The legal moves. Nothing is legal if the world is frozen, it is not the turn of the player or the player is empty.
+func (w *World) LegalMoves(player string) []Move {
+	if player == "" {
+		return nil // see AttackOrMove
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.legalMoves(player, true)
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// legalMoves is the implementation of LegalMoves. Without the cache, all moves are computed again.
+// The caller must hold the world lock.
+func (w *World) legalMoves(player string, cached bool) []Move {
+	if w.Freeze || len(w.PlayerQueue) < 1 || (player != "" && w.PlayerQueue[0].Name != player) {
+		return nil // no command would be accepted
+	}
+	c := &w.legal
+	if !cached {
+		c = new(legalCache) // the cache of the world is left alone
+	}
+	if c.moves == nil || c.player != player || c.round != w.Round || c.subRound != w.SubRound {
+		*c = legalCache{player: player, round: w.Round, subRound: w.SubRound, moves: make(map[string][]Move)}
+	}
+
+	moves := make([]Move, 0)
+	for _, country := range w.sortedCountryList() {
+		if country.Occupier == nil || (player != "" && country.Occupier.Player != player) {
+			continue
+		}
+
+		// reinforcements depend on the pool, which changes with every deployment
+		if w.validateAttackOrMove(country.Name, country.Name, 1, player) == nil {
+			moves = append(moves, Move{Attacker: country.Name, Defender: country.Name})
+		}
+
+		// attacks and moves
+		list, ok := c.moves[country.Name]
+		if !ok {
+			neighbors := slices.Clone(country.Neighbors)
+			slices.Sort(neighbors)
+			list = make([]Move, 0, len(neighbors))
+			for _, n := range neighbors {
+				if w.validateAttackOrMove(country.Name, n, 1, player) == nil {
+					list = append(list, Move{Attacker: country.Name, Defender: n})
+				}
+			}
+			c.moves[country.Name] = list
+		}
+		moves = append(moves, list...)
+	}
+	return moves
+}
+
+// invalidateAround removes the cached moves of the countries and their neighbors (see LegalMoves).
+// An order changes the strength of its countries, which also decides about the moves of the neighbors
+// into them (e.g. with MaxCountryStrength).
+// The caller must hold the world lock.
+func (w *World) invalidateAround(countries ...string) {
+	if w.legal.moves == nil {
+		return
+	}
+	for _, name := range countries {
+		delete(w.legal.moves, name)
+		if c := w.Countries[name]; c != nil {
+			for _, n := range c.Neighbors {
+				delete(w.legal.moves, n)
+			}
+		}
+	}
+}
+
+// resetLegal clears the cache of LegalMoves after a change of the board, e.g. of the owner of a country.
+// The caller must hold the world lock.
+func (w *World) resetLegal() {
+	w.legal = legalCache{}
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+// legalWorld returns a running game of two players with a limited country strength, P1 is active.
+func legalWorld(t testing.TB) *World {
+	t.Helper()
+	w := twoPlayerWorld(t, func(w *World) {
+		w.MaxCountryStrength = 6
+	})
+	for _, c := range w.sortedCountryList() {
+		c.Occupier.Strength = 1 + len(c.Name)%5
+	}
+	return w
+}
+
+// bruteForceMoves returns the legal moves of the player by testing all pairs with CanAttackOrMove.
+func bruteForceMoves(w *World, player string) []Move {
+	var moves []Move
+	for _, c := range w.sortedCountryList() {
+		neighbors := slices.Clone(c.Neighbors)
+		slices.Sort(neighbors)
+		for _, n := range append([]string{c.Name}, neighbors...) {
+			if w.CanAttackOrMove(c.Name, n, 1, player) == nil {
+				moves = append(moves, Move{Attacker: c.Name, Defender: n})
+			}
+		}
+	}
+	return moves
+}
+
+func TestWorld_LegalMoves(t *testing.T) {
+	w := legalWorld(t)
+
+	moves := w.LegalMoves("P1")
+	if len(moves) == 0 || !slices.Equal(moves, bruteForceMoves(w, "P1")) {
+		t.Fatal(moves)
+	}
+	if moves := w.LegalMoves("P2"); len(moves) != 0 {
+		t.Fatal("not the turn of P2", moves)
+	}
+	w.Freeze = true
+	if moves := w.LegalMoves("P1"); len(moves) != 0 {
+		t.Fatal("frozen", moves)
+	}
+}
+
+func TestWorld_LegalMoves_cache(t *testing.T) {
+	w := legalWorld(t)
+
+	check := func(step string) {
+		t.Helper()
+		player := w.PlayerQueue[0].Name
+		if got, want := w.LegalMoves(player), bruteForceMoves(w, player); !slices.Equal(got, want) {
+			t.Fatalf("%s: got %d moves, want %d", step, len(got), len(want))
+		}
+	}
+
+	for turn := 0; turn < 6; turn++ {
+		check("start of the turn")
+
+		// give orders until nothing is left; every order invalidates its surroundings
+		for i := 0; i < 50; i++ {
+			player := w.PlayerQueue[0].Name
+			moves := w.LegalMoves(player)
+			if len(moves) == 0 {
+				break
+			}
+			m := moves[(i*7)%len(moves)]
+			if err := w.AttackOrMove(m.Attacker, m.Defender, 1, player); err != nil {
+				t.Fatal(m, err)
+			}
+			check("after an order")
+		}
+
+		// changes of the board
+		switch turn {
+		case 1:
+			_ = w.SetCountryOwner("Alaska", w.PlayerQueue[0].Name, 2)
+			check("after a new owner")
+		case 2:
+			_ = w.RequestTruce(w.PlayerQueue[1].Name, w.PlayerQueue[0].Name, 2)
+			_ = w.RequestTruce(w.PlayerQueue[0].Name, w.PlayerQueue[1].Name, 2)
+			check("after a truce")
+		}
+
+		if _, err := w.AdminEndTurn(); err != nil {
+			t.Fatal(err)
+		}
+		if w.Winner() != "" {
+			break
+		}
+	}
+}
+
+// BenchmarkWorld_LegalMoves_cached calls LegalMoves after every order of a turn, like an AI that evaluates
+// its options step by step.
+func BenchmarkWorld_LegalMoves_cached(b *testing.B) {
+	benchmarkLegalMoves(b, true)
+}
+
+// BenchmarkWorld_LegalMoves_recomputed is the same without the cache.
+func BenchmarkWorld_LegalMoves_recomputed(b *testing.B) {
+	benchmarkLegalMoves(b, false)
+}
+
+func benchmarkLegalMoves(b *testing.B, cached bool) {
+	w := legalWorld(b)
+	for i := 0; i < b.N; i++ {
+		c := w.DeepCopy()
+		for j := 0; j < 10; j++ {
+			c.lock.Lock()
+			moves := c.legalMoves("P1", cached)
+			c.lock.Unlock()
+			if len(moves) == 0 {
+				break
+			}
+			m := moves[len(moves)-1]
+			_ = c.AttackOrMove(m.Attacker, m.Defender, 1, "P1")
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package core
+
+import (
+	"errors"
+	"slices"
+	"sync"
+)
+
+// TurnOrder decides the order of the PlayerQueue when the game starts (see World.TurnOrder).
+type TurnOrder string
+
+// Turn orders (see World.TurnOrder).
+const (
+	TurnOrderShuffle TurnOrder = ""     // InitPopulation shuffles the queue once with the world seed (default).
+	TurnOrderJoin    TurnOrder = "join" // The players take their turns in the order in which they have joined.
+)
+
+//--------  GETTER  --------------------------------------------------------------------------------------------------//
+
+// LobbyLock returns the mutex that serializes the lobby operations of all transports of this world
+// (e.g. joining, READY and the game start of the remote package), so two players joining concurrently
+// cannot start the game twice. These operations call several thread-safe methods in a row,
+// so they cannot use the world lock. Every world has its own lobby lock; copies get a new one.
+func (w *World) LobbyLock() *sync.Mutex {
+	return w.lobby
+}
+
+// AllReady reports whether the lobby can be closed: at least two players have joined and all of them
+// have confirmed with SetReady (see RequireReady).
+// The function is thread-safe.
+func (w *World) AllReady() bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if len(w.PlayerQueue) < 2 {
+		return false
+	}
+	for _, p := range w.PlayerQueue {
+		if !p.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// PlayerCount returns the number of players in the PlayerQueue (without the eliminated players).
+// The function is thread-safe.
+func (w *World) PlayerCount() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return len(w.PlayerQueue)
+}
+
+//--------  SETTER  --------------------------------------------------------------------------------------------------//
+
+// SetReady marks a player as ready to start the game (see RequireReady and Player.Ready).
+// The function is thread-safe.
+//
+// Error cases:
+//   - The game has already started (see Phase).
+//   - The player does not exist ("player not found").
+func (w *World) SetReady(player string) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.Phase != PhaseLobby {
+		return errors.New("game already started") // ERROR EXIT
+	}
+	for _, p := range w.PlayerQueue {
+		if p.Name == player {
+			p.Ready = true
+			return nil // SUCCESS EXIT
+		}
+	}
+	return errors.New("player not found") // ERROR EXIT
+}
+
+// SetMaxPlayers changes the number of seats in the lobby (see MaxPlayers).
+// It does not start the game, even if the lobby is full now; that is up to the caller (see remote.Server).
+// The function is thread-safe.
+//
+// Error cases:
+//   - The game has already started (see Phase).
+//   - Fewer than two seats, or fewer seats than players who have already joined.
+func (w *World) SetMaxPlayers(seats int) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.Phase != PhaseLobby {
+		return errors.New("game already started") // ERROR EXIT
+	}
+	if seats < 2 {
+		return errors.New("at least two seats needed") // ERROR EXIT
+	}
+	if seats < len(w.PlayerQueue) {
+		return errors.New("more players have already joined") // ERROR EXIT
+	}
+	w.MaxPlayers = seats
+	return nil // SUCCESS EXIT
+}
+
+// RemovePlayer removes a player who has left the lobby, so the slot and the color become free again.
+// In a running game, players can only be removed if a DeparturePolicy is set, which decides what happens
+// to their countries; by default, they are only eliminated by losing their last country.
+// The function is thread-safe.
+//
+// Error cases:
+//   - The game has already started (see Phase) and DeparturePolicy is DepartureRefuse, or the game is finished.
+//   - The player does not exist ("player not found").
+func (w *World) RemovePlayer(player string) error {
+	// The end of the game is published after the lock is released (see GameOverEvent).
+	var events []Event
+	defer func() { w.publish(events) }()
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	running := w.Phase == PhasePlaying && w.DeparturePolicy != DepartureRefuse
+	if w.Phase != PhaseLobby && !running {
+		return errors.New("game already started") // ERROR EXIT
+	}
+	i := slices.IndexFunc(w.PlayerQueue, func(p *Player) bool { return p.Name == player })
+	if i < 0 {
+		return errors.New("player not found") // ERROR EXIT
+	}
+	if running {
+		w.depart(i)
+		w.resetLegal() // the countries have new owners (see LegalMoves)
+		if w.Phase == PhaseFinished {
+			events = append(events, GameOverEvent{Winner: w.winner(), Round: w.Round})
+		}
+		return nil // SUCCESS EXIT
+	}
+	w.PlayerQueue = slices.Delete(w.PlayerQueue, i, i+1)
+	return nil // SUCCESS EXIT
+}
+
+// SetStartContinent sets the continent in which a player would like to start (see Player.StartContinent).
+// InitPopulation gives the player the countries of this continent first, as long as the fair share
+// of the player allows. If several players prefer the same continent, the first of them in the turn order
+// (see TurnOrder) gets it, and the others are seated by the normal distribution.
+// The function is thread-safe.
+//
+// Parameters:
+//   - player: The name of the player.
+//   - continent: The name of the preferred continent, or "" to remove the preference.
+//
+// Error cases:
+//   - The game has already started (see Phase).
+//   - The player does not exist ("player not found").
+//   - The continent does not exist ("continent not found").
+func (w *World) SetStartContinent(player, continent string) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.Phase != PhaseLobby {
+		return errors.New("game already started") // ERROR EXIT
+	}
+	if continent != "" && w.Continents[continent] == nil {
+		return errors.New("continent not found") // ERROR EXIT
+	}
+	for _, p := range w.PlayerQueue {
+		if p.Name == player {
+			p.StartContinent = continent
+			return nil // SUCCESS EXIT
+		}
+	}
+	return errors.New("player not found") // ERROR EXIT
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// startContinents returns the granted start continent of every player with a preference (see SetStartContinent).
+// A continent is granted to the first player in the queue who prefers it.
+// The caller must hold the world lock.
+func (w *World) startContinents() map[string]string {
+	granted := make(map[string]string) // player -> continent
+	taken := make(map[string]bool)     // continent -> granted
+	for _, p := range w.PlayerQueue {
+		if p.StartContinent != "" && w.Continents[p.StartContinent] != nil && !taken[p.StartContinent] {
+			granted[p.Name] = p.StartContinent
+			taken[p.StartContinent] = true
+		}
+	}
+	return granted
+}
+
+// nextStartCountry returns the index of the next free country of the list for a player (see populate).
+// A player with a granted start continent takes its countries first, and other players leave the granted
+// continents alone as long as other countries are free. Without preferences, it is the first free country.
+// The caller must hold the world lock.
+func nextStartCountry(list []*Country, used []bool, player string, granted map[string]string) int {
+	own := granted[player]
+	others := make(map[string]bool, len(granted))
+	for p, ctt := range granted {
+		if p != player {
+			others[ctt] = true
+		}
+	}
+
+	first, neutral := -1, -1
+	for i, c := range list {
+		if used[i] {
+			continue
+		}
+		if own != "" && c.Continent == own {
+			return i // preferred continent
+		}
+		if first < 0 {
+			first = i
+		}
+		if neutral < 0 && !others[c.Continent] {
+			neutral = i
+		}
+	}
+	if neutral >= 0 {
+		return neutral // not preferred by another player
+	}
+	return first // -1 if all countries are used
+}
@@ -0,0 +1,195 @@
+package core
+
+import (
+	"image/color"
+	"slices"
+	"testing"
+)
+
+func TestWorld_LobbyLock(t *testing.T) {
+	w := NewWorld()
+	if w.LobbyLock() == nil {
+		t.Fatal("no lobby lock")
+	}
+
+	// every world has its own lock
+	w.LobbyLock().Lock()
+	defer w.LobbyLock().Unlock()
+	c := w.DeepCopy()
+	if !c.LobbyLock().TryLock() {
+		t.Fatal("the copy shares the lobby lock")
+	}
+	c.LobbyLock().Unlock()
+	if !NewWorld().LobbyLock().TryLock() {
+		t.Fatal("worlds share the lobby lock")
+	}
+}
+
+func TestWorld_SetReady(t *testing.T) {
+	w := NewWorld()
+	_ = w.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	if w.AllReady() {
+		t.Fatal("one player is not enough")
+	}
+	_ = w.AddPlayer("P2", color.RGBA{B: 255, A: 255})
+
+	if err := w.SetReady("P3"); err == nil || err.Error() != "player not found" {
+		t.Fatal(err)
+	}
+	if err := w.SetReady("P1"); err != nil {
+		t.Fatal(err)
+	}
+	if w.AllReady() {
+		t.Fatal("P2 is not ready")
+	}
+	if err := w.SetReady("P2"); err != nil {
+		t.Fatal(err)
+	}
+	if !w.AllReady() {
+		t.Fatal("all players are ready")
+	}
+
+	w.InitPopulation()
+	if err := w.SetReady("P1"); err == nil || err.Error() != "game already started" {
+		t.Fatal(err)
+	}
+}
+
+func TestWorld_SetMaxPlayers(t *testing.T) {
+	w := NewWorld()
+	_ = w.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	_ = w.AddPlayer("P2", color.RGBA{B: 255, A: 255})
+	_ = w.AddPlayer("P3", color.RGBA{G: 255, A: 255})
+
+	if err := w.SetMaxPlayers(1); err == nil || err.Error() != "at least two seats needed" {
+		t.Fatal(err)
+	}
+	if err := w.SetMaxPlayers(2); err == nil || err.Error() != "more players have already joined" {
+		t.Fatal(err)
+	}
+	if err := w.SetMaxPlayers(3); err != nil || w.MaxPlayers != 3 {
+		t.Fatal(err, w.MaxPlayers)
+	}
+
+	w.InitPopulation()
+	if err := w.SetMaxPlayers(4); err == nil || err.Error() != "game already started" {
+		t.Fatal(err)
+	}
+	if w.MaxPlayers != 3 {
+		t.Fatal(w.MaxPlayers)
+	}
+}
+
+func TestWorld_RemovePlayer(t *testing.T) {
+	w := NewWorld()
+	_ = w.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	_ = w.AddPlayer("P2", color.RGBA{B: 255, A: 255})
+
+	if err := w.RemovePlayer("P3"); err == nil || err.Error() != "player not found" {
+		t.Fatal(err)
+	}
+	if err := w.RemovePlayer("P1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(w.PlayerQueue) != 1 || w.PlayerQueue[0].Name != "P2" {
+		t.Fatal(w.PlayerQueue)
+	}
+
+	// the name and color are free again
+	if err := w.AddPlayer("P1", color.RGBA{R: 255, A: 255}); err != nil {
+		t.Fatal(err)
+	}
+
+	w.InitPopulation()
+	if err := w.RemovePlayer("P1"); err == nil || err.Error() != "game already started" {
+		t.Fatal(err)
+	}
+}
+
+func TestWorld_SetStartContinent(t *testing.T) {
+	w := NewWorld()
+	_ = w.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	_ = w.AddPlayer("P2", color.RGBA{G: 255, A: 255})
+	_ = w.AddPlayer("P3", color.RGBA{B: 255, A: 255})
+
+	if err := w.SetStartContinent("P4", "Europe"); err == nil || err.Error() != "player not found" {
+		t.Fatal(err)
+	}
+	if err := w.SetStartContinent("P1", "Atlantis"); err == nil || err.Error() != "continent not found" {
+		t.Fatal(err)
+	}
+	_ = w.SetStartContinent("P1", "Europe")
+	_ = w.SetStartContinent("P2", "Europe") // conflict: the first of them in the turn order gets it
+	_ = w.SetStartContinent("P3", "South America")
+
+	w.InitPopulation()
+
+	var europe string
+	for _, p := range w.PlayerQueue {
+		if p.Name == "P1" || p.Name == "P2" {
+			europe = p.Name
+			break
+		}
+	}
+
+	for _, name := range w.Continent("Europe").Countries {
+		if o := w.Country(name).Occupier.Player; o != europe {
+			t.Fatalf("%s: %s", name, o)
+		}
+	}
+	for _, name := range w.Continent("South America").Countries {
+		if o := w.Country(name).Occupier.Player; o != "P3" {
+			t.Fatalf("%s: %s", name, o)
+		}
+	}
+	for _, p := range w.PlayerQueue {
+		if n := w.countryCount(p.Name); n != len(w.Countries)/3 {
+			t.Fatalf("%s: %d countries", p.Name, n)
+		}
+	}
+
+	if err := w.SetStartContinent("P1", ""); err == nil || err.Error() != "game already started" {
+		t.Fatal(err)
+	}
+}
+
+func TestWorld_TurnOrder(t *testing.T) {
+	names := []string{"P1", "P2", "P3", "P4", "P5"}
+	order := func(mode TurnOrder, seed int64) []string {
+		w := NewWorld()
+		w.SetSeed(seed)
+		w.TurnOrder = mode
+		for _, name := range names {
+			_ = w.AddPlayer(name, color.RGBA{})
+		}
+		// the lobby keeps the join order
+		for i, p := range w.PlayerQueue {
+			if p.Name != names[i] {
+				t.Fatal("AddPlayer changed the order")
+			}
+		}
+		w.InitPopulation()
+		list := make([]string, len(w.PlayerQueue))
+		for i, p := range w.PlayerQueue {
+			list[i] = p.Name
+		}
+		return list
+	}
+
+	// join order
+	if got := order(TurnOrderJoin, 1); !slices.Equal(got, names) {
+		t.Fatal(got)
+	}
+
+	// shuffled once at the start, deterministic under a fixed seed
+	if !slices.Equal(order(TurnOrderShuffle, 1), order(TurnOrderShuffle, 1)) {
+		t.Fatal("order depends on more than the seed")
+	}
+	shuffled := false
+	for seed := int64(0); seed < 10 && !shuffled; seed++ {
+		shuffled = !slices.Equal(order(TurnOrderShuffle, seed), names)
+	}
+	if !shuffled {
+		t.Fatal("order is never shuffled")
+	}
+}
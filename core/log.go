@@ -0,0 +1,41 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+	"os"
+)
+
+// defaultLogger is used by all worlds without their own logger (see World.SetLogger).
+// It writes all messages, including the battle logs at debug level, to stdout.
+var defaultLogger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+// Logger returns the logger of the world. If no logger was set, the default logger is returned,
+// which writes all levels to stdout.
+//
+// Levels used by the game:
+//   - Debug: battle logs (dice rolls and losses of every round)
+//   - Info: game flow (new rounds, reinforcements, connections)
+//   - Warn: rejected or unusual client behavior
+//   - Error: failures (e.g. serialization or network errors)
+func (w *World) Logger() *slog.Logger {
+	if w.log == nil {
+		return defaultLogger
+	}
+	return w.log
+}
+
+// SetLogger sets the logger used by the world and all components working with it (e.g. the server).
+// The logger is runtime configuration only; it is not serialized and not affected by FromJson.
+// A nil logger restores the default logger.
+func (w *World) SetLogger(l *slog.Logger) {
+	w.log = l
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// battleLogEnabled reports whether the detailed battle logs should be generated.
+// They are skipped if NoLog is set or if the logger discards debug messages anyway.
+func (w *World) battleLogEnabled() bool {
+	return !w.NoLog && w.Logger().Enabled(context.Background(), slog.LevelDebug)
+}
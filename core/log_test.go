@@ -0,0 +1,41 @@
+package core
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestWorld_Logger(t *testing.T) {
+	w := NewWorld()
+
+	// default logger
+	if w.Logger() == nil || !w.battleLogEnabled() {
+		t.Fatal("invalid default logger")
+	}
+
+	// NoLog
+	w.NoLog = true
+	if w.battleLogEnabled() {
+		t.Fatal("battle log should be disabled")
+	}
+	w.NoLog = false
+
+	// custom logger with level info
+	buf := new(bytes.Buffer)
+	w.SetLogger(slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	if w.battleLogEnabled() {
+		t.Fatal("battle log should be disabled")
+	}
+	w.Logger().Info("test message")
+	if !strings.Contains(buf.String(), "test message") {
+		t.Fatalf("invalid log: %s", buf.String())
+	}
+
+	// reset
+	w.SetLogger(nil)
+	if w.Logger() != defaultLogger {
+		t.Fatal("invalid default logger")
+	}
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"slices"
+	"sort"
+)
+
+// roundOdds holds the loss distribution of a single dice round (see Army.Battle):
+// roundOdds[attackDice][defendDice][k] is the probability that the defender loses k units
+// and the attacker loses the rest of the compared dice.
+var roundOdds = computeRoundOdds()
+
+// WinProbability returns the exact probability that an attacker with the given strength conquers
+// a country defended by the given strength (see Army.Battle). The dice rules are the same as in a battle:
+// up to 3 attack dice, up to 2 defend dice (3 in a fortress region), and the defender wins ties.
+// The cost grows with attacker*defender, so callers that evaluate the odds every frame should cache them.
+//
+// Parameters:
+//   - attacker: The strength of the attacking army.
+//   - defender: The strength of the defending army.
+//   - fortress: Whether the defender is in a fortress region.
+//
+// Returns:
+//   - The probability between 0 and 1. An attacker without units never wins,
+//     an empty country is always conquered.
+func WinProbability(attacker, defender int, fortress bool) float64 {
+	if attacker <= 0 {
+		return 0
+	}
+	if defender <= 0 {
+		return 1
+	}
+	maxDefendDice := DefendDice
+	if fortress {
+		maxDefendDice = FortressDefendDice
+	}
+
+	// p[a][d] is the probability to win from a attackers and d defenders.
+	// a round costs at most 3 attackers, so only the last 4 rows are kept.
+	var rows [4][]float64
+	for i := range rows {
+		rows[i] = make([]float64, defender+1)
+	}
+	for a := 1; a <= attacker; a++ {
+		row := rows[a%4]
+		row[0] = 1
+		for d := 1; d <= defender; d++ {
+			ad, dd := minInt(AttackDice, a), minInt(maxDefendDice, d)
+			p := 0.0
+			for k, q := range roundOdds[ad][dd] {
+				if lost := len(roundOdds[ad][dd]) - 1 - k; a-lost > 0 {
+					p += q * rows[(a-lost)%4][d-k]
+				}
+			}
+			row[d] = p
+		}
+	}
+	return rows[attacker%4][defender]
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// computeRoundOdds enumerates all dice rolls of a round for up to 3 dice on each side (see roundOdds).
+func computeRoundOdds() (odds [4][4][]float64) {
+	for ad := 1; ad <= 3; ad++ {
+		for dd := 1; dd <= 3; dd++ {
+			compared := minInt(ad, dd)
+			counts := make([]int, compared+1)
+			total := 0
+			rolls := make([]int, ad+dd)
+			var roll func(i int)
+			roll = func(i int) {
+				if i < len(rolls) {
+					for v := 1; v <= DiceSides; v++ {
+						rolls[i] = v
+						roll(i + 1)
+					}
+					return
+				}
+				attack := sortedDesc(rolls[:ad])
+				defend := sortedDesc(rolls[ad:])
+				k := 0
+				for j := 0; j < compared; j++ {
+					if attack[j] > defend[j] {
+						k++
+					}
+				}
+				counts[k]++
+				total++
+			}
+			roll(0)
+
+			odds[ad][dd] = make([]float64, compared+1)
+			for k, n := range counts {
+				odds[ad][dd][k] = float64(n) / float64(total)
+			}
+		}
+	}
+	return
+}
+
+// sortedDesc returns a copy of the dice sorted in descending order.
+func sortedDesc(dice []int) []int {
+	s := slices.Clone(dice)
+	sort.Sort(sort.Reverse(sort.IntSlice(s)))
+	return s
+}
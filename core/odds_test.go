@@ -0,0 +1,43 @@
+package core
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWinProbability(t *testing.T) {
+	// trivial cases
+	if WinProbability(0, 5, false) != 0 || WinProbability(-1, 5, false) != 0 {
+		t.Fatal("no attacker")
+	}
+	if WinProbability(5, 0, false) != 1 {
+		t.Fatal("no defender")
+	}
+
+	// a single round of one die against one die: 15 of 36 rolls win
+	if p := WinProbability(1, 1, false); math.Abs(p-15.0/36) > 1e-9 {
+		t.Fatal(p)
+	}
+
+	// more attackers win more often, fortresses defend better
+	if WinProbability(10, 5, false) <= WinProbability(5, 5, false) {
+		t.Fatal("more attackers must have better odds")
+	}
+	if WinProbability(10, 5, true) >= WinProbability(10, 5, false) {
+		t.Fatal("a fortress must have better odds")
+	}
+
+	// compare with simulated battles
+	w := NewWorld()
+	wins, n := 0, 20000
+	for i := 0; i < n; i++ {
+		att := NewArmy(w, 8, "Attacker", "AttBase")
+		def := NewArmy(w, 6, "Defender", "DefBase")
+		if att.Battle(def, true).AttackerWon {
+			wins++
+		}
+	}
+	if p := WinProbability(8, 6, false); math.Abs(p-float64(wins)/float64(n)) > 0.02 {
+		t.Fatal(p, float64(wins)/float64(n))
+	}
+}
@@ -0,0 +1,43 @@
+package core
+
+// Phase is the state of the game (see World.Phase).
+type Phase string
+
+// The phases of a game. The zero value is the lobby, so a new world has not started yet.
+const (
+	PhaseLobby    Phase = ""         // Players can join (PLAYER); the game has not started yet.
+	PhasePlaying  Phase = "playing"  // The game is running (a pause is indicated by World.Freeze).
+	PhaseFinished Phase = "finished" // The game has been won (see Winner); no more moves are possible.
+)
+
+//--------  GETTER  --------------------------------------------------------------------------------------------------//
+
+// Started reports whether the game has started, i.e. the lobby is closed (PhasePlaying or PhaseFinished).
+// The function is thread-safe.
+func (w *World) Started() bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.Phase != PhaseLobby
+}
+
+// Frozen reports whether the world is frozen (see Freeze), e.g. before the start or while a game is paused.
+// The function is thread-safe.
+func (w *World) Frozen() bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.Freeze
+}
+
+//--------  SETTER  --------------------------------------------------------------------------------------------------//
+
+// SetFreeze freezes or unfreezes the world (see Freeze). Unlike writing the field, it is safe to call
+// while other goroutines give orders, e.g. to pause a game on a server.
+// The function is thread-safe.
+func (w *World) SetFreeze(freeze bool) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.Freeze = freeze
+}
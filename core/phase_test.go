@@ -0,0 +1,62 @@
+package core
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestWorld_Phase(t *testing.T) {
+	w := NewWorld()
+	if w.Phase != PhaseLobby || w.Started() {
+		t.Fatal(w.Phase)
+	}
+	_ = w.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	_ = w.AddPlayer("P2", color.RGBA{B: 255, A: 255})
+	w.InitPopulation()
+	if w.Phase != PhasePlaying || !w.Started() {
+		t.Fatal(w.Phase)
+	}
+
+	// serialized with the world
+	loaded := new(World)
+	if err := loaded.FromJson(w.Json()); err != nil || loaded.Phase != PhasePlaying {
+		t.Fatal(loaded.Phase, err)
+	}
+
+	// last player standing
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 1, "P1", c.Name)
+	}
+	endRound(t, w)
+	if w.Phase != PhaseFinished || w.Winner() != "P1" {
+		t.Fatal(w.Phase, w.Winner())
+	}
+}
+
+func TestWorld_Phase_victory(t *testing.T) {
+	w := victoryWorld(t, VictoryDomination, 42)
+	w.InitPopulation()
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 1, "P1", c.Name)
+	}
+	endRound(t, w)
+	if w.Phase != PhaseFinished || w.Victor != "P1" {
+		t.Fatal(w.Phase, w.Victor)
+	}
+}
+
+func TestWorld_SetFreeze(t *testing.T) {
+	w := NewWorld()
+	_ = w.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	if w.Frozen() || w.PlayerCount() != 1 {
+		t.Fatal(w.Freeze, w.PlayerCount())
+	}
+	w.SetFreeze(true)
+	if !w.Frozen() || !w.Freeze {
+		t.Fatal("not frozen")
+	}
+	w.SetFreeze(false)
+	if w.Frozen() {
+		t.Fatal("frozen")
+	}
+}
@@ -1,6 +1,16 @@
 package core
 
-import "image/color"
+import (
+	"errors"
+	"image/color"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// MaxPlayerNameLength is the maximum length of a player name in characters (runes), so names fit into the GUI.
+const MaxPlayerNameLength = 32
 
 // Player represents a player in the game world. Each player has unique attributes, including a name, a color for visual
 // representation on the map, and a pool of available reinforcements that they can deploy to strengthen their armies.
@@ -34,6 +44,11 @@ type Player struct {
 	// at the start of their turn or through special events.
 	Reinforcement int
 
+	// ContinentReinforcement holds the reinforcement units per continent if the world uses local reinforcements
+	// (see World.ContinentReinforcementPools). Units can only be deployed in recruiting regions of the continent
+	// in which they were earned. Reinforcement is the total of all pools. Without the rule the map is nil.
+	ContinentReinforcement map[string]int // Key: Continent.Name
+
 	// LastBattleWonRound indicates the most recent round in which the player won a battle.
 	// This value is updated at the end of a turn by the `EndTurn()` function if the player has won any battles.
 	// It is used for game mechanics such as granting bonuses or tracking player performance.
@@ -41,4 +56,72 @@ type Player struct {
 	// Example:
 	//  - If the player won a battle in round 5, this value would be set to 5.
 	LastBattleWonRound int
+
+	// RoundIncome is the income of the current round, i.e. the reinforcement from countries and continents
+	// without the sack bonus (see World.EliminationReinforcement). It is only maintained if the rule is enabled.
+	// 0 means that no income has been paid yet, e.g. in the first round.
+	RoundIncome int
+
+	// ConqueredContinents lists the continents in which the player has captured at least one country
+	// (see World.FirstConquestBonus). It is only maintained if the rule is enabled.
+	ConqueredContinents []string // value: Continent.Name
+
+	// TimeBank is the remaining thinking time of the player if the game is played with a match clock
+	// (chess clock). It ticks down during the player's turns and is maintained by the server (see World.SetTimeBank).
+	// When it reaches zero, the server ends the player's turn automatically.
+	// Without a match clock the value is always 0.
+	TimeBank time.Duration
+
+	// Capital is the name of the capital country of the player (see VictoryCapital and World.CapitalRule).
+	// It is chosen by InitPopulation, can be moved in the first round (see World.SetCapital)
+	// and stays the same even if the country is captured.
+	// Without capitals the value is always "".
+	Capital string
+
+	// Ready indicates that the player has confirmed in the lobby that the game can start (see World.RequireReady).
+	Ready bool
+
+	// StartContinent is the continent in which the player would like to start (see World.SetStartContinent).
+	// InitPopulation seats the player there if possible; "" means no preference (default).
+	StartContinent string
+
+	// Revealed holds the countries the player has scouted in fog of war (see World.Scout),
+	// with the round in which the revelation expires. EndTurn removes expired entries.
+	Revealed map[string]int // Key: Country.Name
+
+	// Conquered is the number of countries the player has captured in this game (see MatchSummary).
+	Conquered int
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// addReinforcement awards reinforcement units to the player. With local reinforcements
+// (pools, see World.ContinentReinforcementPools), the units are also added to the pool of the continent
+// in which they were earned, so Reinforcement remains the total of all pools.
+// The caller must hold the world lock.
+func (p *Player) addReinforcement(continent string, units int, pools bool) {
+	p.Reinforcement += units
+	if !pools {
+		return
+	}
+	if p.ContinentReinforcement == nil {
+		p.ContinentReinforcement = make(map[string]int)
+	}
+	p.ContinentReinforcement[continent] += units
+}
+
+// validatePlayerName checks a user-supplied player name (see World.AddPlayer). Names are sent in the
+// pipe-delimited protocol of the server and drawn by the GUI, so the delimiter "|", line breaks and
+// other non-printable characters are rejected, as well as names that are not valid UTF-8 or too long.
+func validatePlayerName(name string) error {
+	if !utf8.ValidString(name) {
+		return errors.New("player name is not valid UTF-8")
+	}
+	if strings.ContainsFunc(name, func(r rune) bool { return r == '|' || !unicode.IsPrint(r) }) {
+		return errors.New("player name contains invalid characters")
+	}
+	if utf8.RuneCountInString(name) > MaxPlayerNameLength {
+		return errors.New("player name is too long")
+	}
+	return nil
 }
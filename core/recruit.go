@@ -0,0 +1,50 @@
+package core
+
+import "errors"
+
+// Recruitment is a single entry of ReinforceAll: the number of reinforcement units to deploy in a country.
+type Recruitment struct {
+	Country  string // The country to reinforce (Country.Name)
+	Strength int    // The number of units taken from the reinforcement pool
+}
+
+//--------  SETTER  --------------------------------------------------------------------------------------------------//
+
+// ReinforceAll deploys reinforcements in several countries at once. Each entry is validated and applied
+// like AttackOrMove(r.Country, r.Country, r.Strength, player), in the given order and under a single lock,
+// so no other command can interleave. Failed entries are reported but do not undo the successful ones,
+// i.e. a partial success is possible (e.g. if the reinforcement pool runs out).
+//
+// Parameters:
+//   - player: The name of the player issuing the command (see AttackOrMove).
+//   - orders: The countries and the number of units to deploy.
+//
+// Returns:
+//   - One result per entry of orders: nil if the entry was applied, otherwise the reason
+//     (e.g. "cannot recruit in this region" or "not enough reinforcement", or "no player" for an empty player).
+func (w *World) ReinforceAll(player string, orders []Recruitment) []error {
+	// Country events are published after the lock is released (defers run in reverse order).
+	var changes []CountryEvent
+	defer func() { w.notifyWatchers(changes) }()
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	// the watched countries are compared after the change (see WatchCountry)
+	before := w.watchState()
+	defer func() { changes = w.watchChanges(before) }()
+
+	results := make([]error, len(orders))
+	for i, r := range orders {
+		if player == "" {
+			results[i] = errors.New("no player")
+			continue
+		}
+		if err := w.validateAttackOrMove(r.Country, r.Country, r.Strength, player); err != nil {
+			results[i] = err
+			continue
+		}
+		w.applyAttackOrMove(r.Country, r.Country, r.Strength, player)
+	}
+	return results
+}
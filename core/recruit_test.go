@@ -0,0 +1,59 @@
+package core
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestWorld_ReinforceAll(t *testing.T) {
+	w := NewWorld()
+	_ = w.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	_ = w.AddPlayer("P2", color.RGBA{G: 255, A: 255})
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 1, "P1", c.Name)
+	}
+	w.Country("Egypt").Occupier.Player = "P2"
+	w.Player("P1").Reinforcement = 5
+	if w.PlayerQueue[0].Name != "P1" {
+		_, _ = w.AdminEndTurn()
+	}
+
+	var recruiting, other string
+	for _, c := range w.sortedCountryList() {
+		if c.RecruitingRegion && recruiting == "" {
+			recruiting = c.Name
+		}
+		if !c.RecruitingRegion && other == "" {
+			other = c.Name
+		}
+	}
+
+	results := w.ReinforceAll("P1", []Recruitment{
+		{Country: recruiting, Strength: 3},
+		{Country: other, Strength: 1},
+		{Country: "Egypt", Strength: 1},
+		{Country: recruiting, Strength: 3},
+		{Country: recruiting, Strength: 2},
+	})
+	want := []string{"", "cannot recruit in this region", "cannot command enemy armies", "not enough reinforcement", ""}
+	if len(results) != len(want) {
+		t.Fatal(results)
+	}
+	for i, err := range results {
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != want[i] {
+			t.Fatalf("entry %d: got %q, want %q", i, got, want[i])
+		}
+	}
+
+	// partial success: the valid entries are applied
+	if r := w.Player("P1").Reinforcement; r != 0 {
+		t.Fatal(r)
+	}
+	if inv := w.Country(recruiting).Invader; inv == nil || inv.Strength != 5 {
+		t.Fatal(inv)
+	}
+}
@@ -0,0 +1,191 @@
+package core
+
+import (
+	"errors"
+	"maps"
+	"sort"
+)
+
+// ReinforcementBreakdown is the reinforcement of a player for a round with its parts (see World.CalcReinforcement).
+type ReinforcementBreakdown struct {
+	All        int // The total reinforcement points the player is awarded
+	Countries  int // The points from the countries controlled by the player
+	Continents int // The points from the continents fully controlled by the player
+	SackBonus  int // The points for a battle won in the last round
+}
+
+//--------  GETTER  --------------------------------------------------------------------------------------------------//
+
+// DefaultReinforcement is the reinforcement formula used without a World.ReinforcementFunc:
+// one point per country, the points of fully controlled continents (see World.ContinentBonusScale),
+// a sack bonus for a battle won in the last round and the World.MinReinforcementPerTurn floor.
+// A custom ReinforcementFunc can call it to modify the default.
+// It does not lock the world, so it can be called from a ReinforcementFunc.
+func DefaultReinforcement(w *World, player string) ReinforcementBreakdown {
+	all, countries, continents, sackBonus := w.defaultReinforcement(player)
+	return ReinforcementBreakdown{All: all, Countries: countries, Continents: continents, SackBonus: sackBonus}
+}
+
+// CalcContinentReinforcement partitions the reinforcement of CalcReinforcement by continent
+// (see World.ContinentReinforcementPools):
+//   - Every controlled country earns one point in its continent.
+//   - The points of a fully controlled continent are earned in that continent.
+//   - The sack bonus and the MinReinforcementPerTurn floor are earned in the home continent of the player.
+//
+// The home continent is the continent with the most recruiting regions of the player (ties: most countries, then name).
+// With a custom World.ReinforcementFunc, the whole reinforcement is earned in the home continent.
+//
+// Parameters:
+//   - player: The name of the player for whom the reinforcement is being calculated.
+//
+// Returns:
+//   - The reinforcement points per continent (Key: Continent.Name). The sum equals the total of CalcReinforcement.
+//     The map is empty for players without countries.
+func (w *World) CalcContinentReinforcement(player string) map[string]int {
+	all, _, _, _ := w.CalcReinforcement(player)
+	pools := make(map[string]int)
+	if w.ReinforcementFunc != nil {
+		if home := w.homeContinent(player); home != "" && all > 0 {
+			pools[home] = all
+		}
+		return pools
+	}
+
+	// countries and continent points
+	local := 0
+	for _, c := range w.Countries {
+		if c.Occupier != nil && c.Occupier.Player == player {
+			pools[c.Continent]++
+			local++
+		}
+	}
+	for _, continent := range w.Continents {
+		if w.ContinentOwner(continent.Name) == player {
+			pools[continent.Name] += w.continentPoints(continent)
+			local += w.continentPoints(continent)
+		}
+	}
+
+	// sack bonus and floor
+	if rest := all - local; rest > 0 {
+		if home := w.homeContinent(player); home != "" {
+			pools[home] += rest
+		}
+	}
+	return pools
+}
+
+// ReinforcementPool returns the reinforcement units the player can currently deploy, whether or not it is their turn.
+// The function is thread-safe.
+//
+// Parameters:
+//   - player: The name of the player.
+//
+// Returns:
+//   - total: The reinforcement of the player (see Player.Reinforcement).
+//   - continents: A copy of the pools per continent (see ContinentReinforcementPools); nil without the rule.
+//   - err: "player not found" if the player is not in the game.
+func (w *World) ReinforcementPool(player string) (total int, continents map[string]int, err error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if !w.playerExists(player) {
+		return 0, nil, errors.New("player not found") // ERROR EXIT
+	}
+	p := w.Player(player)
+	if w.ContinentReinforcementPools {
+		continents = maps.Clone(p.ContinentReinforcement)
+		if continents == nil {
+			continents = make(map[string]int)
+		}
+	}
+	return p.Reinforcement, continents, nil // SUCCESS EXIT
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// homeContinent returns the continent with the most recruiting regions of the player.
+// Ties are broken by the number of countries and then by name. "" if the player has no countries.
+// The caller must hold the world lock.
+func (w *World) homeContinent(player string) string {
+	recruiting := make(map[string]int)
+	countries := make(map[string]int)
+	for _, c := range w.Countries {
+		if c.Occupier != nil && c.Occupier.Player == player {
+			countries[c.Continent]++
+			if c.RecruitingRegion {
+				recruiting[c.Continent]++
+			}
+		}
+	}
+
+	names := make([]string, 0, len(countries))
+	for name := range countries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	home := ""
+	for _, name := range names {
+		if home == "" || recruiting[name] > recruiting[home] || (recruiting[name] == recruiting[home] && countries[name] > countries[home]) {
+			home = name
+		}
+	}
+	return home
+}
+
+// addContinentReinforcement adds the reinforcement of the round to the continent pools of the player
+// (see CalcContinentReinforcement) and to Player.Reinforcement, which remains the total.
+// The caller must hold the world lock.
+func (w *World) addContinentReinforcement(p *Player) {
+	for continent, points := range w.CalcContinentReinforcement(p.Name) {
+		p.addReinforcement(continent, points, true)
+	}
+}
+
+// initContinentReinforcement puts the starting reinforcement of every player into the pool of their home continent.
+// The caller must hold the world lock.
+func (w *World) initContinentReinforcement() {
+	for _, p := range w.PlayerQueue {
+		p.ContinentReinforcement = make(map[string]int)
+		if home := w.homeContinent(p.Name); home != "" && p.Reinforcement > 0 {
+			p.ContinentReinforcement[home] = p.Reinforcement
+		}
+	}
+}
+
+// roundIncome returns the reinforcement of a round without the sack bonus (see Player.RoundIncome).
+// The MinReinforcementPerTurn floor applies, so a player at the floor only gains once the income exceeds it.
+// The caller must hold the world lock.
+func (w *World) roundIncome(player string) int {
+	_, countries, continents, _ := w.CalcReinforcement(player)
+	income := countries + continents
+	if countries > 0 && income < w.MinReinforcementPerTurn {
+		income = w.MinReinforcementPerTurn
+	}
+	return income
+}
+
+// eliminationReinforcement recalculates the income of the round after a player has been eliminated
+// (see EliminationReinforcement) and pays the growth to the survivors. Nothing is paid in the first round,
+// because the players have not received any income yet. With local reinforcements (see ContinentReinforcementPools),
+// the units go to the pool of the continent of the captured country that eliminated the player.
+// The caller must hold the world lock.
+func (w *World) eliminationReinforcement(captured string) {
+	if !w.EliminationReinforcement {
+		return
+	}
+	for _, p := range w.PlayerQueue {
+		if p.RoundIncome == 0 {
+			continue // no income yet
+		}
+		income := w.roundIncome(p.Name)
+		if income <= p.RoundIncome {
+			continue // a smaller income is never taken back
+		}
+		units := income - p.RoundIncome
+		p.RoundIncome = income
+		p.addReinforcement(w.Countries[captured].Continent, units, w.ContinentReinforcementPools)
+		w.Logger().Info("elimination reinforcement", "player", p.Name, "units", units, "income", income)
+	}
+}
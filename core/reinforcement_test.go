@@ -0,0 +1,170 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestWorld_CalcContinentReinforcement(t *testing.T) {
+	w := NewWorld()
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 1, "P2", c.Name)
+	}
+	for _, c := range w.Continent("Australia").Countries {
+		w.Country(c).Occupier.Player = "P1"
+	}
+	w.Country("Japan").Occupier.Player = "P1"
+
+	pools := w.CalcContinentReinforcement("P1")
+	if len(pools) != 2 || pools["Australia"] != 4+2 || pools["Asia"] != 1 {
+		t.Fatal(pools)
+	}
+
+	// the floor is earned in the home continent
+	home := w.homeContinent("P1")
+	w.MinReinforcementPerTurn = 10
+	floor := w.CalcContinentReinforcement("P1")
+	if floor[home] != pools[home]+3 || floor["Australia"]+floor["Asia"] != 10 {
+		t.Fatal(home, floor)
+	}
+
+	// no countries
+	if pools := w.CalcContinentReinforcement("P3"); len(pools) != 0 {
+		t.Fatal(pools)
+	}
+}
+
+func TestWorld_ContinentReinforcementPools(t *testing.T) {
+	w := twoPlayerWorld(t, func(w *World) {
+		w.ContinentReinforcementPools = true
+	})
+
+	// the starting pool lies in the home continent
+	active := w.PlayerQueue[0]
+	home := w.homeContinent(active.Name)
+	if active.Reinforcement < 1 || active.ContinentReinforcement[home] != active.Reinforcement {
+		t.Fatal(home, active.Reinforcement, active.ContinentReinforcement)
+	}
+
+	// recruiting regions of the player inside and outside the home continent
+	var inside, outside string
+	for _, c := range w.sortedCountryList() {
+		if !c.RecruitingRegion || c.Occupier.Player != active.Name {
+			continue
+		}
+		if c.Continent == home && inside == "" {
+			inside = c.Name
+		}
+		if c.Continent != home && outside == "" {
+			outside = c.Name
+		}
+	}
+	if inside == "" {
+		t.Fatal("no recruiting region in the home continent")
+	}
+
+	// spend only in the same continent
+	if err := w.AttackOrMove(inside, inside, 1, active.Name); err != nil {
+		t.Fatal(err)
+	}
+	if active.ContinentReinforcement[home] != active.Reinforcement {
+		t.Fatal(active.Reinforcement, active.ContinentReinforcement)
+	}
+	if outside != "" {
+		if err := w.AttackOrMove(outside, outside, 1, active.Name); err == nil || err.Error() != "not enough reinforcement in this continent" {
+			t.Fatal(err)
+		}
+	}
+
+	// income is earned per continent
+	before := active.ContinentReinforcement[home]
+	if _, err := w.AdminEndTurn(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.AdminEndTurn(); err != nil {
+		t.Fatal(err)
+	}
+	total := 0
+	for _, n := range active.ContinentReinforcement {
+		total += n
+	}
+	if total != active.Reinforcement || active.ContinentReinforcement[home] <= before {
+		t.Fatal(active.Reinforcement, active.ContinentReinforcement)
+	}
+}
+
+func TestWorld_ReinforcementPool(t *testing.T) {
+	w := twoPlayerWorld(t)
+
+	// also for the player who is not active
+	waiting := w.PlayerQueue[1]
+	waiting.Reinforcement = 7
+	if total, continents, err := w.ReinforcementPool(waiting.Name); err != nil || total != 7 || continents != nil {
+		t.Fatal(total, continents, err)
+	}
+	if _, _, err := w.ReinforcementPool("P3"); err == nil || err.Error() != "player not found" {
+		t.Fatal(err)
+	}
+
+	// local reinforcements: the pools are a copy
+	w.ContinentReinforcementPools = true
+	waiting.ContinentReinforcement = map[string]int{"Asia": 4, "Europe": 3}
+	total, continents, err := w.ReinforcementPool(waiting.Name)
+	if err != nil || total != 7 || len(continents) != 2 || continents["Asia"] != 4 {
+		t.Fatal(total, continents, err)
+	}
+	continents["Asia"] = 0
+	if waiting.ContinentReinforcement["Asia"] != 4 {
+		t.Fatal("pools not copied")
+	}
+}
+
+func TestWorld_EliminationReinforcement(t *testing.T) {
+	// P3 occupies Egypt, P2 occupies Congo, P1 the rest; P1 wipes out P3 in the second round
+	play := func(enabled, pools bool) (*World, int) {
+		w := NewWorld()
+		w.NoLog = true
+		w.EliminationReinforcement = enabled
+		w.ContinentReinforcementPools = pools
+		w.PlayerQueue = []*Player{{Name: "P1"}, {Name: "P2"}, {Name: "P3"}}
+		for _, c := range w.Countries {
+			c.Occupier = NewArmy(w, 1, "P1", c.Name)
+		}
+		w.Country("Congo").Occupier.Player = "P2"
+		w.Country("Egypt").Occupier.Player = "P3"
+		for _, p := range []string{"P1", "P2", "P3"} {
+			if _, err := w.EndTurn(p); err != nil {
+				t.Fatal(err)
+			}
+		}
+		before := w.Player("P1").Reinforcement
+		w.Country("Egypt").Invader = NewArmy(w, 1000, "P1", "North Africa")
+		if _, err := w.EndTurn("P1"); err != nil {
+			t.Fatal(err)
+		}
+		if len(w.PlayerQueue) != 2 {
+			t.Fatal("P3 not eliminated")
+		}
+		return w, w.Player("P1").Reinforcement - before
+	}
+
+	// disabled (default): nothing until the next round
+	if w, gain := play(false, false); gain != 0 || w.Player("P1").RoundIncome != 0 {
+		t.Fatal(gain, w.Player("P1").RoundIncome)
+	}
+
+	// enabled: one more country, no sack bonus
+	w, gain := play(true, false)
+	p1 := w.Player("P1")
+	if gain != 1 || p1.RoundIncome != w.roundIncome("P1") {
+		t.Fatal(gain, p1.RoundIncome)
+	}
+	if w.Player("P2").RoundIncome != 1 || w.Player("P2").Reinforcement != 1 {
+		t.Fatal(w.Player("P2"))
+	}
+
+	// local reinforcements: the units go to the continent of the captured country
+	w, gain = play(true, true)
+	if sum := w.Player("P1").ContinentReinforcement["Africa"]; gain != 1 || sum != w.CalcContinentReinforcement("P1")["Africa"] {
+		t.Fatal(gain, w.Player("P1").ContinentReinforcement)
+	}
+}
@@ -0,0 +1,183 @@
+package core
+
+import (
+	"bytes"
+	"compress/gzip"
+	crnd "crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+)
+
+// rngSource is a math/rand source that counts the generated values.
+// The state of the generator is therefore fully described by the seed and the number of calls,
+// which allows saving and restoring it exactly (see Save and Load).
+type rngSource struct {
+	seed  int64         // The seed of the generator.
+	calls uint64        // The number of values generated since seeding.
+	src   rand.Source64 // The underlying generator.
+}
+
+// newRngSource creates a new counting source with the given seed.
+func newRngSource(seed int64) *rngSource {
+	return &rngSource{seed: seed, src: rand.NewSource(seed).(rand.Source64)}
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer (see rand.Source).
+func (s *rngSource) Int63() int64 {
+	s.calls++
+	return s.src.Int63()
+}
+
+// Uint64 returns a pseudo-random 64-bit value (see rand.Source64).
+func (s *rngSource) Uint64() uint64 {
+	s.calls++
+	return s.src.Uint64()
+}
+
+// Seed resets the generator to the given seed (see rand.Source).
+func (s *rngSource) Seed(seed int64) {
+	s.seed = seed
+	s.calls = 0
+	s.src.Seed(seed)
+}
+
+// skip advances the generator by n values.
+func (s *rngSource) skip(n uint64) {
+	for i := uint64(0); i < n; i++ {
+		s.Int63()
+	}
+}
+
+// cryptoSeed returns a random seed from the operating system.
+func cryptoSeed() int64 {
+	var seed int64
+	_ = binary.Read(crnd.Reader, binary.LittleEndian, &seed)
+	return seed
+}
+
+// gzipHeader is the magic number at the beginning of gzip data (see SaveGzip).
+const gzipHeader = "\x1f\x8b"
+
+// savegame is the format of Save and Load: the public world state (see Json) plus the state
+// of the random number generator, which must not be sent to the clients.
+type savegame struct {
+	World json.RawMessage // The world as returned by Json.
+	Seed  int64           // The seed of the random number generator.
+	Calls uint64          // The number of values generated since seeding.
+}
+
+//--------  GETTER  --------------------------------------------------------------------------------------------------//
+
+// Save serializes the complete game state, including the state of the random number generator.
+// Unlike Json, a game restored with Load continues with exactly the same dice rolls as the original game.
+// The result must not be sent to the players, as it allows predicting the dice.
+// The function is thread-safe.
+//
+// Returns:
+//   - The saved game as JSON string.
+//   - An error if the world cannot be serialized.
+func (w *World) Save() (string, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	b, err := json.Marshal(w)
+	if err != nil {
+		return "", err // ERROR EXIT
+	}
+	b, err = json.Marshal(savegame{World: b, Seed: w.src.seed, Calls: w.src.calls})
+	if err != nil {
+		return "", err // ERROR EXIT
+	}
+	return string(b), nil // SUCCESS EXIT
+}
+
+// SaveGzip is like Save, but returns the saved game compressed with gzip.
+// The JSON of a world is highly compressible, which saves disk space for big maps and long games.
+// Load detects and restores compressed saved games automatically.
+// The function is thread-safe.
+//
+// Returns:
+//   - The saved game as gzip data.
+//   - An error if the world cannot be serialized.
+func (w *World) SaveGzip() ([]byte, error) {
+	s, err := w.Save()
+	if err != nil {
+		return nil, err // ERROR EXIT
+	}
+
+	buf := new(bytes.Buffer)
+	zw := gzip.NewWriter(buf)
+	if _, err := zw.Write([]byte(s)); err != nil {
+		return nil, err // ERROR EXIT
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err // ERROR EXIT
+	}
+	return buf.Bytes(), nil // SUCCESS EXIT
+}
+
+//--------  SETTER  --------------------------------------------------------------------------------------------------//
+
+// Load restores a game saved with Save or SaveGzip, including the state of the random number generator.
+// Compressed saved games are recognized by the gzip header.
+// The function is thread-safe.
+//
+// Parameters:
+//   - s: The saved game as returned by Save or SaveGzip.
+//
+// Returns:
+//   - An error if the saved game is invalid.
+func (w *World) Load(s string) error {
+	if strings.HasPrefix(s, gzipHeader) {
+		zr, err := gzip.NewReader(strings.NewReader(s))
+		if err != nil {
+			return err // ERROR EXIT
+		}
+		b, err := io.ReadAll(zr)
+		if err != nil {
+			return err // ERROR EXIT
+		}
+		s = string(b)
+	}
+
+	var save savegame
+	if err := json.Unmarshal([]byte(s), &save); err != nil {
+		return err // ERROR EXIT
+	}
+	if len(save.World) == 0 {
+		return errors.New("saved game without world") // ERROR EXIT
+	}
+	if err := w.FromJson(string(save.World)); err != nil {
+		return err // ERROR EXIT
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.setRandom(save.Seed)
+	w.src.skip(save.Calls)
+	return nil // SUCCESS EXIT
+}
+
+// SetSeed resets the random number generator of the world to the given seed.
+// Two worlds with the same seed and the same commands produce the same game, which is useful for tests and replays.
+// The function is thread-safe.
+func (w *World) SetSeed(seed int64) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.setRandom(seed)
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// setRandom initializes the random number generator of the world with the given seed.
+// The caller must hold the world lock (or own the world exclusively).
+func (w *World) setRandom(seed int64) {
+	w.src = newRngSource(seed)
+	w.rnd = rand.New(w.src)
+}
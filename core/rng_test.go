@@ -0,0 +1,80 @@
+package core
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestWorld_SetSeed(t *testing.T) {
+	w1 := NewWorld()
+	w2 := NewWorld()
+	w1.SetSeed(42)
+	w2.SetSeed(42)
+
+	for i := 0; i < 100; i++ {
+		if a, b := w1.rnd.Intn(6), w2.rnd.Intn(6); a != b {
+			t.Fatal(i, a, b)
+		}
+	}
+}
+
+func TestWorld_SaveLoad(t *testing.T) {
+	w := NewWorld()
+	w.SetSeed(7)
+	_ = w.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	_ = w.AddPlayer("P2", color.RGBA{G: 255, A: 255})
+	w.InitPopulation()
+	w.rnd.Shuffle(10, func(i, j int) {})
+
+	save, err := w.Save()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the RNG state is not part of the public JSON
+	if strings.Contains(w.Json(), "Seed") || !strings.Contains(save, "Seed") {
+		t.Fatal("rng state in json")
+	}
+
+	// restore
+	loaded := NewWorld()
+	if err := loaded.Load(save); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Json() != w.Json() {
+		t.Fatal("world not restored")
+	}
+	for i := 0; i < 100; i++ {
+		if a, b := w.rnd.Int63(), loaded.rnd.Int63(); a != b {
+			t.Fatal(i, a, b)
+		}
+	}
+
+	// compressed
+	gz, err := w.SaveGzip()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gz) >= len(save) {
+		t.Fatal("not compressed", len(gz), len(save))
+	}
+	compressed := NewWorld()
+	if err := compressed.Load(string(gz)); err != nil {
+		t.Fatal(err)
+	}
+	if compressed.Json() != w.Json() {
+		t.Fatal("compressed world not restored")
+	}
+
+	// invalid input
+	if err := loaded.Load(string(gz[:20])); err == nil {
+		t.Fatal("truncated gzip")
+	}
+	if err := loaded.Load("{}"); err == nil {
+		t.Fatal("missing world")
+	}
+	if err := loaded.Load("no json"); err == nil {
+		t.Fatal("invalid json")
+	}
+}
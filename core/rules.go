@@ -0,0 +1,54 @@
+package core
+
+// The dice rules of a battle (see Army.Battle and WinProbability).
+const (
+	AttackDice         = 3  // The maximum number of dice the attacker rolls per battle round
+	DefendDice         = 2  // The maximum number of dice the defender rolls per battle round
+	FortressDefendDice = 3  // The maximum number of dice the defender rolls in a fortress region (see Country.FortressRegion)
+	DiceSides          = 6  // The number of sides of a die
+	MaxSackBonus       = 20 // The upper limit of the sack bonus (see CalcReinforcement)
+)
+
+// Rules are the combat and reinforcement rules of a world (see World.Rules), e.g. for an AI that computes
+// win probabilities and income itself. The other options of the world are part of its JSON (see Json).
+type Rules struct {
+	AttackDice         int  // The maximum number of attack dice per battle round (see AttackDice)
+	DefendDice         int  // The maximum number of defend dice per battle round (see DefendDice)
+	FortressDefendDice int  // The maximum number of defend dice in a fortress region (see FortressDefendDice)
+	DiceSides          int  // The number of sides of a die (see DiceSides)
+	DefenderWinsTies   bool // Equal dice are won by the defender
+	MaxCountryStrength int  // The maximum strength of an army in a country, 0 = unlimited (see World.MaxCountryStrength)
+	MinGarrison        int  // The units that must stay behind in a country, unless the country overrides it (see Country.Garrison)
+
+	MinReinforcementPerTurn int            // The floor of the reinforcement per round (see World.MinReinforcementPerTurn)
+	MaxSackBonus            int            // The upper limit of the sack bonus, which grows with the round (see MaxSackBonus)
+	ContinentPoints         map[string]int // The scaled points of the continents (Key: Continent.Name, see World.ContinentBonusScale)
+	CustomReinforcement     bool           // The host uses its own formula (see World.ReinforcementFunc), so the income cannot be computed
+}
+
+//--------  GETTER  --------------------------------------------------------------------------------------------------//
+
+// Rules returns the combat and reinforcement rules of the world.
+// The function is thread-safe.
+func (w *World) Rules() Rules {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	rules := Rules{
+		AttackDice:              AttackDice,
+		DefendDice:              DefendDice,
+		FortressDefendDice:      FortressDefendDice,
+		DiceSides:               DiceSides,
+		DefenderWinsTies:        true,
+		MaxCountryStrength:      w.MaxCountryStrength,
+		MinGarrison:             max(1, w.MinGarrison),
+		MinReinforcementPerTurn: w.MinReinforcementPerTurn,
+		MaxSackBonus:            MaxSackBonus,
+		ContinentPoints:         make(map[string]int, len(w.Continents)),
+		CustomReinforcement:     w.ReinforcementFunc != nil,
+	}
+	for name, continent := range w.Continents {
+		rules.ContinentPoints[name] = w.continentPoints(continent)
+	}
+	return rules
+}
@@ -0,0 +1,25 @@
+package core
+
+import "testing"
+
+func TestWorld_Rules(t *testing.T) {
+	w := NewWorld()
+	w.ContinentBonusScale = 2
+	w.MaxCountryStrength = 30
+
+	rules := w.Rules()
+	if rules.AttackDice != 3 || rules.DefendDice != 2 || rules.FortressDefendDice != 3 || rules.DiceSides != 6 || !rules.DefenderWinsTies {
+		t.Fatal(rules)
+	}
+	if rules.MaxCountryStrength != 30 || rules.MaxSackBonus != 20 || rules.CustomReinforcement {
+		t.Fatal(rules)
+	}
+	if len(rules.ContinentPoints) != len(w.Continents) || rules.ContinentPoints["Europe"] != 2*w.Continent("Europe").Points {
+		t.Fatal(rules.ContinentPoints)
+	}
+
+	w.ReinforcementFunc = DefaultReinforcement
+	if !w.Rules().CustomReinforcement {
+		t.Fatal("custom formula not reported")
+	}
+}
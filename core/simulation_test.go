@@ -0,0 +1,140 @@
+package core
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"log/slog"
+	"math/rand"
+	"testing"
+)
+
+// simulationMaxRounds is the number of rounds after which a simulated game is considered stuck.
+const simulationMaxRounds = 1000
+
+// TestWorld_simulation plays many complete games with randomized, aggressive players through AttackOrMove and EndTurn
+// and checks the invariants of the world after every turn. A failure reports the seed, so the game can be replayed.
+func TestWorld_simulation(t *testing.T) {
+	for seed := int64(1); seed <= 20; seed++ {
+		w := NewWorld()
+		if seed%4 == 0 {
+			var err error
+			if w, err = GenerateRandomWorld(seed, 30+int(seed), 4); err != nil {
+				t.Fatal(err)
+			}
+		}
+		w.NoLog = true
+		w.SetLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		w.SetSeed(seed)
+		players := 2 + int(seed)%4
+		for i := 1; i <= players; i++ {
+			_ = w.AddPlayer(fmt.Sprintf("P%d", i), color.RGBA{})
+		}
+		w.InitPopulation()
+
+		if err := simulateGame(w, rand.New(rand.NewSource(seed))); err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+	}
+}
+
+// simulateGame plays the game until a player has won and checks the invariants after every turn.
+func simulateGame(w *World, rnd *rand.Rand) error {
+	if err := checkInvariants(w); err != nil {
+		return fmt.Errorf("after InitPopulation: %w", err)
+	}
+	for w.Round < simulationMaxRounds {
+		if winner := w.Winner(); winner != "" {
+			if len(w.PlayerQueue) != 1 || w.PlayerQueue[0].Name != winner {
+				return fmt.Errorf("round %d: winner %q, but %d players left", w.Round, winner, len(w.PlayerQueue))
+			}
+			return nil // SUCCESS EXIT
+		}
+
+		player := w.PlayerQueue[0]
+		simulateTurn(w, rnd, player)
+		if _, err := w.EndTurn(player.Name); err != nil {
+			return fmt.Errorf("round %d: end turn of %s: %w", w.Round, player.Name, err)
+		}
+		if err := checkInvariants(w); err != nil {
+			return fmt.Errorf("round %d, subRound %d: %w", w.Round, w.SubRound, err)
+		}
+	}
+	return fmt.Errorf("no winner after %d rounds", simulationMaxRounds)
+}
+
+// simulateTurn deploys the reinforcements of the player in random recruiting regions,
+// attacks the strongest weaker neighbor of each frontline country and moves the other units towards the frontline.
+func simulateTurn(w *World, rnd *rand.Rand, player *Player) {
+	var own, recruiting []*Country
+	for _, c := range w.sortedCountryList() {
+		if c.Occupier.Player == player.Name {
+			own = append(own, c)
+			if c.RecruitingRegion {
+				recruiting = append(recruiting, c)
+			}
+		}
+	}
+
+	// reinforce
+	for player.Reinforcement > 0 && len(recruiting) > 0 {
+		c := recruiting[rnd.Intn(len(recruiting))]
+		_ = w.AttackOrMove(c.Name, c.Name, 1+rnd.Intn(player.Reinforcement), player.Name)
+	}
+
+	// attack or move
+	for _, c := range own {
+		strength := c.Occupier.Strength - 1
+		if strength < 1 {
+			continue
+		}
+		var target *Country
+		for _, n := range c.NeighborsObj() {
+			if c.IsFrontline(player.Name) {
+				if n.Occupier.Player == player.Name {
+					continue
+				}
+				weaker := n.Occupier.Strength < strength
+				if target == nil || weaker && (target.Occupier.Strength >= strength || n.Occupier.Strength > target.Occupier.Strength) {
+					target = n // the strongest enemy that is weaker than the army
+				}
+			} else if target == nil || rnd.Intn(2) == 0 {
+				target = n // a random own country
+			}
+		}
+		if target.Occupier.Player != player.Name && strength < target.Occupier.Strength && rnd.Intn(4) > 0 {
+			continue // mostly wait for a stronger army
+		}
+		_ = w.AttackOrMove(c.Name, target.Name, strength, player.Name)
+	}
+}
+
+// checkInvariants returns an error if the world is in a state that must never occur between two turns.
+func checkInvariants(w *World) error {
+	alive := make(map[string]int)
+	for _, c := range w.sortedCountryList() {
+		switch {
+		case c.Occupier == nil:
+			return fmt.Errorf("%s: no occupier", c.Name)
+		case c.Occupier.Strength < 1:
+			return fmt.Errorf("%s: occupier strength %d", c.Name, c.Occupier.Strength)
+		case c.Invader != nil:
+			return fmt.Errorf("%s: invader after the end of the turn", c.Name)
+		case !w.playerExists(c.Occupier.Player):
+			return fmt.Errorf("%s: occupier %q is not a player", c.Name, c.Occupier.Player)
+		}
+		alive[c.Occupier.Player]++
+	}
+	for _, p := range w.PlayerQueue {
+		if p.Reinforcement < 0 {
+			return fmt.Errorf("%s: reinforcement %d", p.Name, p.Reinforcement)
+		}
+		if alive[p.Name] == 0 {
+			return fmt.Errorf("%s: player without countries", p.Name)
+		}
+	}
+	if len(alive) != len(w.PlayerQueue) {
+		return fmt.Errorf("%d players occupy countries, but %d are in the queue", len(alive), len(w.PlayerQueue))
+	}
+	return nil
+}
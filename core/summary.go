@@ -0,0 +1,119 @@
+package core
+
+import (
+	"slices"
+	"strings"
+)
+
+// MatchSummary is the result of a game for all players (see World.MatchSummary).
+type MatchSummary struct {
+	Winner  string          // The winner of the game (see World.Winner), "" if there is none yet
+	Rounds  int             // The number of rounds played
+	Players []PlayerSummary // All players of the game, ordered by rank (see World.FinalRanking)
+}
+
+// PlayerSummary is the result of a single player in a MatchSummary.
+type PlayerSummary struct {
+	Name       string // The name of the player (Player.Name)
+	Rank       int    // The final rank of the player (1 = best)
+	Countries  int    // The number of countries controlled at the end of the game
+	Conquered  int    // The number of countries captured during the game (see Player.Conquered)
+	Eliminated bool   // The player was eliminated or departed before the end of the game (see World.Eliminated)
+}
+
+// PlayerStrength is the current power of a player in the game (see World.PlayerStrength), e.g. for a scoreboard.
+type PlayerStrength struct {
+	Countries     int  // The number of countries controlled by the player
+	Strength      int  // The total strength of the occupiers in these countries (invaders are not counted)
+	Reinforcement int  // The units the player can still deploy (see Player.Reinforcement)
+	Fogged        bool // Some strengths are hidden by the fog of war (see FogStrength), so Strength is a lower bound
+}
+
+//--------  GETTER  --------------------------------------------------------------------------------------------------//
+
+// FinalRanking returns the names of all players of the game, ordered by rank.
+// The winner comes first, followed by the players still in the game (most countries first, ties broken by name),
+// followed by the eliminated players (the last one to be eliminated first).
+// While the game is running, it is the current standing.
+// The function is thread-safe.
+func (w *World) FinalRanking() []string {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	ranking, _ := w.ranking()
+	names := make([]string, len(ranking))
+	for i, p := range ranking {
+		names[i] = p.Name
+	}
+	return names
+}
+
+// MatchSummary returns the result of the game for all players, ordered by rank (see FinalRanking).
+// It is meant to be called once the game is finished (see GameOverEvent), e.g. to update a ladder.
+// The function is thread-safe.
+func (w *World) MatchSummary() MatchSummary {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	ranking, alive := w.ranking()
+	summary := MatchSummary{Winner: w.winner(), Rounds: w.Round, Players: make([]PlayerSummary, len(ranking))}
+	for i, p := range ranking {
+		summary.Players[i] = PlayerSummary{
+			Name:       p.Name,
+			Rank:       i + 1,
+			Countries:  w.countryCount(p.Name),
+			Conquered:  p.Conquered,
+			Eliminated: i >= alive,
+		}
+	}
+	return summary
+}
+
+// PlayerStrength returns the number of countries and the total strength of a player.
+// In a world filtered for another player (see FogOfWar), hidden strengths are not counted.
+// The function is thread-safe.
+func (w *World) PlayerStrength(player string) PlayerStrength {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	stats := PlayerStrength{Reinforcement: w.Player(player).Reinforcement}
+	for _, c := range w.Countries {
+		if c.Occupier == nil || c.Occupier.Player != player {
+			continue
+		}
+		stats.Countries++
+		if c.Occupier.Strength == FogStrength {
+			stats.Fogged = true
+		} else {
+			stats.Strength += c.Occupier.Strength
+		}
+	}
+	return stats
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// ranking is the implementation of FinalRanking. It returns the players ordered by rank
+// and the number of players still in the game (the first entries).
+// The caller must hold the world lock.
+func (w *World) ranking() (ranking []*Player, alive int) {
+	winner := w.winner()
+	ranking = slices.Clone(w.PlayerQueue)
+	slices.SortStableFunc(ranking, func(a, b *Player) int {
+		switch {
+		case a.Name == winner:
+			return -1
+		case b.Name == winner:
+			return 1
+		}
+		if ca, cb := w.countryCount(a.Name), w.countryCount(b.Name); ca != cb {
+			return cb - ca
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	alive = len(ranking)
+	for i := len(w.Eliminated) - 1; i >= 0; i-- {
+		ranking = append(ranking, w.Eliminated[i])
+	}
+	return ranking, alive
+}
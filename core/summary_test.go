@@ -0,0 +1,88 @@
+package core
+
+import (
+	"image/color"
+	"slices"
+	"testing"
+)
+
+func TestWorld_MatchSummary(t *testing.T) {
+	w := twoPlayerWorld(t, func(w *World) {
+		_ = w.AddPlayer("P3", color.RGBA{B: 255, A: 255})
+	})
+	for name := range w.Countries {
+		_ = w.SetCountryOwner(name, "P1", 1)
+	}
+	_ = w.SetCountryOwner("Alaska", "P1", 1000)
+	_ = w.SetCountryOwner("Venezuela", "P1", 1000)
+	_ = w.SetCountryOwner("Alberta", "P2", 1)
+	_ = w.SetCountryOwner("Brazil", "P3", 1)
+	_ = w.SetCountryOwner("Peru", "P3", 1)
+
+	var over []GameOverEvent
+	w.Subscribe(func(e Event) {
+		if g, ok := e.(GameOverEvent); ok {
+			over = append(over, g)
+		}
+	})
+
+	// running game: the current standing
+	if r := w.FinalRanking(); !slices.Equal(r, []string{"P1", "P3", "P2"}) {
+		t.Fatal(r)
+	}
+
+	// P1 eliminates both players in one turn (P2 first, in alphabetical order of the countries)
+	_ = w.AttackOrMove("Alaska", "Alberta", 999, "P1")
+	_ = w.AttackOrMove("Venezuela", "Brazil", 500, "P1")
+	_ = w.AttackOrMove("Venezuela", "Peru", 499, "P1")
+	if _, err := w.EndTurn("P1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(over) != 1 || over[0].Winner != "P1" {
+		t.Fatal(over)
+	}
+
+	summary := w.MatchSummary()
+	if summary.Winner != "P1" || len(summary.Players) != 3 {
+		t.Fatal(summary)
+	}
+	want := []PlayerSummary{
+		{Name: "P1", Rank: 1, Countries: len(w.Countries), Conquered: 3},
+		{Name: "P3", Rank: 2, Eliminated: true},
+		{Name: "P2", Rank: 3, Eliminated: true},
+	}
+	if !slices.Equal(summary.Players, want) {
+		t.Fatal(summary.Players)
+	}
+	if r := w.FinalRanking(); !slices.Equal(r, []string{"P1", "P3", "P2"}) {
+		t.Fatal(r)
+	}
+}
+
+func TestWorld_PlayerStrength(t *testing.T) {
+	w := NewWorld()
+	w.PlayerQueue = []*Player{{Name: "P1", Reinforcement: 7}, {Name: "P2"}}
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 2, "P2", c.Name)
+	}
+	w.Country("Alaska").Occupier = NewArmy(w, 5, "P1", "Alaska")
+	w.Country("Alberta").Occupier = NewArmy(w, 3, "P1", "Alberta")
+
+	if s := w.PlayerStrength("P1"); s != (PlayerStrength{Countries: 2, Strength: 8, Reinforcement: 7}) {
+		t.Fatal(s)
+	}
+	if s := w.PlayerStrength("P2"); s != (PlayerStrength{Countries: 40, Strength: 80}) {
+		t.Fatal(s)
+	}
+
+	// fog of war
+	w.Country("Alberta").Occupier.Strength = FogStrength
+	if s := w.PlayerStrength("P1"); s != (PlayerStrength{Countries: 2, Strength: 5, Reinforcement: 7, Fogged: true}) {
+		t.Fatal(s)
+	}
+
+	// unknown player
+	if s := w.PlayerStrength("P3"); s != (PlayerStrength{}) {
+		t.Fatal(s)
+	}
+}
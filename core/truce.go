@@ -0,0 +1,134 @@
+package core
+
+import (
+	"errors"
+	"slices"
+)
+
+// Truce represents a non-aggression pact between two players. While a truce is active,
+// neither player can attack countries occupied by the other (see AttackOrMove).
+// A truce is created as an offer by one player and becomes active as soon as the other
+// player requests a truce with the first one as well (see RequestTruce).
+type Truce struct {
+
+	// Players holds the names of the two players bound by the truce.
+	// The first entry is the player who made the offer.
+	Players [2]string // value: Player.Name
+
+	// Rounds is the number of rounds the truce remains active.
+	// It is decremented by EndTurn at the end of every round, and the truce expires when it reaches 0.
+	Rounds int
+
+	// Active indicates whether both players have agreed to the truce.
+	// Offers that have not been accepted by the end of the round are discarded.
+	Active bool
+}
+
+//--------  GETTER  --------------------------------------------------------------------------------------------------//
+
+// HasTruce reports whether an active truce exists between the two players.
+func (w *World) HasTruce(player1, player2 string) bool {
+	for _, t := range w.Truces {
+		if t.Active && t.involves(player1, player2) {
+			return true
+		}
+	}
+	return false
+}
+
+//--------  SETTER  --------------------------------------------------------------------------------------------------//
+
+// RequestTruce requests a truce between player and other for the given number of rounds.
+// If other has already offered a truce to player, the truce becomes active for the shorter of
+// the two requested durations. Otherwise, an offer is stored that other can accept until the end of the round.
+//
+// Parameters:
+//   - player: The name of the player requesting the truce.
+//   - other: The name of the player with whom the truce is requested.
+//   - rounds: The number of rounds the truce should last. Must be greater than 0.
+//
+// Returns:
+//   - An error if any validation fails.
+//
+// Error cases:
+//   - The world is frozen.
+//   - Unknown players or a truce with oneself.
+//   - Rounds less than 1.
+//   - A truce between the players is already active.
+func (w *World) RequestTruce(player, other string, rounds int) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	// check freeze
+	if w.Freeze {
+		return errors.New("world is frozen") // ERROR EXIT
+	}
+
+	//------  validate input  -----------------------------------------//
+
+	if !w.playerExists(player) || !w.playerExists(other) {
+		return errors.New("player not found") // ERROR EXIT
+	}
+	if player == other {
+		return errors.New("cannot make a truce with yourself") // ERROR EXIT
+	}
+	if rounds < 1 {
+		return errors.New("truce rounds must be greater than 0") // ERROR EXIT
+	}
+	if w.HasTruce(player, other) {
+		return errors.New("truce already active") // ERROR EXIT
+	}
+
+	//------  offer or accept  ----------------------------------------//
+
+	for _, t := range w.Truces {
+		if !t.involves(player, other) {
+			continue
+		}
+		if t.Players[0] == other {
+			// accept the offer of the other player
+			t.Active = true
+			w.resetLegal() // the players cannot attack each other any more (see LegalMoves)
+			t.Rounds = minInt(t.Rounds, rounds)
+		} else {
+			// update own offer
+			t.Rounds = rounds
+		}
+		return nil // SUCCESS EXIT
+	}
+
+	// new offer
+	w.Truces = append(w.Truces, &Truce{Players: [2]string{player, other}, Rounds: rounds})
+	return nil // SUCCESS EXIT
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// involves reports whether the truce is between the two given players (in any order).
+func (t *Truce) involves(player1, player2 string) bool {
+	return (t.Players[0] == player1 && t.Players[1] == player2) || (t.Players[0] == player2 && t.Players[1] == player1)
+}
+
+// updateTruces is called at the end of every round. It decrements the remaining rounds of all
+// active truces, removes expired truces and discards offers that were not accepted.
+// The caller must hold the world lock.
+func (w *World) updateTruces() {
+	w.Truces = slices.DeleteFunc(w.Truces, func(t *Truce) bool {
+		if !t.Active {
+			return true // offer not accepted
+		}
+		t.Rounds--
+		return t.Rounds < 1 // expired
+	})
+}
+
+// playerExists reports whether a player with the given name is in the PlayerQueue.
+// The caller must hold the world lock.
+func (w *World) playerExists(name string) bool {
+	for _, p := range w.PlayerQueue {
+		if p != nil && p.Name == name {
+			return true
+		}
+	}
+	return false
+}
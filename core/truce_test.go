@@ -0,0 +1,81 @@
+package core
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestWorld_RequestTruce(t *testing.T) {
+	w := NewWorld()
+	w.TurnOrder = TurnOrderJoin
+	_ = w.AddPlayer("Player1", color.RGBA{R: 255, A: 255})
+	_ = w.AddPlayer("Player2", color.RGBA{G: 255, A: 255})
+	w.InitPopulation()
+
+	// errors
+	if err := w.RequestTruce("Player1", "Player9", 2); err == nil || err.Error() != "player not found" {
+		t.Fatal(err)
+	}
+	if err := w.RequestTruce("Player1", "Player1", 2); err == nil || err.Error() != "cannot make a truce with yourself" {
+		t.Fatal(err)
+	}
+	if err := w.RequestTruce("Player1", "Player2", 0); err == nil || err.Error() != "truce rounds must be greater than 0" {
+		t.Fatal(err)
+	}
+
+	// offer
+	if err := w.RequestTruce("Player1", "Player2", 3); err != nil {
+		t.Fatal(err)
+	}
+	if w.HasTruce("Player1", "Player2") {
+		t.Fatal("truce should not be active")
+	}
+
+	// accept
+	if err := w.RequestTruce("Player2", "Player1", 2); err != nil {
+		t.Fatal(err)
+	}
+	if !w.HasTruce("Player1", "Player2") || !w.HasTruce("Player2", "Player1") || w.Truces[0].Rounds != 2 {
+		t.Fatal("truce should be active")
+	}
+	if err := w.RequestTruce("Player2", "Player1", 2); err == nil || err.Error() != "truce already active" {
+		t.Fatal(err)
+	}
+
+	// attack is rejected
+	w.Country("Alaska").Occupier.Player = "Player1"
+	w.Country("Alaska").Occupier.Strength = 5
+	w.Country("Kamchatka").Occupier.Player = "Player2"
+	if err := w.AttackOrMove("Alaska", "Kamchatka", 1, "Player1"); err == nil || err.Error() != "attack violates truce" {
+		t.Fatal(err)
+	}
+
+	// truce expires after two rounds
+	for i := 0; i < 4; i++ {
+		if !w.HasTruce("Player1", "Player2") {
+			t.Fatalf("truce expired too early: %d", i)
+		}
+		if _, err := w.AdminEndTurn(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if w.HasTruce("Player1", "Player2") || len(w.Truces) != 0 {
+		t.Fatal("truce should be expired")
+	}
+}
+
+func TestWorld_RequestTruce_offerDiscarded(t *testing.T) {
+	w := NewWorld()
+	_ = w.AddPlayer("Player1", color.RGBA{R: 255, A: 255})
+	_ = w.AddPlayer("Player2", color.RGBA{G: 255, A: 255})
+	w.InitPopulation()
+
+	if err := w.RequestTruce("Player1", "Player2", 3); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = w.AdminEndTurn()
+	_, _ = w.AdminEndTurn()
+	if len(w.Truces) != 0 {
+		t.Fatal("offer should be discarded")
+	}
+}
@@ -0,0 +1,56 @@
+package core
+
+// TurnResult summarizes everything EndTurn has resolved, so a caller (e.g. the GUI for animations)
+// does not have to diff the world or subscribe to events (see World.Subscribe).
+type TurnResult struct {
+	Player         string         // The player who ended the turn, "" if the turn was ended by an admin (see World.AdminEndTurn)
+	Battles        []BattleEvent  // The battles of the turn in the order they were fought
+	Captures       []CaptureEvent // The countries that changed their owner in these battles
+	Eliminated     []string       // The players who have left the game with this turn (see World.Eliminated)
+	Discarded      int            // The unspent reinforcement the player has lost (see World.DiscardReinforcement)
+	NewRound       bool           // The turn has completed the round and a new one has started
+	Reinforcements map[string]int // The units awarded at the start of the new round (Key: Player.Name); nil without a new round
+	Finished       bool           // The game has ended with this turn (see GameOverEvent)
+	Winner         string         // The winner of the game if it has ended, otherwise ""
+}
+
+// TurnInfo is the current turn and the order of the players (see World.TurnInfo).
+// It is a small extract of the world for clients that only poll whose turn it is.
+type TurnInfo struct {
+	Round    int      // The current round (see World.Round)
+	SubRound int      // The turns played in the current round (see World.SubRound)
+	Active   string   // The active player, "" if the world is frozen or has no players (see World.Turn)
+	Queue    []string // The names of all players in the order of their turns (see World.PlayerQueue)
+}
+
+//--------  GETTER  --------------------------------------------------------------------------------------------------//
+
+// TurnInfo returns the current turn together with the order of the players.
+// The function is thread-safe.
+func (w *World) TurnInfo() TurnInfo {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	info := TurnInfo{Round: w.Round, SubRound: w.SubRound, Queue: make([]string, len(w.PlayerQueue))}
+	for i, p := range w.PlayerQueue {
+		info.Queue[i] = p.Name
+	}
+	if !w.Freeze && len(w.PlayerQueue) > 0 {
+		info.Active = w.PlayerQueue[0].Name
+	}
+	return info
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// addEvents sorts the battles and captures of the events into the result.
+func (r *TurnResult) addEvents(events []Event) {
+	for _, e := range events {
+		switch e := e.(type) {
+		case BattleEvent:
+			r.Battles = append(r.Battles, e)
+		case CaptureEvent:
+			r.Captures = append(r.Captures, e)
+		}
+	}
+}
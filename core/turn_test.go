@@ -0,0 +1,37 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWorld_TurnInfo(t *testing.T) {
+	w := NewWorld()
+	w.NoLog = true
+
+	// no players
+	if info := w.TurnInfo(); info.Active != "" || info.Queue == nil || len(info.Queue) != 0 {
+		t.Fatal(info)
+	}
+
+	// running game
+	w.PlayerQueue = []*Player{{Name: "P1"}, {Name: "P2"}, {Name: "P3"}}
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 1, "P1", c.Name)
+	}
+	w.Country("Congo").Occupier.Player = "P2"
+	w.Country("Egypt").Occupier.Player = "P3"
+	if _, err := w.EndTurn("P1"); err != nil {
+		t.Fatal(err)
+	}
+	info := w.TurnInfo()
+	if info.Round != 0 || info.SubRound != 1 || info.Active != "P2" || !slices.Equal(info.Queue, []string{"P2", "P3", "P1"}) {
+		t.Fatal(info)
+	}
+
+	// frozen
+	w.Freeze = true
+	if info := w.TurnInfo(); info.Active != "" || len(info.Queue) != 3 {
+		t.Fatal(info)
+	}
+}
@@ -0,0 +1,129 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+//--------  GETTER  --------------------------------------------------------------------------------------------------//
+
+// Validate checks whether the map of the world is a valid game board. It is used for generated maps
+// (see GenerateRandomWorld) and can be used for custom maps before a game is started.
+// The function is thread-safe.
+//
+// Returns:
+//   - nil if the map is valid, otherwise an error describing the first problem found.
+//
+// Error cases:
+//   - No countries or continents, or a map key that does not match the name.
+//   - A country whose continent does not exist or does not list the country (and vice versa).
+//   - A position outside of CountryPosScaleWidth x CountryPosScaleHeight.
+//   - A country without neighbors, with itself, an unknown or a duplicate neighbor, or a one-way neighborhood.
+//   - Invalid region flags: a fortress region must be a recruiting region and cannot be a border region,
+//     a border region cannot be a recruiting region, and every country must have at least one of the flags.
+//   - Countries that cannot be reached from all other countries (the map is not connected).
+func (w *World) Validate() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if len(w.Countries) == 0 || len(w.Continents) == 0 {
+		return errors.New("no countries or continents") // ERROR EXIT
+	}
+
+	// continents
+	for _, name := range sortedKeys(w.Continents) {
+		ctt := w.Continents[name]
+		if ctt == nil || ctt.Name != name {
+			return fmt.Errorf("continent %q: invalid name", name) // ERROR EXIT
+		}
+		if len(ctt.Countries) == 0 {
+			return fmt.Errorf("continent %q: no countries", name) // ERROR EXIT
+		}
+		for _, c := range ctt.Countries {
+			if country := w.Countries[c]; country == nil || country.Continent != name {
+				return fmt.Errorf("continent %q: invalid country %q", name, c) // ERROR EXIT
+			}
+		}
+	}
+
+	// countries
+	for _, name := range sortedKeys(w.Countries) {
+		c := w.Countries[name]
+		if c == nil || c.Name != name {
+			return fmt.Errorf("country %q: invalid name", name) // ERROR EXIT
+		}
+
+		// continent
+		if ctt := w.Continents[c.Continent]; ctt == nil || !slices.Contains(ctt.Countries, name) {
+			return fmt.Errorf("country %q: invalid continent %q", name, c.Continent) // ERROR EXIT
+		}
+
+		// position
+		if c.Position[0] < 0 || c.Position[0] > CountryPosScaleWidth || c.Position[1] < 0 || c.Position[1] > CountryPosScaleHeight {
+			return fmt.Errorf("country %q: position %v out of range", name, c.Position) // ERROR EXIT
+		}
+
+		// neighbors
+		if len(c.Neighbors) == 0 {
+			return fmt.Errorf("country %q: no neighbors", name) // ERROR EXIT
+		}
+		for i, n := range c.Neighbors {
+			neighbor := w.Countries[n]
+			switch {
+			case n == name:
+				return fmt.Errorf("country %q: neighbor of itself", name) // ERROR EXIT
+			case neighbor == nil:
+				return fmt.Errorf("country %q: unknown neighbor %q", name, n) // ERROR EXIT
+			case slices.Contains(c.Neighbors[:i], n):
+				return fmt.Errorf("country %q: duplicate neighbor %q", name, n) // ERROR EXIT
+			case !slices.Contains(neighbor.Neighbors, name):
+				return fmt.Errorf("country %q: no back link from neighbor %q", name, n) // ERROR EXIT
+			}
+		}
+
+		// region flags
+		if c.FortressRegion && (c.BorderRegion || !c.RecruitingRegion) {
+			return fmt.Errorf("country %q: a fortress region must be a recruiting region and not a border region", name) // ERROR EXIT
+		}
+		if c.BorderRegion && c.RecruitingRegion {
+			return fmt.Errorf("country %q: a border region cannot be a recruiting region", name) // ERROR EXIT
+		}
+		if !c.FortressRegion && !c.BorderRegion && !c.RecruitingRegion {
+			return fmt.Errorf("country %q: no region flag", name) // ERROR EXIT
+		}
+	}
+
+	// connected
+	start := sortedKeys(w.Countries)[0]
+	reached := map[string]bool{start: true}
+	queue := []string{start}
+	for len(queue) > 0 {
+		c := w.Countries[queue[0]]
+		queue = queue[1:]
+		for _, n := range c.Neighbors {
+			if !reached[n] {
+				reached[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	if len(reached) != len(w.Countries) {
+		return fmt.Errorf("the map is not connected: %d of %d countries reachable from %q", len(reached), len(w.Countries), start) // ERROR EXIT
+	}
+
+	return nil // SUCCESS EXIT
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// sortedKeys returns the keys of the map in alphabetical order, so checks report problems deterministically.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
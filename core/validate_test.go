@@ -0,0 +1,49 @@
+package core
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWorld_Validate(t *testing.T) {
+	if err := NewWorld().Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(w *World)
+		wantErr string
+	}{
+		{"no countries", func(w *World) { w.Countries = nil }, "no countries or continents"},
+		{"continent without country", func(w *World) { w.Continents["Atlantis"] = &Continent{Name: "Atlantis"} }, `continent "Atlantis": no countries`},
+		{"continent link", func(w *World) { w.Country("Alaska").Continent = "Asia" }, `invalid country "Alaska"`},
+		{"position", func(w *World) { w.Country("Alaska").Position = [2]int{-1, 0} }, "out of range"},
+		{"one-way neighbor", func(w *World) { w.Country("Alaska").Neighbors = append(w.Country("Alaska").Neighbors, "Brazil") }, `no back link from neighbor "Brazil"`},
+		{"duplicate neighbor", func(w *World) { w.Country("Alaska").Neighbors = append(w.Country("Alaska").Neighbors, "Alberta") }, `duplicate neighbor "Alberta"`},
+		{"unknown neighbor", func(w *World) { w.Country("Alaska").Neighbors = []string{"Atlantis"} }, `unknown neighbor "Atlantis"`},
+		{"fortress border", func(w *World) { w.Country("Alaska").FortressRegion = true }, "a fortress region"},
+		{"border recruiting", func(w *World) { w.Country("Alaska").RecruitingRegion = true }, "a border region cannot be a recruiting region"},
+		{"no flag", func(w *World) { w.Country("Alaska").BorderRegion = false }, "no region flag"},
+	}
+	for _, tt := range tests {
+		w := NewWorld()
+		tt.modify(w)
+		if err := w.Validate(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Fatalf("%s: got %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+
+	// not connected: cut South America off
+	w := NewWorld()
+	cut := func(a, b string) {
+		w.Country(a).Neighbors = slices.DeleteFunc(w.Country(a).Neighbors, func(n string) bool { return n == b })
+		w.Country(b).Neighbors = slices.DeleteFunc(w.Country(b).Neighbors, func(n string) bool { return n == a })
+	}
+	cut("Venezuela", "Central America")
+	cut("Brazil", "North Africa")
+	if err := w.Validate(); err == nil || !strings.Contains(err.Error(), "not connected") {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,169 @@
+package core
+
+import (
+	"sort"
+)
+
+// VictoryMode selects how a game is won (see VictoryCondition).
+type VictoryMode string
+
+const (
+	// VictoryLastStanding: the game is won by the last player with countries (default).
+	VictoryLastStanding VictoryMode = ""
+	// VictoryDomination: the game is won by the first player who controls all countries.
+	VictoryDomination VictoryMode = "domination"
+	// VictoryTerritory: the game is won by the first player who controls at least VictoryCondition.Threshold
+	// percent of all countries at the end of two consecutive rounds, i.e. for a full round.
+	VictoryTerritory VictoryMode = "territory"
+	// VictoryCapital: every player gets a capital at the start of the game (see Player.Capital).
+	// The game is won by the first player who controls the capitals of all remaining players.
+	VictoryCapital VictoryMode = "capital"
+)
+
+// VictoryCondition configures how a game is won. It is evaluated by EndTurn at the end of every round.
+// As soon as a player has won, the winner is stored in World.Victor and the world is frozen.
+// The zero value is the classic last-player-standing rule, which never freezes the world.
+type VictoryCondition struct {
+
+	// Mode is the rule that decides the winner (e.g. VictoryDomination).
+	Mode VictoryMode
+
+	// Threshold is the percentage of all countries a player must control in the VictoryTerritory mode (e.g. 70).
+	// It is ignored by all other modes.
+	Threshold int
+}
+
+//--------  GETTER  --------------------------------------------------------------------------------------------------//
+
+// Winner returns the name of the player who has won the game according to the VictoryCondition.
+// In the default last-player-standing mode, this is the only remaining player in the PlayerQueue
+// once the game has been played for at least one round.
+// The function is thread-safe.
+//
+// Returns:
+//   - The name of the winner (Player.Name) or "" if the game has not been decided yet.
+func (w *World) Winner() string {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.winner()
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// winner is the implementation of Winner.
+// The caller must hold the world lock.
+func (w *World) winner() string {
+	if w.Victor != "" {
+		return w.Victor
+	}
+	if w.VictoryCondition.Mode == VictoryLastStanding && w.Round > 0 && len(w.PlayerQueue) == 1 {
+		return w.PlayerQueue[0].Name
+	}
+	return ""
+}
+
+// checkVictory evaluates the VictoryCondition at the end of a round (see EndTurn).
+// If a player has won, World.Victor is set and the world is frozen.
+// The last player standing does not freeze the world, but the game is finished as well (see Phase).
+// The caller must hold the world lock.
+func (w *World) checkVictory() {
+	winner := ""
+
+	switch w.VictoryCondition.Mode {
+	case VictoryLastStanding:
+		if len(w.PlayerQueue) == 1 {
+			w.Phase = PhaseFinished
+		}
+	case VictoryDomination:
+		winner = w.territoryLeader(100)
+	case VictoryTerritory:
+		// the threshold must be held at the end of two consecutive rounds
+		leader := w.territoryLeader(w.VictoryCondition.Threshold)
+		if leader != "" && leader == w.DominantPlayer {
+			winner = leader
+		}
+		w.DominantPlayer = leader
+	case VictoryCapital:
+		winner = w.capitalHolder()
+	}
+
+	if winner != "" {
+		w.Victor = winner
+		w.Freeze = true
+		w.Phase = PhaseFinished
+		w.Logger().Info("game over", "winner", winner, "mode", string(w.VictoryCondition.Mode), "round", w.Round)
+	}
+}
+
+// territoryLeader returns the player who controls at least percent of all countries or "" if there is none.
+// Neutral countries (see NeutralPlayer) count towards the total, but never make a leader.
+// If several players reach the threshold (only possible below 50 percent), the one with the most countries wins;
+// ties are broken by name, so the result is deterministic.
+// The caller must hold the world lock.
+func (w *World) territoryLeader(percent int) string {
+	if len(w.Countries) == 0 || percent <= 0 {
+		return ""
+	}
+
+	counts := make(map[string]int)
+	for _, c := range w.Countries {
+		if c.Occupier != nil && c.Occupier.Player != NeutralPlayer {
+			counts[c.Occupier.Player]++
+		}
+	}
+
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	leader := ""
+	for _, name := range names {
+		if counts[name]*100 >= percent*len(w.Countries) && counts[name] > counts[leader] {
+			leader = name
+		}
+	}
+	return leader
+}
+
+// capitalHolder returns the player who controls the capitals of all players in the PlayerQueue or "" if there is none.
+// The caller must hold the world lock.
+func (w *World) capitalHolder() string {
+	holder := ""
+	for _, p := range w.PlayerQueue {
+		c := w.Countries[p.Capital]
+		if c == nil || c.Occupier == nil {
+			return "" // no capital
+		}
+		if holder != "" && c.Occupier.Player != holder {
+			return "" // capitals held by different players
+		}
+		holder = c.Occupier.Player
+	}
+	return holder
+}
+
+// assignCapitals chooses the capital of every player after the countries have been distributed (see InitPopulation).
+// The capital is the first recruiting region of the player in alphabetical order, or the first country
+// if the player has no recruiting region.
+// The caller must hold the world lock.
+func (w *World) assignCapitals() {
+	for _, p := range w.PlayerQueue {
+		p.Capital = ""
+		recruiting := false
+		for _, c := range w.sortedCountryList() {
+			if c.Occupier == nil || c.Occupier.Player != p.Name {
+				continue
+			}
+			if p.Capital == "" || (c.RecruitingRegion && !recruiting) {
+				p.Capital = c.Name
+				recruiting = c.RecruitingRegion
+			}
+			if recruiting {
+				break
+			}
+		}
+	}
+}
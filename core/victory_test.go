@@ -0,0 +1,126 @@
+package core
+
+import (
+	"testing"
+)
+
+// victoryWorld creates a started world with two players, where P1 occupies the given number of countries
+// (in alphabetical order) and P2 the rest.
+func victoryWorld(t *testing.T, mode VictoryMode, p1Countries int) *World {
+	t.Helper()
+	w := twoPlayerWorld(t, func(w *World) {
+		w.VictoryCondition.Mode = mode
+	})
+	for i, c := range w.sortedCountryList() {
+		player := "P2"
+		if i < p1Countries {
+			player = "P1"
+		}
+		c.Occupier = NewArmy(w, 1, player, c.Name)
+	}
+	return w
+}
+
+// endRound ends the turns of all players.
+func endRound(t *testing.T, w *World) {
+	for i := len(w.PlayerQueue); i > 0; i-- {
+		if _, err := w.AdminEndTurn(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestWorld_Winner_lastStanding(t *testing.T) {
+	w := victoryWorld(t, VictoryLastStanding, 41)
+	endRound(t, w)
+	if winner := w.Winner(); winner != "" {
+		t.Fatal(winner)
+	}
+
+	w.Country(w.sortedCountryList()[41].Name).Occupier.Player = "P1"
+	endRound(t, w)
+	if winner := w.Winner(); winner != "P1" || w.Victor != "" || w.Freeze {
+		t.Fatal(winner, w.Victor, w.Freeze)
+	}
+}
+
+func TestWorld_Winner_domination(t *testing.T) {
+	w := victoryWorld(t, VictoryDomination, 41)
+	endRound(t, w)
+	if winner := w.Winner(); winner != "" || w.Freeze {
+		t.Fatal(winner)
+	}
+
+	w.Country(w.sortedCountryList()[41].Name).Occupier.Player = "P1"
+	endRound(t, w)
+	if winner := w.Winner(); winner != "P1" || w.Victor != "P1" || !w.Freeze {
+		t.Fatal(winner, w.Victor, w.Freeze)
+	}
+	if _, err := w.AdminEndTurn(); err == nil || err.Error() != "world is frozen" {
+		t.Fatal(err)
+	}
+}
+
+func TestWorld_Winner_territory(t *testing.T) {
+	w := victoryWorld(t, VictoryTerritory, 30) // 30 of 42 countries = 71%
+	w.VictoryCondition.Threshold = 70
+
+	// the threshold must be held for a full round
+	endRound(t, w)
+	if winner := w.Winner(); winner != "" || w.DominantPlayer != "P1" {
+		t.Fatal(winner, w.DominantPlayer)
+	}
+
+	// losing the threshold resets the tracking
+	w.Country(w.sortedCountryList()[0].Name).Occupier.Player = "P2"
+	endRound(t, w)
+	if winner := w.Winner(); winner != "" || w.DominantPlayer != "" {
+		t.Fatal(winner, w.DominantPlayer)
+	}
+
+	w.Country(w.sortedCountryList()[0].Name).Occupier.Player = "P1"
+	endRound(t, w)
+	endRound(t, w)
+	if winner := w.Winner(); winner != "P1" || !w.Freeze {
+		t.Fatal(winner, w.Freeze)
+	}
+}
+
+func TestWorld_Winner_territoryNeutral(t *testing.T) {
+	w := victoryWorld(t, VictoryTerritory, 35)
+	w.VictoryCondition.Threshold = 70
+	for _, c := range w.sortedCountryList()[:30] { // 30 of 42 countries = 71%
+		c.Occupier.Player = NeutralPlayer // e.g. left behind by a departed player
+	}
+
+	endRound(t, w)
+	endRound(t, w)
+	if winner := w.Winner(); winner != "" || w.DominantPlayer != "" || w.Victor != "" {
+		t.Fatal(winner, w.DominantPlayer, w.Victor)
+	}
+}
+
+func TestWorld_Winner_capital(t *testing.T) {
+	w := twoPlayerWorld(t, func(w *World) {
+		w.VictoryCondition.Mode = VictoryCapital
+	})
+
+	// every player starts with an own capital
+	for _, p := range w.PlayerQueue {
+		c := w.Country(p.Capital)
+		if c.Occupier == nil || c.Occupier.Player != p.Name {
+			t.Fatal(p.Name, p.Capital)
+		}
+	}
+	endRound(t, w)
+	if winner := w.Winner(); winner != "" {
+		t.Fatal(winner)
+	}
+
+	// capture the enemy capital
+	w.Country(w.Player("P2").Capital).Occupier.Player = "P1"
+	endRound(t, w)
+	if winner := w.Winner(); winner != "P1" || !w.Freeze {
+		t.Fatal(winner, w.Freeze)
+	}
+}
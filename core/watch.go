@@ -0,0 +1,166 @@
+package core
+
+import (
+	"slices"
+)
+
+// CountryEvent describes the change of a single country by one call of AttackOrMove, AttackOrMoveRounds,
+// ReinforceAll or EndTurn (see World.WatchCountry). It holds the state of the country before and after the call,
+// so a watcher can tell what has happened (see OwnerChanged, StrengthChanged and InvaderArrived).
+type CountryEvent struct {
+	Country            string // The name of the country (Country.Name)
+	OldOwner, Owner    string // The occupying player before and after the change (Player.Name), "" if unoccupied
+	OldStrength        int    // The strength of the occupier before the change
+	Strength           int    // The strength of the occupier after the change
+	OldInvader         string // The player of the invader army before the change, "" if there was none
+	Invader            string // The player of the invader army after the change, "" if there is none
+	OldInvaderStrength int    // The strength of the invader army before the change
+	InvaderStrength    int    // The strength of the invader army after the change
+	Round              int    // The round in which the change happened
+}
+
+// EventRound returns the round in which the country has changed.
+func (e CountryEvent) EventRound() int {
+	return e.Round
+}
+
+// OwnerChanged reports whether the country has been captured (or has become neutral).
+func (e CountryEvent) OwnerChanged() bool {
+	return e.OldOwner != e.Owner
+}
+
+// StrengthChanged reports whether the strength of the occupier has changed, e.g. by reinforcements or a battle.
+func (e CountryEvent) StrengthChanged() bool {
+	return e.OldStrength != e.Strength
+}
+
+// InvaderArrived reports whether an invader army has been sent to the country: an attack, a move or reinforcements,
+// which are merged with the occupier at the end of the turn.
+func (e CountryEvent) InvaderArrived() bool {
+	return e.OldInvader == "" && e.Invader != ""
+}
+
+// watcher is a registered country handler (see World.WatchCountry).
+type watcher struct {
+	id      int
+	country string
+	fn      func(CountryEvent)
+}
+
+// countryState is the part of a country that is reported to watchers (see CountryEvent).
+type countryState struct {
+	owner, invader            string
+	strength, invaderStrength int
+}
+
+//--------  SETTER  --------------------------------------------------------------------------------------------------//
+
+// WatchCountry registers a function that is called whenever a single country changes: its owner, the strength
+// of its occupier or its invader army. It is the fine-grained counterpart of Subscribe, e.g. for dashboards
+// or scenario scripts with objectives tied to specific territories.
+//
+// The changes made by AttackOrMove, AttackOrMoveRounds, ReinforceAll and EndTurn are reported, one CountryEvent
+// per country and call. Like listeners, the watchers are called synchronously in the order of registration
+// after the world lock has been released, so they may call methods of the world. Watchers are runtime only:
+// they are neither serialized nor copied by Clone and DeepCopy.
+//
+// Parameters:
+//   - name: The name of the country (Country.Name). Unknown countries never change.
+//   - fn: The function that is called for each change.
+//
+// Returns:
+//   - A function that removes the watcher again.
+func (w *World) WatchCountry(name string, fn func(CountryEvent)) (unwatch func()) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.listenerID++
+	id := w.listenerID
+	w.watchers = append(w.watchers, watcher{id: id, country: name, fn: fn})
+
+	return func() {
+		w.lock.Lock()
+		defer w.lock.Unlock()
+
+		w.watchers = slices.DeleteFunc(w.watchers, func(wt watcher) bool {
+			return wt.id == id
+		})
+	}
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// watchState returns the state of all watched countries before a change (see watchChanges).
+// Without watchers it returns nil, so the game loop pays nothing.
+// The caller must hold the world lock.
+func (w *World) watchState() map[string]countryState {
+	if len(w.watchers) == 0 {
+		return nil
+	}
+	states := make(map[string]countryState, len(w.watchers))
+	for _, wt := range w.watchers {
+		if c := w.Countries[wt.country]; c != nil {
+			states[wt.country] = stateOf(c)
+		}
+	}
+	return states
+}
+
+// watchChanges compares the watched countries with their state before the change
+// and returns an event for every changed country, in alphabetical order.
+// The caller must hold the world lock.
+func (w *World) watchChanges(before map[string]countryState) []CountryEvent {
+	names := make([]string, 0, len(before))
+	for name := range before {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var changes []CountryEvent
+	for _, name := range names {
+		old, now := before[name], stateOf(w.Countries[name])
+		if old == now {
+			continue
+		}
+		changes = append(changes, CountryEvent{
+			Country:  name,
+			OldOwner: old.owner, Owner: now.owner,
+			OldStrength: old.strength, Strength: now.strength,
+			OldInvader: old.invader, Invader: now.invader,
+			OldInvaderStrength: old.invaderStrength, InvaderStrength: now.invaderStrength,
+			Round: w.Round,
+		})
+	}
+	return changes
+}
+
+// notifyWatchers calls the watchers of the changed countries.
+// The caller must NOT hold the world lock.
+func (w *World) notifyWatchers(changes []CountryEvent) {
+	if len(changes) == 0 {
+		return
+	}
+
+	w.lock.Lock()
+	watchers := slices.Clone(w.watchers)
+	w.lock.Unlock()
+
+	for _, e := range changes {
+		for _, wt := range watchers {
+			if wt.country == e.Country {
+				wt.fn(e)
+			}
+		}
+	}
+}
+
+// stateOf returns the reported state of the country (see CountryEvent).
+func stateOf(c *Country) (s countryState) {
+	if c.Occupier != nil {
+		s.owner, s.strength = c.Occupier.Player, c.Occupier.Strength
+	}
+	if c.Invader != nil {
+		s.invader, s.invaderStrength = c.Invader.Player, c.Invader.Strength
+	}
+	return s
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"testing"
+)
+
+func TestWorld_WatchCountry(t *testing.T) {
+	// P2 occupies Egypt, P1 the rest
+	w := NewWorld()
+	w.NoLog = true
+	w.PlayerQueue = []*Player{{Name: "P1", Reinforcement: 5}, {Name: "P2"}}
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 1, "P1", c.Name)
+	}
+	w.Country("Egypt").Occupier.Player = "P2"
+	w.Country("North Africa").Occupier.Strength = 1001
+	w.Country("North Africa").RecruitingRegion = true
+
+	var egypt, africa []CountryEvent
+	unwatch := w.WatchCountry("Egypt", func(e CountryEvent) { t.Fatal("removed watcher called", e) })
+	unwatch()
+	w.WatchCountry("Egypt", func(e CountryEvent) {
+		egypt = append(egypt, e)
+		_ = w.Json() // the world is unlocked
+	})
+	w.WatchCountry("North Africa", func(e CountryEvent) { africa = append(africa, e) })
+
+	// no change
+	if err := w.AttackOrMove("Egypt", "Egypt", 1, "P1"); err == nil || len(egypt) != 0 {
+		t.Fatal(err, egypt)
+	}
+
+	// reinforcement (merged at the end of the turn)
+	if err := w.AttackOrMove("North Africa", "North Africa", 2, "P1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(egypt) != 0 || len(africa) != 1 || !africa[0].InvaderArrived() || africa[0].InvaderStrength != 2 || africa[0].StrengthChanged() {
+		t.Fatal(egypt, africa)
+	}
+
+	// attack
+	if err := w.AttackOrMove("North Africa", "Egypt", 1000, "P1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(egypt) != 1 || !egypt[0].InvaderArrived() || egypt[0].Invader != "P1" || egypt[0].InvaderStrength != 1000 || egypt[0].StrengthChanged() {
+		t.Fatal(egypt)
+	}
+	if len(africa) != 2 || !africa[1].StrengthChanged() || africa[1].Strength != 1 || africa[1].InvaderArrived() {
+		t.Fatal(africa)
+	}
+
+	// battle
+	if _, err := w.EndTurn("P1"); err != nil {
+		t.Fatal(err)
+	}
+	e := egypt[len(egypt)-1]
+	if len(egypt) != 2 || !e.OwnerChanged() || e.OldOwner != "P2" || e.Owner != "P1" || e.Invader != "" || e.InvaderArrived() {
+		t.Fatal(egypt)
+	}
+	if len(africa) != 3 || africa[2].Strength != 3 || africa[2].Invader != "" {
+		t.Fatal(africa)
+	}
+
+	// runtime only
+	if c := w.DeepCopy(); len(c.watchers) != 0 {
+		t.Fatal(c.watchers)
+	}
+	if c := w.Clone(); len(c.watchers) != 0 {
+		t.Fatal(c.watchers)
+	}
+}
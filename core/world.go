@@ -1,31 +1,59 @@
 package core
 
 import (
-	crnd "crypto/rand"
-	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"image/color"
+	"log/slog"
+	"maps"
+	"math"
 	"math/rand"
 	"slices"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 )
 
 // World represents the entire game world, containing all continents, countries, and players.
 // It acts as the main data structure managing the state of the game.
+//
+// The methods documented as thread-safe lock the world, so they can be called concurrently, e.g. by all
+// connections of a server. The other methods (e.g. Country, Player or CalcReinforcement) and the exported fields
+// are not synchronized: they may only be used while no other goroutine changes the world, e.g. to configure it
+// before the server is started, or on a copy (see DeepCopy). A running game is changed with the methods,
+// such as SetFreeze instead of writing Freeze.
 type World struct {
-	rnd   *rand.Rand  // Random number generator used for various game mechanics.
-	lock  *sync.Mutex // Mutex to handle concurrent access to the world state.
+	rnd   *rand.Rand   // Random number generator used for various game mechanics.
+	src   *rngSource   // The source of rnd, which keeps track of the generator state (see Save).
+	lock  *sync.Mutex  // Mutex to handle concurrent access to the world state.
+	lobby *sync.Mutex  // Mutex of the transports for multi-step lobby operations (see LobbyLock).
+	log   *slog.Logger // Logger for game events (see Logger and SetLogger).
+
+	listeners  []listener // Event handlers (see Subscribe).
+	watchers   []watcher  // Country handlers (see WatchCountry).
+	listenerID int        // The id of the last registered listener or watcher.
+
+	legal legalCache // The cached legal moves of the active player (see LegalMoves).
+
+	// NoLog disables the detailed battle logs. It has the same effect as a logger level above debug.
 	NoLog bool
 
+	// BattleDetails records the dice of every battle in the BattleEvent (see BattleRound), even if the
+	// battle log is disabled (see NoLog), e.g. for spectators that replay the battles (see remote.Server.BattleLogSize).
+	BattleDetails bool
+
 	// Freeze indicates whether the world state is locked. When set to true,
 	// any SET-functions (such as AttackOrMove and EndTurn) have no effect,
-	// effectively preventing any changes to the world.
+	// effectively preventing any changes to the world. Use SetFreeze while the world is shared.
 	Freeze bool
 
+	// Phase is the state of the game: the lobby (players can join), playing or finished.
+	// InitPopulation starts the game, and EndTurn finishes it as soon as a player has won (see Winner).
+	// Unlike Freeze, which also pauses a running game, the phase never goes back.
+	Phase Phase
+
 	// Round keeps track of the current round number.
 	// This value increments by 1 every time all players in the PlayerQueue have completed their turn.
 	Round int
@@ -46,10 +74,148 @@ type World struct {
 
 	// PlayerQueue is a slice that maintains the turn order of players during the game.
 	// The first player in the queue is the active player. At the end of a turn,
-	// the active player is moved to the end of the queue. When new players are added,
-	// the queue is shuffled randomly to ensure a fair starting order.
+	// the active player is moved to the end of the queue. New players are appended,
+	// and InitPopulation shuffles the queue once to ensure a fair starting order (see TurnOrder).
 	// The list managing all players participating in the game.
 	PlayerQueue []*Player
+
+	// TurnOrder decides the starting order of the PlayerQueue. By default (TurnOrderShuffle), InitPopulation
+	// shuffles the queue once, so the order only depends on the seed of the world (see SetSeed) and the players.
+	// TurnOrderJoin keeps the order in which the players have joined.
+	TurnOrder TurnOrder
+
+	// RequireReady keeps the game in the lobby until every player has confirmed with SetReady (READY command)
+	// or the host starts it (START admin command), instead of starting as soon as the last player has joined.
+	// false starts the game when it is full (default).
+	RequireReady bool
+
+	// MaxPlayers is the number of seats in the lobby; the game starts when they are all taken (see RequireReady).
+	// It can be changed in the lobby with SetMaxPlayers (MAXPLAYERS admin command).
+	// 0 leaves the limit to the server or client that adds the players (see remote.NewServer).
+	MaxPlayers int
+
+	// SetupRerolls is the number of additional starting layouts InitPopulation rolls.
+	// The layout with the best (lowest) SetupFairness score is kept. 0 keeps the first layout (default).
+	SetupRerolls int
+
+	// MinReinforcementPerTurn is the minimum number of reinforcements a player receives per round
+	// (see CalcReinforcement), like the guaranteed 3 armies in the classic board game.
+	// The floor applies to the total of countries, continents and sack bonus, so it only matters for players
+	// with few countries. Players without any country get nothing. 0 disables the floor (default).
+	MinReinforcementPerTurn int
+
+	// ReinforcementFunc replaces the reinforcement formula of CalcReinforcement, e.g. for the economy of a scenario
+	// (see DefaultReinforcement). It is called with the world lock held, so it must only read the fields of the world
+	// and not call its thread-safe methods. The function is runtime configuration of the host: it is not serialized
+	// (see Json), and clients only see the resulting reinforcements. nil uses the default formula (default).
+	ReinforcementFunc func(w *World, player string) ReinforcementBreakdown `json:"-"`
+
+	// ContinentBonusScale scales the points of all continents (see Continent.Points and CalcReinforcement)
+	// to tune the balance between expansion and turtling, e.g. 0.5 halves and 2 doubles the continent bonuses.
+	// The scaled points are rounded to the nearest integer. Individual bonuses can be changed with Continent.Points.
+	// 0 and 1 keep the points unchanged (default). Negative values are invalid; they are treated as no bonus,
+	// so a continent never lowers the reinforcement.
+	ContinentBonusScale float64
+
+	// ContinentRecruitBonus is an optional rule that rewards continent control beyond the continent points.
+	// If a player controls all countries of a continent, every reinforcement placed in a recruiting region
+	// of that continent yields this percentage of additional units (e.g. 50 -> 4 reinforcements become 6 units).
+	// 0 disables the rule (default).
+	ContinentRecruitBonus int
+
+	// FirstConquestBonus is an optional rule that rewards expansion: the first country a player captures in each continent
+	// yields this number of reinforcement units once (see Player.ConqueredContinents). 0 disables the rule (default).
+	FirstConquestBonus int
+
+	// EliminationReinforcement is an optional rule that pays the income of a round again as soon as a player
+	// is eliminated in the middle of the round: the survivors whose income has grown since the start of the round
+	// (see Player.RoundIncome) get the difference immediately, instead of waiting for the next round.
+	// The sack bonus is not part of the income, so the battle that eliminates a player earns it only once,
+	// with the reinforcement of the next round. false keeps the reinforcement once per round (default).
+	EliminationReinforcement bool
+
+	// ContinentReinforcementPools is an optional rule that makes reinforcements local: they are earned per continent
+	// (see CalcContinentReinforcement) and can only be deployed in recruiting regions of the same continent
+	// (see Player.ContinentReinforcement). Player.Reinforcement remains the total of all pools.
+	// false keeps the single global pool (default).
+	ContinentReinforcementPools bool
+
+	// MaxCountryStrength is an optional limit of the units in a single country, so players must spread their forces
+	// instead of stacking them in one fortress. Reinforcements and moves that would exceed the limit are rejected
+	// (see AttackOrMove), and EndTurn clamps armies that exceed it after merging or capturing (the excess is lost).
+	// 0 disables the limit (default).
+	MaxCountryStrength int
+
+	// MinGarrison is the number of units that must stay behind in a country when its army attacks or moves
+	// (see AttackOrMove), e.g. 2 to keep the borders manned. All orders of a turn from the same country count together.
+	// Country.MinGarrison overrides it for single countries. 0 and 1 keep one man (default).
+	MinGarrison int
+
+	// DeparturePolicy decides what happens to the countries of a player who leaves a running game
+	// (see RemovePlayer): they become neutral, go to the strongest neighboring enemy, or are left empty.
+	// The default (DepartureRefuse) does not let players leave; they are only eliminated by losing their last country.
+	DeparturePolicy DeparturePolicy
+
+	// NeutralGarrison is the number of units defending a country that became neutral (see DepartureNeutral).
+	// 0 keeps the units of the departed player.
+	NeutralGarrison int
+
+	// VictoryCondition configures how the game is won (see Winner). The default is last-player-standing.
+	VictoryCondition VictoryCondition
+
+	// Victor is the name of the player who has won the game according to the VictoryCondition (see Winner).
+	// It is set by EndTurn at the end of a round, which also freezes the world. "" while the game is running.
+	Victor string
+
+	// Eliminated holds the players who have been removed from the PlayerQueue during the game, in the order
+	// of their elimination (lost their last country or departed, see RemovePlayer). It is used for the
+	// final ranking (see MatchSummary).
+	Eliminated []*Player
+
+	// DominantPlayer is the player who controlled the VictoryCondition.Threshold of all countries at the end of
+	// the last round (VictoryTerritory mode only). The player wins if they still control it at the end of the next round.
+	DominantPlayer string
+
+	// FogOfWar hides the strength of the armies in the countries a player cannot see (see Fogged and JsonFor).
+	// A player sees their own countries, the countries within FogRadius and the countries they have scouted (see Scout).
+	// false shows the whole world to everyone (default).
+	FogOfWar bool
+
+	// FogRadius is the number of hops from the own countries within which the fog of war is lifted:
+	// 0 reveals only the own countries (default), 1 also their neighbors, and a large radius approaches full visibility.
+	FogRadius int
+
+	// ScoutRounds is the number of rounds a scouted country stays revealed (see Scout), counting the current round.
+	// Values below 1 reveal the country until the end of the current round.
+	ScoutRounds int
+
+	// CapitalRule gives every player a capital (see Player.Capital and SetCapital) and selects what happens
+	// when it is captured: "eliminate" (the owner is eliminated, their countries become neutral), "transfer"
+	// (all countries of the owner go to the conqueror) or "bonus" (the conqueror gets CapitalBonus units).
+	// "" disables capitals (default), except for the capital victory mode.
+	CapitalRule CapitalRule
+
+	// CapitalBonus is the number of reinforcement units for capturing a capital with the "bonus" CapitalRule.
+	CapitalBonus int
+
+	// StepwiseAttacks allows attacks with a limit of dice rounds (see AttackOrMoveRounds).
+	// If neither side is destroyed, the surviving attackers retreat to their home base and the losses of both sides remain.
+	// false: every battle is fought until one side is destroyed (default).
+	StepwiseAttacks bool
+
+	// PeacefulRounds is an optional buildup phase: in the first rounds (while Round < PeacefulRounds), attacks are
+	// rejected, while reinforcements and moves between own countries are allowed (see AttackOrMove).
+	// 0 disables the rule (default).
+	PeacefulRounds int
+
+	// DiscardReinforcement is an optional use-it-or-lose-it rule: the reinforcement a player has not deployed
+	// is lost when they end their turn (see EndTurn). Bonuses won in the battles of that turn (e.g. FirstConquestBonus)
+	// are kept, because the player could not spend them yet. false keeps the unspent units for the next turns (default).
+	DiscardReinforcement bool
+
+	// Truces holds all truce offers and active truces between players (see RequestTruce).
+	// Players bound by an active truce cannot attack each other.
+	Truces []*Truce
 }
 
 //--------  GETTER  --------------------------------------------------------------------------------------------------//
@@ -81,16 +247,11 @@ func (w *World) Country(name string) *Country {
 }
 
 // RndCountryList generates and returns a new, randomized list of all countries in the world.
-// It creates a list of country pointers, shuffles it using the world's random number generator,
-// and then returns the shuffled list.
+// It creates a list of country pointers in alphabetical order, shuffles it using the world's random number generator,
+// and then returns the shuffled list. The canonical order makes the result reproducible with a fixed seed (see SetSeed).
 func (w *World) RndCountryList() []*Country {
-	// Create a list to hold the country pointers.
-	list := make([]*Country, 0, len(w.Countries))
-
-	// Append each country pointer from the world map to the list.
-	for _, c := range w.Countries {
-		list = append(list, c)
-	}
+	// Create a list of the country pointers in alphabetical order (a map has no stable iteration order).
+	list := w.sortedCountryList()
 
 	// Shuffle the list using the world's random number generator.
 	w.rnd.Shuffle(len(list), func(i, j int) {
@@ -101,12 +262,25 @@ func (w *World) RndCountryList() []*Country {
 	return list
 }
 
+// HasPlayer reports whether a player with the given name is in the PlayerQueue,
+// i.e. has joined the game and has not been eliminated.
+// The function is thread-safe.
+func (w *World) HasPlayer(name string) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.playerExists(name)
+}
+
 // Player retrieves a player by their name from the world's PlayerQueue.
 // If the player is not found, it returns an empty Player struct with the given name
 // and a default color of black. This ensures that the function always returns a valid Player object.
 func (w *World) Player(name string) *Player {
-	// Create a default player to return if no match is found.
+	// Create a default player to return if no match is found (e.g. the NeutralPlayer).
 	ply := &Player{Name: name, Color: color.RGBA{}}
+	if name == NeutralPlayer {
+		ply.Color = NeutralColor
+	}
 
 	// Search for the player in the PlayerQueue by name.
 	for _, p := range w.PlayerQueue {
@@ -120,13 +294,147 @@ func (w *World) Player(name string) *Player {
 	return ply
 }
 
-// CalcReinforcement calculates the total reinforcements a player receives based on:
+// Turn returns the active player (the first player in the PlayerQueue) together with the current round and sub-round.
+// The three values identify the current turn. If the world is frozen or has no players, player is empty.
+// The function is thread-safe.
+func (w *World) Turn() (player string, round, subRound int) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.Freeze || len(w.PlayerQueue) < 1 {
+		return "", w.Round, w.SubRound
+	}
+	return w.PlayerQueue[0].Name, w.Round, w.SubRound
+}
+
+// IsTurn reports whether the player can act now: the game is running, not paused, has an opponent
+// and the player is the active player. It also returns the remaining time bank of the player (see Player.TimeBank),
+// which is 0 if the game is played without a match clock.
+// The function is thread-safe.
+func (w *World) IsTurn(player string) (active bool, timeLeft time.Duration) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for _, p := range w.PlayerQueue {
+		if p.Name == player {
+			timeLeft = p.TimeBank
+		}
+	}
+	active = w.Phase == PhasePlaying && !w.Freeze && len(w.PlayerQueue) > 1 && w.PlayerQueue[0].Name == player
+	return active, timeLeft
+}
+
+// ContinentOwner returns the name of the player who controls all countries of the given continent.
+// If the countries are occupied by different players (or not occupied at all), an empty string is returned.
+func (w *World) ContinentOwner(name string) string {
+	owner := ""
+	for i, countryName := range w.Continent(name).Countries {
+		occupier := w.Country(countryName).Occupier
+		if occupier == nil {
+			return "" // not occupied
+		}
+		if i == 0 {
+			owner = occupier.Player
+		} else if occupier.Player != owner {
+			return "" // different players
+		}
+	}
+	return owner
+}
+
+// FrontlineCountries returns all countries of the player that border a country of another player (see Country.IsFrontline).
+// The list is sorted by country name.
+func (w *World) FrontlineCountries(player string) []*Country {
+	list := make([]*Country, 0)
+	for _, c := range w.Countries {
+		if c.IsFrontline(player) {
+			list = append(list, c)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// ConnectedComponents partitions the countries of the player into groups that are connected through
+// countries of the same player (supply network). Countries in different groups are cut off from each other.
+//
+// Each group is sorted by country name. The groups are sorted by size (largest first) and then
+// by the name of their first country, so the result is deterministic.
+//
+// Parameters:
+//   - player: The name of the player.
+//
+// Returns:
+//   - The connected groups of country names. Empty if the player has no countries.
+func (w *World) ConnectedComponents(player string) [][]string {
+	visited := make(map[string]bool)
+	groups := make([][]string, 0)
+
+	for name, c := range w.Countries {
+		if visited[name] || c.Occupier == nil || c.Occupier.Player != player {
+			continue
+		}
+
+		// BFS over the own countries
+		group := make([]string, 0)
+		queue := []*Country{c}
+		visited[name] = true
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			group = append(group, current.Name)
+
+			for _, n := range current.NeighborsObj() {
+				if !visited[n.Name] && n.Occupier != nil && n.Occupier.Player == player {
+					visited[n.Name] = true
+					queue = append(queue, n)
+				}
+			}
+		}
+		sort.Strings(group)
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i]) != len(groups[j]) {
+			return len(groups[i]) > len(groups[j])
+		}
+		return groups[i][0] < groups[j][0]
+	})
+	return groups
+}
+
+// CalcReinforcement calculates the reinforcements a player receives with the ReinforcementFunc of the world,
+// or with the default formula (see DefaultReinforcement).
+//
+// Parameters:
+//   - player: The name of the player for whom the reinforcement is being calculated.
+//
+// Returns:
+//   - all: The total reinforcement points the player is awarded.
+//   - countries: The reinforcement points from the number of countries controlled by the player.
+//   - continents: The reinforcement points from any continents fully controlled by the player.
+//   - sackBonus: The additional reinforcement points awarded if the player won a battle in the last round.
+func (w *World) CalcReinforcement(player string) (all, countries, continents, sackBonus int) {
+	calc := DefaultReinforcement
+	if w.ReinforcementFunc != nil {
+		calc = w.ReinforcementFunc
+	}
+	r := calc(w, player)
+	return r.All, r.Countries, r.Continents, r.SackBonus
+}
+
+// defaultReinforcement is the implementation of DefaultReinforcement.
+// It calculates the total reinforcements a player receives based on:
 //   - The number of countries they control.
 //   - Any continent bonuses for fully controlled continents.
 //   - A sack bonus for winning a battle in the last round.
 //
 // The function returns the total reinforcement points, as well as the individual contributions
-// from countries, continents, and the sack bonus.
+// from countries, continents, and the sack bonus. If the sum is below MinReinforcementPerTurn,
+// the total is raised to that minimum, while the individual contributions stay unchanged.
 //
 // Parameters:
 //   - player: The name of the player for whom the reinforcement is being calculated.
@@ -136,7 +444,7 @@ func (w *World) Player(name string) *Player {
 //   - countries: The reinforcement points from the number of countries controlled by the player.
 //   - continents: The reinforcement points from any continents fully controlled by the player.
 //   - sackBonus: The additional reinforcement points awarded if the player won a battle in the last round.
-func (w *World) CalcReinforcement(player string) (all, countries, continents, sackBonus int) {
+func (w *World) defaultReinforcement(player string) (all, countries, continents, sackBonus int) {
 
 	//------  count controlled countries  ----------------------------//
 
@@ -152,23 +460,9 @@ func (w *World) CalcReinforcement(player string) (all, countries, continents, sa
 
 	// For each continent, check if the player controls all countries within the continent.
 	for _, continent := range w.Continents {
-		// Assume the player controls the entire continent unless proven otherwise.
-		totalControl := true
-
-		// Check each country in the continent.
-		for _, countryName := range continent.Countries {
-			// Get the country object to check its occupier.
-			countryObj := w.Country(countryName)
-			if countryObj.Occupier.Player != player {
-				// The player doesn't control this country, so they don't control the entire continent.
-				totalControl = false
-				break
-			}
-		}
-
 		// If the player controls all countries in the continent, add the continent's bonus points.
-		if totalControl {
-			continents += continent.Points
+		if w.ContinentOwner(continent.Name) == player {
+			continents += w.continentPoints(continent)
 		}
 	}
 
@@ -176,7 +470,7 @@ func (w *World) CalcReinforcement(player string) (all, countries, continents, sa
 
 	// Check if the player won a battle in this last round.
 	if w.Round == w.Player(player).LastBattleWonRound {
-		sackBonus = minInt(w.Round, 20) // max bonus is 20
+		sackBonus = minInt(w.Round, MaxSackBonus)
 	}
 
 	//------  calculate total reinforcements  ------------------------//
@@ -187,6 +481,12 @@ func (w *World) CalcReinforcement(player string) (all, countries, continents, sa
 	//  - The sack bonus for winning a battle in this round.
 	all = countries + continents + sackBonus
 
+	// Apply the minimum (not for eliminated players).
+	// The individual contributions remain unchanged, so the floor is the difference between all and their sum.
+	if countries > 0 && all < w.MinReinforcementPerTurn {
+		all = w.MinReinforcementPerTurn
+	}
+
 	//------  return values  -----------------------------------------//
 
 	// Return the total reinforcements, along with the individual contributions from countries,
@@ -208,19 +508,98 @@ func (w *World) Clone() *World {
 	origJSON := w.Json()
 
 	// Initialize the new World instance with the JSON string.
-	// The `FromJson()` function also uses locking.
-	err := clone.FromJson(origJSON)
+	// The `FromViewJson()` function also uses locking; fogged occupiers are copied as they are.
+	err := clone.FromViewJson(origJSON)
 
 	if err != nil {
 		// Return `nil` in case of an error.
-		println(err.Error())
+		w.Logger().Error("clone world", "err", err)
 		return nil
 	} else {
 		// Return the cloned World instance.
+		clone.log = w.log
+		clone.ReinforcementFunc = w.ReinforcementFunc // runtime config (not serialized)
 		return clone
 	}
 }
 
+// DeepCopy creates a deep copy of the World by copying the Go structs directly.
+// It is much faster than Clone, which serializes the world to JSON and back, and is intended for hot loops
+// such as AI simulations. Both produce equivalent worlds: all exported fields are copied, the world links
+// of countries and armies point to the copy, and the copy gets a new lock and a new random number generator.
+// The logger and the ReinforcementFunc are shared; event listeners and country watchers (see Subscribe and WatchCountry) are not copied.
+// The function is thread-safe.
+//
+// Returns:
+//   - A new World instance independent of the original.
+func (w *World) DeepCopy() *World {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	// copy all fields (including the configuration)
+	c := new(World)
+	*c = *w
+
+	// not exported vars
+	c.lock = new(sync.Mutex)
+	c.lobby = new(sync.Mutex)
+	c.setRandom(cryptoSeed())
+	c.listeners = nil
+	c.watchers = nil
+	c.listenerID = 0
+	c.legal = legalCache{}
+
+	// continents
+	c.Continents = make(map[string]*Continent, len(w.Continents))
+	for name, ctt := range w.Continents {
+		cp := *ctt
+		cp.Countries = slices.Clone(ctt.Countries)
+		c.Continents[name] = &cp
+	}
+
+	// countries and armies
+	c.Countries = make(map[string]*Country, len(w.Countries))
+	for name, cnt := range w.Countries {
+		cp := *cnt
+		cp.world = c
+		cp.Neighbors = slices.Clone(cnt.Neighbors)
+		cp.Occupier = cnt.Occupier.copyTo(c)
+		cp.Invader = cnt.Invader.copyTo(c)
+		c.Countries[name] = &cp
+	}
+
+	// players
+	c.PlayerQueue = make([]*Player, 0, cap(w.PlayerQueue))
+	for _, p := range w.PlayerQueue {
+		cp := *p
+		cp.ContinentReinforcement = maps.Clone(p.ContinentReinforcement)
+		cp.ConqueredContinents = slices.Clone(p.ConqueredContinents)
+		cp.Revealed = maps.Clone(p.Revealed)
+		c.PlayerQueue = append(c.PlayerQueue, &cp)
+	}
+	if w.Eliminated != nil {
+		c.Eliminated = make([]*Player, 0, len(w.Eliminated))
+		for _, p := range w.Eliminated {
+			cp := *p
+			cp.ContinentReinforcement = maps.Clone(p.ContinentReinforcement)
+			cp.ConqueredContinents = slices.Clone(p.ConqueredContinents)
+			cp.Revealed = maps.Clone(p.Revealed)
+			c.Eliminated = append(c.Eliminated, &cp)
+		}
+	}
+
+	// truces
+	if w.Truces != nil {
+		c.Truces = make([]*Truce, 0, len(w.Truces))
+		for _, t := range w.Truces {
+			cp := *t
+			c.Truces = append(c.Truces, &cp)
+		}
+	}
+
+	return c
+}
+
 // Json converts the World object to a JSON-formatted string.
 // This method uses locking to ensure thread safety.
 //
@@ -242,61 +621,117 @@ func (w *World) Json() string {
 	}
 }
 
+// CountryJson converts a single country to a JSON-formatted string.
+// It is a lightweight alternative to Json for clients that only need one country
+// (occupier, invader, neighbors, region flags and continent).
+// This method uses locking to ensure thread safety.
+//
+// Parameters:
+//   - name: The name of the country.
+//
+// Returns:
+//   - The JSON string representing the country.
+//   - An error if the country does not exist.
+func (w *World) CountryJson(name string) (string, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	c := w.Countries[name]
+	if c == nil {
+		return "", errors.New("country not found") // ERROR EXIT
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err // ERROR EXIT
+	}
+	return string(b), nil // SUCCESS EXIT
+}
+
 //--------  SETTER  --------------------------------------------------------------------------------------------------//
 
 // FromJson initializes the world's state from a given JSON string.
 // This function reads the JSON string and updates the World object accordingly.
 // It uses locking to ensure thread safety.
+// Armies with a negative strength are rejected; a rejected JSON leaves the world unchanged.
+// The view of a player with fogged occupiers (see JsonFor) must be read with FromViewJson.
 //
 // Parameters:
 //   - `s`: The JSON string representing the world's state.
 //
 // Returns:
-//   - `error`: Returns an error in case of failure; returns `nil` on success.
+//   - `error`: Returns an error in case of failure (e.g. a negative army); returns `nil` on success.
 func (w *World) FromJson(s string) error {
-	if w.lock != nil {
-		w.lock.Lock()         // Acquire lock for thread safety.
-		defer w.lock.Unlock() // Release lock at the end of the function.
-	}
-
-	// detect error string
-	if strings.HasPrefix(s, "err") {
-		return errors.New(s)
-	}
+	return w.fromJson(s, false)
+}
 
-	// Deserialize the JSON data and update the World object.
-	if err := json.Unmarshal([]byte(s), &w); err != nil {
-		return err // Return the error in case of failure.
-	}
+// FromViewJson is FromJson for the view of a player (see JsonFor), e.g. the STATUS command of a client:
+// occupiers hidden by the fog of war keep their FogStrength. All other negative armies are rejected.
+// A game that is played on must be loaded with FromJson (or Load).
+func (w *World) FromViewJson(s string) error {
+	return w.fromJson(s, true)
+}
 
-	// ----- not exported vars ----- ///
+// SetTimeBank sets the remaining thinking time of a player (see Player.TimeBank).
+// If 'player' is empty, the time bank of all players is set.
+// The function is thread-safe.
+func (w *World) SetTimeBank(player string, d time.Duration) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
 
-	// Reinitialize the random number generator.
-	var seed int64
-	_ = binary.Read(crnd.Reader, binary.LittleEndian, &seed)
-	w.rnd = rand.New(rand.NewSource(seed))
+	for _, p := range w.PlayerQueue {
+		if p != nil && (player == "" || p.Name == player) {
+			p.TimeBank = d
+		}
+	}
+}
 
-	// Reinitialize the lock.
-	w.lock = new(sync.Mutex)
+// SetCountryOwner gives a country to a player with the given strength, e.g. for scenario setup, tests
+// or admin corrections. The occupier army is replaced by a new army of the player with the country as HomeBase.
+// A pending invader is not changed. The function bypasses the game rules, so it is not available
+// to the players over the protocol (see the admin command OWNER, which requires a frozen world).
+// The function is thread-safe.
+//
+// Parameters:
+//   - country: The name of the country.
+//   - player: The name of the new occupier (must be in the PlayerQueue).
+//   - strength: The strength of the new occupier army (at least 1).
+//
+// Error cases:
+//   - The country does not exist ("country not found").
+//   - The player does not exist ("player not found").
+//   - The strength is less than 1.
+func (w *World) SetCountryOwner(country, player string, strength int) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
 
-	// add world link to countries & armies
-	for _, c := range w.Countries {
-		c.world = w
-		if c.Occupier != nil {
-			c.Occupier.world = w
-		}
-		if c.Invader != nil {
-			c.Invader.world = w
-		}
+	c := w.Countries[country]
+	if c == nil {
+		return errors.New("country not found") // ERROR EXIT
+	}
+	if !w.playerExists(player) {
+		return errors.New("player not found") // ERROR EXIT
+	}
+	if strength < 1 {
+		return errors.New("strength must be greater than 0") // ERROR EXIT
 	}
 
-	// Success; no error occurred.
-	return nil
+	c.Occupier = NewArmy(w, strength, player, c.Name)
+	w.resetLegal()
+	return nil // SUCCESS EXIT
 }
 
 // AddPlayer adds a new player to the world with the specified name and color.
 // Returns an error if the name is empty, already exists, or if the color is nil or already taken.
 // Ensures player names are trimmed and unique, and colors are valid and unique.
+//
+// If no color is supplied (zero value color.RGBA{}), a color is derived from the name (see ColorForName).
+// If that color is already taken, the next free color of the palette is used instead.
+//
+// The name must not contain the protocol delimiter "|", line breaks or other non-printable characters,
+// and it is limited to MaxPlayerNameLength characters.
+//
+// Players can only be added in the lobby (see Phase). A late player would have no countries and would be
+// shuffled into the middle of the running turn order, so AddPlayer returns "game already started" instead.
 func (w *World) AddPlayer(name string, clr color.RGBA) error {
 	w.lock.Lock()
 	defer w.lock.Unlock()
@@ -308,6 +743,22 @@ func (w *World) AddPlayer(name string, clr color.RGBA) error {
 	if len(name) == 0 {
 		return errors.New("player name is empty")
 	}
+	if name == NeutralPlayer {
+		return errors.New("player name is reserved")
+	}
+	if err := validatePlayerName(name); err != nil {
+		return err
+	}
+
+	// The game is running or finished (see InitPopulation).
+	if w.Phase != PhaseLobby {
+		return errors.New("game already started")
+	}
+
+	// No color supplied: assign a deterministic, unused color.
+	if clr == (color.RGBA{}) {
+		clr = w.freeColorForName(name)
+	}
 
 	// Check if a player with the same name already exists in the world.
 	// Check if the specified color is already being used by another player.
@@ -331,11 +782,6 @@ func (w *World) AddPlayer(name string, clr color.RGBA) error {
 	}
 	w.PlayerQueue = append(w.PlayerQueue, newP)
 
-	// Shuffle PlayerQueue using the world's random number generator.
-	w.rnd.Shuffle(len(w.PlayerQueue), func(i, j int) {
-		w.PlayerQueue[i], w.PlayerQueue[j] = w.PlayerQueue[j], w.PlayerQueue[i]
-	})
-
 	// Return nil to indicate that the player was added successfully.
 	return nil
 }
@@ -343,15 +789,62 @@ func (w *World) AddPlayer(name string, clr color.RGBA) error {
 // InitPopulation distributes initial armies to each country in the world.
 // It randomizes the order of countries and players, then assigns one army to each country,
 // cycling through the players until all countries are occupied.
+// The same seed always results in the same starting layout (see SetSeed and RndCountryList).
+// With SetupRerolls, several layouts are rolled and the most balanced one is kept (see SetupFairness).
+// With a CapitalRule or in the VictoryCapital mode, the capitals of the players are chosen afterwards (see SetCapital).
 func (w *World) InitPopulation() {
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
+	// the board is set up from scratch (see LegalMoves)
+	w.resetLegal()
+
 	// no player
 	if len(w.PlayerQueue) < 1 {
 		return // ERROR: no player
 	}
 
+	// Set the starting order (see TurnOrder).
+	if w.TurnOrder != TurnOrderJoin {
+		w.rnd.Shuffle(len(w.PlayerQueue), func(i, j int) {
+			w.PlayerQueue[i], w.PlayerQueue[j] = w.PlayerQueue[j], w.PlayerQueue[i]
+		})
+	}
+
+	// Distribute the armies. With SetupRerolls, the distribution is repeated
+	// and the most balanced one is kept (see SetupFairness).
+	w.populate()
+	if w.SetupRerolls > 0 {
+		best := w.occupiers()
+		bestScore := w.setupFairness()
+		for i := 0; i < w.SetupRerolls; i++ {
+			w.populate()
+			if score := w.setupFairness(); score < bestScore {
+				best, bestScore = w.occupiers(), score
+			}
+		}
+		for name, player := range best {
+			w.Countries[name].Occupier = NewArmy(w, 1, player, name)
+		}
+	}
+
+	// With local reinforcements, the starting pool is placed in the home continent of every player.
+	if w.ContinentReinforcementPools {
+		w.initContinentReinforcement()
+	}
+
+	// Every player gets a capital with a CapitalRule or in the capital victory mode (see VictoryCapital).
+	if w.capitalsEnabled() {
+		w.assignCapitals()
+	}
+
+	// The game has started.
+	w.Phase = PhasePlaying
+}
+
+// populate assigns one army to each country (see InitPopulation).
+// The caller must hold the world lock.
+func (w *World) populate() {
 	// Get a randomized list of all countries.
 	list := w.RndCountryList()
 
@@ -376,19 +869,22 @@ func (w *World) InitPopulation() {
 	}
 
 	// Distribute one army per country, cycling through the players.
-	for i := 0; ; {
+	// Players with a start continent pick its countries first (see SetStartContinent).
+	granted := w.startContinents()
+	used := make([]bool, len(list))
+	for {
 		for _, p := range w.PlayerQueue {
-			if len(list) > i {
-				// Assign one army to the current country with the current player as the occupier.
-				c := list[i]
-				c.Occupier = NewArmy(w, 1, p.Name, c.Name)
-				// Pay for the army with Reinforcement points
-				p.Reinforcement--
-			} else {
+			i := nextStartCountry(list, used, p.Name, granted)
+			if i < 0 {
 				// Return once all countries are occupied.
 				return
 			}
-			i++
+			// Assign one army to the current country with the current player as the occupier.
+			c := list[i]
+			used[i] = true
+			c.Occupier = NewArmy(w, 1, p.Name, c.Name)
+			// Pay for the army with Reinforcement points
+			p.Reinforcement--
 		}
 	}
 }
@@ -398,6 +894,12 @@ func (w *World) InitPopulation() {
 // and then either moves troops or executes an attack. If a player attacks their own country, the function reinforces it
 // using available reinforcements.
 //
+// All orders of a turn are executed simultaneously by EndTurn: moving, attacking and reinforcing troops are collected
+// in the Invader of the target country and only join (or fight) the occupier when the turn ends.
+// This is intentional and applies to reinforcements as well: units deployed in a country cannot attack or move
+// on in the same turn, just like units that have moved there, so every unit acts at most once per turn.
+// The occupier strength, which limits attacks and moves, is therefore not changed by reinforcements until EndTurn.
+//
 // Parameters:
 //   - attacker: The name of the country initiating the attack or movement.
 //   - defender: The name of the neighboring country being attacked or moved into. If it matches the attacker, reinforcements are deployed.
@@ -413,152 +915,164 @@ func (w *World) InitPopulation() {
 //   - The player tries to command an army that doesn't belong to them.
 //   - Not enough reinforcements when reinforcing.
 //   - The attacker and defender countries are not neighbors.
+//   - The defender is occupied by a player with whom the attacker has an active truce.
+//   - The target is an enemy country during the first rounds (see PeacefulRounds).
+//   - No player (admins and tests use AdminAttackOrMove).
 func (w *World) AttackOrMove(attacker, defender string, strength int, player string) error {
+	if player == "" {
+		return errors.New("no player") // ERROR EXIT
+	}
+	return w.attackOrMove(attacker, defender, strength, player)
+}
+
+// AttackOrMoveRounds is like AttackOrMove, but the attack only lasts a limited number of dice rounds
+// (see StepwiseAttacks and Army.RoundLimit). If neither side is destroyed after these rounds, the surviving
+// attackers retreat to their home base at the end of the turn, and the losses of both sides remain.
+// All attacks on the same country form one invader, so the limit of the latest AttackOrMoveRounds order applies to all of them.
+// A round limit of 0 is the same as AttackOrMove.
+//
+// Parameters:
+//   - attacker, defender, strength, player: The same parameters as for AttackOrMove.
+//   - rounds: The maximum number of dice rounds (0 = until one side is destroyed).
+//
+// Returns:
+//   - An error if any validation fails.
+//
+// Error cases:
+//   - All errors of AttackOrMove.
+//   - Stepwise attacks are disabled, or the round limit is negative.
+//   - The target is not an enemy country (moves and reinforcements have no rounds).
+func (w *World) AttackOrMoveRounds(attacker, defender string, strength, rounds int, player string) error {
+	if player == "" {
+		return errors.New("no player") // ERROR EXIT
+	}
+
+	// Country events are published after the lock is released (defers run in reverse order).
+	var changes []CountryEvent
+	defer func() { w.notifyWatchers(changes) }()
+
 	w.lock.Lock()
 	defer w.lock.Unlock()
 
-	// check freeze
-	if w.Freeze {
-		return errors.New("world is frozen") // ERROR EXIT
+	// the watched countries are compared after the change (see WatchCountry)
+	before := w.watchState()
+	defer func() { changes = w.watchChanges(before) }()
+
+	// validate the command (see CanAttackOrMove)
+	if err := w.validateAttackOrMove(attacker, defender, strength, player); err != nil {
+		return err // ERROR EXIT
+	}
+	if rounds != 0 {
+		if !w.StepwiseAttacks {
+			return errors.New("stepwise attacks disabled") // ERROR EXIT
+		}
+		if rounds < 0 {
+			return errors.New("round limit must not be negative") // ERROR EXIT
+		}
+		target := w.Country(defender).Occupier
+		if attacker == defender || target == nil || target.Player == w.Country(attacker).Occupier.Player {
+			return errors.New("round limit only applies to attacks") // ERROR EXIT
+		}
 	}
 
-	//------  validate input  -----------------------------------------//
+	w.applyAttackOrMove(attacker, defender, strength, player)
+	w.Country(defender).Invader.RoundLimit = rounds
+	return nil // SUCCESS EXIT
+}
 
-	// Validate that the attacker country name is not empty
-	if attacker == "" {
-		return errors.New("attacker is empty") // ERROR EXIT
+// CanAttackOrMove reports whether AttackOrMove would accept the command, without changing the world (dry run).
+// It runs exactly the same validations as AttackOrMove (freeze, turn, ownership, neighbors, strength,
+// truces and the reinforcement rules), so AIs and the GUI can test the legality of a move without cloning the world.
+//
+// Parameters:
+//   - attacker, defender, strength, player: The same parameters as for AttackOrMove.
+//
+// Returns:
+//   - The error AttackOrMove would return, or nil if the command is legal.
+func (w *World) CanAttackOrMove(attacker, defender string, strength int, player string) error {
+	if player == "" {
+		return errors.New("no player") // ERROR EXIT
 	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
 
-	// Validate that the defender country name is not empty
-	if defender == "" {
-		return errors.New("defender is empty") // ERROR EXIT
-	}
+	return w.validateAttackOrMove(attacker, defender, strength, player)
+}
 
-	// Validate that the strength is positive and greater than 0
-	if strength < 1 {
-		return errors.New("attacker army strength must be greater than 0") // ERROR EXIT
+// EndTurn processes the end of a player's turn, simulates any ongoing battles or troop movements,
+// and transitions the game to the next player's turn. If all players have completed a turn, the game
+// round is incremented.
+//
+// Parameters:
+//   - player: The name of the player ending their turn. The player must be the one whose turn it is.
+//
+// Returns:
+//   - The summary of the resolved battles, eliminations and the new round (see TurnResult).
+//   - An error if the player is attempting to end another player's turn or if no players are found.
+//
+// Error cases:
+//   - No players found in the queue.
+//   - Player tries to end the turn of another player.
+//   - No player (admins and tests use AdminEndTurn).
+func (w *World) EndTurn(player string) (TurnResult, error) {
+	if player == "" {
+		return TurnResult{}, errors.New("no player") // ERROR EXIT
 	}
+	return w.endTurn(player)
+}
 
-	//------  get objects  --------------------------------------------//
-
-	// Player object
-	playerObj := w.Player(player) // cannot be nil
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
 
-	// Retrieve the attacker and defender country objects by name
-	attackerObj := w.Country(attacker) // cannot be nil
-	defenderObj := w.Country(defender) // cannot be nil
+// endTurn is the implementation of EndTurn and AdminEndTurn. An empty player ends the turn of the active player.
+func (w *World) endTurn(player string) (TurnResult, error) {
+	// Events are published after the lock is released (defers run in reverse order).
+	var events []Event
+	defer func() { w.publish(events) }()
+	var changes []CountryEvent
+	defer func() { w.notifyWatchers(changes) }()
 
-	// Retrieve the armies occupying the attacking and defending countries
-	attackerArmy := attackerObj.Occupier // should not be null
+	w.lock.Lock()
+	defer w.lock.Unlock()
 
-	//------  second checks  ------------------------------------------//
+	// the watched countries are compared after the change (see WatchCountry)
+	before := w.watchState()
+	defer func() { changes = w.watchChanges(before) }()
 
-	// Make sure that the player can only send orders on his own turn.
-	// If 'player' is empty, commands can always be sent.
-	if len(w.PlayerQueue) < 1 {
-		return errors.New("no player found")
-	}
-	if player != "" && w.PlayerQueue[0].Name != player {
-		return errors.New("not your turn")
+	// check freeze
+	if w.Freeze {
+		return TurnResult{}, errors.New("world is frozen") // ERROR EXIT
 	}
 
-	// check attackerArmy
-	if attackerArmy == nil {
-		return errors.New("attacker army is nil or invalid")
-	}
+	// the battles and the next turn change the board (see LegalMoves)
+	w.resetLegal()
 
-	// Make sure a player can only command his own armies.
-	// An empty player can control all armies.
-	if player != "" && attackerArmy.Player != player {
-		return errors.New("cannot command enemy armies") // ERROR EXIT
-	}
-
-	// Ensure the attacking army has enough strength to leave at least one unit behind
-	if attackerArmy.Strength-strength < 1 && attacker != defender {
-		return errors.New("at least one man must stay behind") // ERROR EXIT
-	}
-
-	// Check if the countries are neighbors (i.e., they can interact with each other)
-	if !slices.Contains(attackerObj.Neighbors, defender) && attacker != defender {
-		return errors.New("attacker and defender are not neighbors") // ERROR EXIT
-	}
-
-	//------  EXIT  ---------------------------------------------------//
-
-	// If the defender does not have an invader, create a new army for the invader
-	if defenderObj.Invader == nil {
-		// Create a new, empty army in the defender's territory representing the invader
-		defenderObj.Invader = NewArmy(w, 0, attackerArmy.Player, attackerArmy.HomeBase)
-	}
-
-	// move, attack or reinforcement
-	if attacker == defender {
-		// MODE: Reinforcement
-		//-----------------------
-
-		// check RecruitingRegion flag
-		if !defenderObj.RecruitingRegion {
-			return errors.New("cannot recruit in this region")
+	// The end of the game is published after the events of the turn.
+	finished := w.Phase == PhaseFinished
+	defer func() {
+		if !finished && w.Phase == PhaseFinished {
+			events = append(events, GameOverEvent{Winner: w.winner(), Round: w.Round})
 		}
-		// The attack on oneself is used to deploy reinforcement troops.
-		if strength <= playerObj.Reinforcement {
-			// The troops are withdrawn directly from the reinforcement pool.
-			playerObj.Reinforcement -= strength
-			defenderObj.Invader.Strength += strength
-			return nil // SUCCESS EXIT
-		} else {
-			// not enough reinforcement
-			return errors.New("not enough reinforcement") // ERROR EXIT
-		}
-
-	} else {
-		// MODE: Move or Attack
-		//-----------------------
-
-		// Handle the attack or movement
-		// Subtract the specified strength from the attacker army's strength
-		attackerArmy.Strength -= strength
-
-		// Add the moved or attacking units to the invader's strength
-		defenderObj.Invader.Strength += strength
-
-		// Return nil to indicate success with no errors
-		return nil // SUCCESS EXIT
-	}
-}
-
-// EndTurn processes the end of a player's turn, simulates any ongoing battles or troop movements,
-// and transitions the game to the next player's turn. If all players have completed a turn, the game
-// round is incremented.
-//
-// Parameters:
-//   - player: The name of the player ending their turn. The player must be the one whose turn it is.
-//
-// Returns:
-//   - An error if the player is attempting to end another player's turn or if no players are found.
-//
-// Error cases:
-//   - No players found in the queue.
-//   - Player tries to end the turn of another player.
-func (w *World) EndTurn(player string) error {
-	w.lock.Lock()
-	defer w.lock.Unlock()
-
-	// check freeze
-	if w.Freeze {
-		return errors.New("world is frozen") // ERROR EXIT
-	}
+	}()
 
 	//------  validate input  -----------------------------------------//
 
 	// Ensure that the player can only end their own turn.
-	// If 'player' is empty, all turns can be ended (for debug or admin purposes).
+	// If 'player' is empty, all turns can be ended (see AdminEndTurn).
 	// If the player does not match the current active player in PlayerQueue, return an error.
 	if len(w.PlayerQueue) <= 1 {
-		return errors.New("no other player found") // ERROR: No or one player in the queue.
+		return TurnResult{}, errors.New("no other player found") // ERROR: No or one player in the queue.
 	}
 	if player != "" && w.PlayerQueue[0].Name != player {
-		return errors.New("cannot end enemy turn") // ERROR: The player tries to end another player's turn.
+		return TurnResult{}, errors.New("cannot end enemy turn") // ERROR: The player tries to end another player's turn.
+	}
+
+	turn := TurnResult{Player: player}
+	eliminated := len(w.Eliminated)
+
+	// The unspent reinforcement is taken before the battles, so bonuses won in them are kept (see DiscardReinforcement).
+	old := w.PlayerQueue[0]
+	if w.DiscardReinforcement {
+		turn.Discarded = w.discardReinforcement(old)
 	}
 
 	//------  simulate battles  ---------------------------------------//
@@ -566,7 +1080,8 @@ func (w *World) EndTurn(player string) error {
 	// Simulate battles or movements for all countries with an invader army.
 	// The invader either moves into the country (if they belong to the same player) or attacks the occupier
 	// (if different players).
-	for _, c := range w.Countries {
+	// The countries are processed in alphabetical order, so a seeded game rolls the same dice (see SetSeed).
+	for _, c := range w.sortedCountryList() {
 		if c.Invader != nil {
 
 			// Check if the invader belongs to the same player as the occupier.
@@ -574,31 +1089,42 @@ func (w *World) EndTurn(player string) error {
 				// MODE: Move
 				//-------------
 
-				// Troop movement: Add the invader's strength to the occupier's.
-				c.Occupier.Strength += c.Invader.Strength
+				// Troop movement: Add the invader's strength to the occupier's (see MaxCountryStrength).
+				c.Occupier.Strength = w.limitStrength(c.Occupier.Strength + c.Invader.Strength)
 
 			} else {
 				// MODE: Attack
 				//---------------
 
 				// Battle: If the players differ, an attack occurs.
-				log := c.Invader.Attack(c.Occupier, w.NoLog)
-
-				// Print the battle log to show the results of each battle.
-				for i, l := range log {
-					if i > 0 {
-						print(" | ")
-					}
-					println(l) // Outputs each event in the battle log.
+				result := c.Invader.Battle(c.Occupier, !w.battleLogEnabled() && !w.BattleDetails)
+				events = append(events, BattleEvent{Country: c.Name, Attacker: c.Invader.Player, Defender: c.Occupier.Player,
+					AttackerLosses: result.AttackerLosses, DefenderLosses: result.DefenderLosses, Captured: c.Occupier.Strength < 1, Round: w.Round,
+					Details: result.Details})
+
+				// Log the battle to show the results of each battle.
+				if len(result.Log) > 0 && w.battleLogEnabled() {
+					w.Logger().Debug("battle", "country", c.Name, "log", strings.Join(result.Log, " | "))
 				}
 
 				// If the occupier's strength drops below 1, he loses the battle.
 				if c.Occupier.Strength < 1 {
-					// Replace the occupier with the invader (the invader now controls the country).
-					c.Occupier = c.Invader
-					c.Occupier.HomeBase = c.Name
+					// The country changes its owner.
+					oldOwner := c.Occupier.Player
+					events = append(events, CaptureEvent{Country: c.Name, OldOwner: oldOwner, NewOwner: c.Invader.Player, Round: w.Round})
+					// The survivors of the invader form a fresh occupier that is stationed in the country,
+					// so no stale reference to the invader army (e.g. held by a caller) can change it.
+					c.Occupier = NewArmy(w, w.limitStrength(c.Invader.Strength), c.Invader.Player, c.Name)
 					// The attacker has won a battle.
 					c.Invader.PlayerObj().LastBattleWonRound = w.Round
+					c.Invader.PlayerObj().Conquered++
+					// The first conquest in a continent is rewarded (see FirstConquestBonus).
+					w.firstConquestBonus(c)
+					// A captured capital is handled according to the CapitalRule.
+					w.captureCapital(c, oldOwner)
+				} else if c.Invader.Strength > 0 {
+					// The attacker broke off the battle (see AttackOrMoveRounds).
+					w.retreat(c.Invader)
 				}
 			}
 
@@ -610,7 +1136,6 @@ func (w *World) EndTurn(player string) error {
 	//------  end turn and go to next player  -------------------------//
 
 	// Move the current player to the end of the queue and update the queue order.
-	old := w.PlayerQueue[0]
 	for i := 1; i < len(w.PlayerQueue); i++ {
 		w.PlayerQueue[i-1] = w.PlayerQueue[i]
 	}
@@ -621,33 +1146,483 @@ func (w *World) EndTurn(player string) error {
 	// Increment the SubRound counter, which tracks the turns of individual players within a round.
 	w.SubRound++
 
+	// Players who have lost their last country are removed immediately, so they do not get an empty turn.
+	w.removeEliminated(events)
+
 	// Check if all players have completed their turns in the current round.
 	if w.SubRound%len(w.PlayerQueue) == 0 {
 		// A new round begins as all players have completed their turns.
-
-		// Calculate and distribute reinforcements for all players.
-		var livingPlayers = make([]*Player, 0, len(w.PlayerQueue))
+		// The awarded reinforcements are the growth of the pools.
+		before := make(map[string]int, len(w.PlayerQueue))
+		for _, p := range w.PlayerQueue {
+			before[p.Name] = p.Reinforcement
+		}
+		w.newRound()
+		turn.NewRound = true
+		turn.Reinforcements = make(map[string]int, len(w.PlayerQueue))
 		for _, p := range w.PlayerQueue {
-			// calc reinforcement
-			all, countries, continents, sackBonus := w.CalcReinforcement(p.Name)
+			turn.Reinforcements[p.Name] = p.Reinforcement - before[p.Name]
+		}
+	}
+
+	// summarize the turn
+	turn.addEvents(events)
+	for _, p := range w.Eliminated[eliminated:] {
+		turn.Eliminated = append(turn.Eliminated, p.Name)
+	}
+	if w.Phase == PhaseFinished {
+		turn.Finished = true
+		turn.Winner = w.winner()
+	}
+
+	// Return the result to indicate that the turn ended successfully without errors.
+	return turn, nil
+}
+
+// attackOrMove is the implementation of AttackOrMove and AdminAttackOrMove.
+// An empty player can control all armies at any time.
+func (w *World) attackOrMove(attacker, defender string, strength int, player string) error {
+	// Country events are published after the lock is released (defers run in reverse order).
+	var changes []CountryEvent
+	defer func() { w.notifyWatchers(changes) }()
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	// the watched countries are compared after the change (see WatchCountry)
+	before := w.watchState()
+	defer func() { changes = w.watchChanges(before) }()
+
+	// validate the command (see CanAttackOrMove)
+	if err := w.validateAttackOrMove(attacker, defender, strength, player); err != nil {
+		return err // ERROR EXIT
+	}
+
+	w.applyAttackOrMove(attacker, defender, strength, player)
+	return nil // SUCCESS EXIT
+}
+
+// discardReinforcement takes the unspent reinforcement of the player at the end of their turn,
+// including the continent pools (see DiscardReinforcement).
+// The caller must hold the world lock.
+func (w *World) discardReinforcement(p *Player) int {
+	discarded := p.Reinforcement
+	p.Reinforcement = 0
+	clear(p.ContinentReinforcement)
+	if discarded > 0 {
+		w.Logger().Info("reinforcement discarded", "player", p.Name, "units", discarded)
+	}
+	return discarded
+}
+
+// newRound starts the next round after all players have had their turn (see EndTurn):
+// the reinforcements are distributed, players without countries are removed, truces are counted down
+// and the victory condition is checked.
+// The caller must hold the world lock.
+func (w *World) newRound() {
+	// Calculate and distribute reinforcements for all players.
+	var livingPlayers = make([]*Player, 0, len(w.PlayerQueue))
+	for _, p := range w.PlayerQueue {
+		// calc reinforcement
+		all, countries, continents, sackBonus := w.CalcReinforcement(p.Name)
+		if w.ContinentReinforcementPools {
+			w.addContinentReinforcement(p) // the pools add up to all
+		} else {
 			p.Reinforcement += all
-			println(fmt.Sprintf("Reinforcements %s: countries=%d, continents=%d, sackBonus=%d", p.Name, countries, continents, sackBonus))
+		}
+		if w.EliminationReinforcement {
+			p.RoundIncome = w.roundIncome(p.Name)
+		}
+		w.Logger().Info("reinforcements", "player", p.Name, "countries", countries, "continents", continents, "sackBonus", sackBonus)
+
+		// save living players
+		if countries > 0 {
+			livingPlayers = append(livingPlayers, p)
+		} else {
+			w.Eliminated = append(w.Eliminated, p)
+		}
+	}
+	w.PlayerQueue = livingPlayers
 
-			// save living players
-			if countries > 0 {
-				livingPlayers = append(livingPlayers, p)
+	// Count down truces and discard unanswered offers
+	w.updateTruces()
+
+	// Check the victory condition (freezes the world if a player has won)
+	w.checkVictory()
+
+	// Go to next Round and reset the SubRound
+	w.Round++
+	w.SubRound = 0
+
+	// Hide the scouted countries again (see Scout)
+	w.expireRevelations()
+
+	// log new round
+	w.Logger().Info("new round", "round", w.Round)
+}
+
+// removeEliminated removes the players from the PlayerQueue who have lost their last country in one of the captures.
+// It is called by EndTurn after the queue has been rotated, so the players who have already played in this round
+// are at the end of the queue. If such a player is removed, SubRound is decreased accordingly, so the round
+// still ends after every remaining player has had a turn.
+// The caller must hold the world lock.
+func (w *World) removeEliminated(events []Event) {
+	for _, e := range events {
+		capture, ok := e.(CaptureEvent)
+		if !ok || w.countryCount(capture.OldOwner) > 0 {
+			continue
+		}
+		for i, p := range w.PlayerQueue {
+			if p.Name != capture.OldOwner {
+				continue
 			}
+			if i >= len(w.PlayerQueue)-w.SubRound {
+				w.SubRound-- // the player has already played in this round
+			}
+			w.PlayerQueue = slices.Delete(w.PlayerQueue, i, i+1)
+			w.Eliminated = append(w.Eliminated, p)
+			w.Logger().Info("player eliminated", "player", p.Name, "by", capture.NewOwner, "round", w.Round)
+			w.eliminationReinforcement(capture.Country)
+			break
+		}
+	}
+}
+
+// firstConquestBonus awards the FirstConquestBonus if the occupier of the country has just captured
+// their first country in the continent of the country. The bonus is added to the pool of that continent
+// if the world uses local reinforcements (see ContinentReinforcementPools).
+// The caller must hold the world lock.
+func (w *World) firstConquestBonus(c *Country) {
+	p := c.Occupier.PlayerObj()
+	if w.FirstConquestBonus <= 0 || slices.Contains(p.ConqueredContinents, c.Continent) {
+		return
+	}
+	p.ConqueredContinents = append(p.ConqueredContinents, c.Continent)
+	p.addReinforcement(c.Continent, w.FirstConquestBonus, w.ContinentReinforcementPools)
+	w.Logger().Info("first conquest bonus", "player", p.Name, "continent", c.Continent, "bonus", w.FirstConquestBonus)
+}
+
+// continentPoints returns the reinforcement points of a continent scaled with ContinentBonusScale.
+// A negative scale is clamped to 0, so the points are never negative.
+func (w *World) continentPoints(continent *Continent) int {
+	if w.ContinentBonusScale == 0 || w.ContinentBonusScale == 1 {
+		return continent.Points
+	}
+	return int(math.Round(float64(continent.Points) * max(w.ContinentBonusScale, 0)))
+}
+
+// limitStrength returns the strength clamped to MaxCountryStrength (if enabled).
+func (w *World) limitStrength(strength int) int {
+	if w.MaxCountryStrength > 0 {
+		return min(strength, w.MaxCountryStrength)
+	}
+	return strength
+}
+
+// exceedsStrengthLimit reports whether sending strength units of the player into an own country would exceed
+// MaxCountryStrength. The units already sent this turn (the pending invader) count as well.
+// Enemy and empty countries are never limited, because the occupier is replaced or fights.
+func (w *World) exceedsStrengthLimit(c *Country, player string, strength int) bool {
+	if w.MaxCountryStrength <= 0 || c.Occupier == nil || c.Occupier.Player != player {
+		return false
+	}
+	pending := 0
+	if c.Invader != nil {
+		pending = c.Invader.Strength
+	}
+	return c.Occupier.Strength+pending+strength > w.MaxCountryStrength
+}
+
+// countryCount returns the number of countries occupied by the player.
+// The caller must hold the world lock.
+func (w *World) countryCount(player string) int {
+	n := 0
+	for _, c := range w.Countries {
+		if c.Occupier != nil && c.Occupier.Player == player {
+			n++
 		}
-		w.PlayerQueue = livingPlayers
+	}
+	return n
+}
 
-		// Go to next Round and reset the SubRound
-		w.Round++
-		w.SubRound = 0
+// recruitBonus returns the additional units for placing strength reinforcements in the given country
+// (see ContinentRecruitBonus). The player must control the whole continent of the country.
+func (w *World) recruitBonus(country *Country, player string, strength int) int {
+	if w.ContinentRecruitBonus <= 0 || w.ContinentOwner(country.Continent) != player {
+		return 0
+	}
+	return strength * w.ContinentRecruitBonus / 100
+}
 
-		// print new turn
-		println(fmt.Sprintf("\n==========  Round %d  ==========", w.Round))
+// distances returns the shortest number of hops from the nearest start country to every country
+// that is at most maxHops away (breadth-first search along the neighbors). The start countries have distance 0.
+// Unknown start countries are ignored. A negative maxHops has no limit.
+func (w *World) distances(start []*Country, maxHops int) map[string]int {
+	dist := make(map[string]int, len(w.Countries))
+	queue := make([]*Country, 0, len(start))
+	for _, c := range start {
+		if c != nil && w.Countries[c.Name] == c {
+			if _, ok := dist[c.Name]; !ok {
+				dist[c.Name] = 0
+				queue = append(queue, c)
+			}
+		}
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		d := dist[current.Name]
+		if maxHops >= 0 && d >= maxHops {
+			continue
+		}
+		for _, n := range current.Neighbors {
+			if _, visited := dist[n]; !visited && w.Countries[n] != nil {
+				dist[n] = d + 1
+				queue = append(queue, w.Countries[n])
+			}
+		}
 	}
+	return dist
+}
 
-	// Return nil to indicate that the turn ended successfully without errors.
+// retreat moves the survivors of a broken off attack back to their home base (see AttackOrMoveRounds).
+// If the home base no longer belongs to the attacker, the survivors are lost.
+// The caller must hold the world lock.
+func (w *World) retreat(invader *Army) {
+	home := w.Countries[invader.HomeBase]
+	if home == nil || home.Occupier == nil || home.Occupier.Player != invader.Player {
+		return
+	}
+	home.Occupier.Strength = w.limitStrength(home.Occupier.Strength + invader.Strength)
+}
+
+// sortedCountryList returns a list of all countries sorted by name (canonical order).
+func (w *World) sortedCountryList() []*Country {
+	list := make([]*Country, 0, len(w.Countries))
+	for _, c := range w.Countries {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// validateAttackOrMove runs all validations of AttackOrMove without changing the world (see CanAttackOrMove).
+// The caller must hold the world lock.
+func (w *World) validateAttackOrMove(attacker, defender string, strength int, player string) error {
+	// check freeze
+	if w.Freeze {
+		return errors.New("world is frozen") // ERROR EXIT
+	}
+
+	//------  validate input  -----------------------------------------//
+
+	// Validate that the attacker country name is not empty
+	if attacker == "" {
+		return errors.New("attacker is empty") // ERROR EXIT
+	}
+
+	// Validate that the defender country name is not empty
+	if defender == "" {
+		return errors.New("defender is empty") // ERROR EXIT
+	}
+
+	// Validate that the strength is positive and greater than 0
+	if strength < 1 {
+		return errors.New("attacker army strength must be greater than 0") // ERROR EXIT
+	}
+
+	//------  get objects  --------------------------------------------//
+
+	// Player object
+	playerObj := w.Player(player) // cannot be nil
+
+	// Retrieve the attacker and defender country objects by name
+	attackerObj := w.Country(attacker) // cannot be nil
+	defenderObj := w.Country(defender) // cannot be nil
+
+	// Retrieve the armies occupying the attacking and defending countries
+	attackerArmy := attackerObj.Occupier // should not be null
+
+	//------  second checks  ------------------------------------------//
+
+	// Make sure that the player can only send orders on his own turn.
+	// If 'player' is empty, commands can always be sent (see AdminAttackOrMove).
+	if len(w.PlayerQueue) < 1 {
+		return errors.New("no player found")
+	}
+	if player != "" && w.PlayerQueue[0].Name != player {
+		return errors.New("not your turn")
+	}
+
+	// check attackerArmy
+	if attackerArmy == nil {
+		return errors.New("attacker army is nil or invalid")
+	}
+
+	// Make sure a player can only command his own armies.
+	// An empty player can control all armies.
+	if player != "" && attackerArmy.Player != player {
+		return errors.New("cannot command enemy armies") // ERROR EXIT
+	}
+
+	// Ensure the attacking army has enough strength to leave the garrison behind (see MinGarrison).
+	// Earlier orders of the turn have already been deducted from the occupier (see applyAttackOrMove),
+	// so the check covers the units committed to all pending invaders together.
+	if garrison := attackerObj.Garrison(); attackerArmy.Strength-strength < garrison && attacker != defender {
+		if garrison == 1 {
+			return errors.New("at least one man must stay behind") // ERROR EXIT
+		}
+		return fmt.Errorf("at least %d men must stay behind", garrison) // ERROR EXIT
+	}
+
+	// Check if the countries are neighbors (i.e., they can interact with each other)
+	if !slices.Contains(attackerObj.Neighbors, defender) && attacker != defender {
+		return errors.New("attacker and defender are not neighbors") // ERROR EXIT
+	}
+
+	// Players with an active truce cannot attack each other
+	if defenderObj.Occupier != nil && w.HasTruce(attackerArmy.Player, defenderObj.Occupier.Player) {
+		return errors.New("attack violates truce") // ERROR EXIT
+	}
+
+	// No attacks during the buildup phase (see PeacefulRounds)
+	if w.Round < w.PeacefulRounds && attacker != defender && (defenderObj.Occupier == nil || defenderObj.Occupier.Player != attackerArmy.Player) {
+		return errors.New("no attacks in peaceful rounds") // ERROR EXIT
+	}
+
+	// Own countries cannot be reinforced beyond the limit (see MaxCountryStrength)
+	if w.exceedsStrengthLimit(defenderObj, attackerArmy.Player, strength) {
+		return errors.New("country strength limit reached") // ERROR EXIT
+	}
+
+	// Reinforcements can only be deployed in recruiting regions and only from the reinforcement pool.
+	// The country must be occupied by the player: attacker and defender are the same country here,
+	// so the army check above ("cannot command enemy armies") rejects recruiting in a foreign country.
+	if attacker == defender {
+		// check RecruitingRegion flag
+		if !defenderObj.RecruitingRegion {
+			return errors.New("cannot recruit in this region") // ERROR EXIT
+		}
+		// check the reinforcement pool
+		if strength > playerObj.Reinforcement {
+			return errors.New("not enough reinforcement") // ERROR EXIT
+		}
+		// check the pool of the continent (see ContinentReinforcementPools)
+		if w.ContinentReinforcementPools && strength > playerObj.ContinentReinforcement[defenderObj.Continent] {
+			return errors.New("not enough reinforcement in this continent") // ERROR EXIT
+		}
+	}
+
+	return nil // SUCCESS EXIT
+}
+
+// applyAttackOrMove executes a command that has been validated with validateAttackOrMove.
+// The caller must hold the world lock.
+func (w *World) applyAttackOrMove(attacker, defender string, strength int, player string) {
+	//------  get objects  --------------------------------------------//
+
+	playerObj := w.Player(player)                // cannot be nil
+	defenderObj := w.Country(defender)           // cannot be nil
+	attackerArmy := w.Country(attacker).Occupier // validated: not nil
+
+	// the strengths of both countries change (see LegalMoves)
+	w.invalidateAround(attacker, defender)
+
+	//------  EXIT  ---------------------------------------------------//
+
+	// If the defender does not have an invader, create a new army for the invader
+	if defenderObj.Invader == nil {
+		// Create a new, empty army in the defender's territory representing the invader
+		defenderObj.Invader = NewArmy(w, 0, attackerArmy.Player, attackerArmy.HomeBase)
+	}
+
+	// move, attack or reinforcement
+	if attacker == defender {
+		// MODE: Reinforcement
+		//-----------------------
+
+		// The attack on oneself is used to deploy reinforcement troops.
+		// The troops are withdrawn directly from the reinforcement pool.
+		playerObj.Reinforcement -= strength
+		if w.ContinentReinforcementPools {
+			playerObj.ContinentReinforcement[defenderObj.Continent] -= strength
+		}
+		defenderObj.Invader.Strength += strength + w.recruitBonus(defenderObj, attackerArmy.Player, strength)
+
+	} else {
+		// MODE: Move or Attack
+		//-----------------------
+
+		// Handle the attack or movement
+		// Subtract the specified strength from the attacker army's strength
+		attackerArmy.Strength -= strength
+
+		// Add the moved or attacking units to the invader's strength
+		defenderObj.Invader.Strength += strength
+	}
+}
+
+// fromJson is the implementation of FromJson and FromViewJson.
+// The JSON is read into a copy of the world, so the world only changes if the JSON is valid.
+// Fields that are missing in the JSON keep their value; the board and the players are replaced.
+func (w *World) fromJson(s string, fogged bool) error {
+	if w.lock != nil {
+		w.lock.Lock()         // Acquire lock for thread safety.
+		defer w.lock.Unlock() // Release lock at the end of the function.
+	}
+
+	// detect error string
+	if strings.HasPrefix(s, "err") {
+		return errors.New(s)
+	}
+
+	// Deserialize the JSON data into a copy, which does not share the board with the world.
+	tmp := *w
+	tmp.Continents, tmp.Countries, tmp.PlayerQueue, tmp.Eliminated, tmp.Truces = nil, nil, nil, nil, nil
+	if err := json.Unmarshal([]byte(s), &tmp); err != nil {
+		return err // Return the error in case of failure.
+	}
+
+	// reject negative armies (a fogged occupier of JsonFor has FogStrength)
+	for _, c := range tmp.Countries {
+		if c.Occupier != nil && c.Occupier.Strength < 0 && !(fogged && c.Occupier.Strength == FogStrength) {
+			return fmt.Errorf("negative strength of the occupier of %s", c.Name)
+		}
+		if c.Invader != nil && c.Invader.Strength < 0 {
+			return fmt.Errorf("negative strength of the invader of %s", c.Name)
+		}
+	}
+	*w = tmp
+
+	// ----- not exported vars ----- ///
+
+	// Reinitialize the random number generator.
+	w.setRandom(cryptoSeed())
+
+	// Initialize the lock (the lock of a shared world is kept, others may wait for it).
+	if w.lock == nil {
+		w.lock = new(sync.Mutex)
+	}
+	if w.lobby == nil {
+		w.lobby = new(sync.Mutex)
+	}
+
+	// The board has been replaced (see LegalMoves).
+	w.legal = legalCache{}
+
+	// add world link to countries & armies
+	for _, c := range w.Countries {
+		c.world = w
+		if c.Occupier != nil {
+			c.Occupier.world = w
+		}
+		if c.Invader != nil {
+			c.Invader.world = w
+		}
+	}
+
+	// Success; no error occurred.
 	return nil
 }
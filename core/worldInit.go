@@ -1,9 +1,6 @@
 package core
 
 import (
-	crnd "crypto/rand"
-	"encoding/binary"
-	"math/rand"
 	"sync"
 )
 
@@ -346,22 +343,28 @@ func NewWorld() *World {
 		},
 	}
 
+	// init not exported vars
+	world.init(cryptoSeed())
+
+	// return
+	return world
+}
+
+// init prepares a new world with the given map for the game: it initializes the random number generator,
+// the lock and the player list and links the countries to the world.
+func (w *World) init(seed int64) {
 	// init random
-	var seed int64
-	_ = binary.Read(crnd.Reader, binary.LittleEndian, &seed)
-	world.rnd = rand.New(rand.NewSource(seed))
+	w.setRandom(seed)
 
-	// init lock
-	world.lock = new(sync.Mutex)
+	// init locks
+	w.lock = new(sync.Mutex)
+	w.lobby = new(sync.Mutex)
 
 	// init player list
-	world.PlayerQueue = make([]*Player, 0, 12)
+	w.PlayerQueue = make([]*Player, 0, 12)
 
 	// add world link to countries
-	for _, c := range world.Countries {
-		c.world = world
+	for _, c := range w.Countries {
+		c.world = w
 	}
-
-	// return
-	return world
 }
@@ -1,9 +1,16 @@
 package core
 
 import (
+	"encoding/json"
+	"fmt"
 	"image/color"
+	"io"
+	"log/slog"
 	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestWorld_Continent(t *testing.T) {
@@ -81,12 +88,32 @@ func TestWorld_RndCountryList(t *testing.T) {
 	if cl1[0].Name == cl2[0].Name && cl1[0].Name == cl3[0].Name && cl1[0].Name == cl4[0].Name {
 		t.Fatalf("not random")
 	}
+
+	// reproducible with a fixed seed
+	names := func(list []*Country) string {
+		s := make([]string, len(list))
+		for i, c := range list {
+			s[i] = c.Name
+		}
+		return strings.Join(s, ",")
+	}
+	for i := 0; i < 10; i++ {
+		a, b := NewWorld(), NewWorld()
+		a.SetSeed(42)
+		b.SetSeed(42)
+		if got, want := names(b.RndCountryList()), names(a.RndCountryList()); got != want {
+			t.Fatalf("not reproducible:\n%s\n%s", got, want)
+		}
+	}
 }
 
 func TestWorld_Player(t *testing.T) {
 	w := NewWorld()
 
 	// not found
+	if w.HasPlayer("Player1") {
+		t.Fatal("unknown player")
+	}
 	if p := w.Player("Player1"); p == nil || p.Name != "Player1" || p.Color.R != 0 || p.Color.G != 0 || p.Color.B != 0 || p.Color.A != 0 {
 		t.Fatalf("invalid player")
 	}
@@ -97,11 +124,207 @@ func TestWorld_Player(t *testing.T) {
 	}
 
 	// found
+	if !w.HasPlayer("Player1") {
+		t.Fatal("player not found")
+	}
 	if p := w.Player("Player1"); p == nil || p.Name != "Player1" || p.Color.R != 255 || p.Color.G != 255 || p.Color.B != 255 || p.Color.A != 255 {
 		t.Fatalf("invalid player")
 	}
 }
 
+func TestWorld_ContinentOwner(t *testing.T) {
+	w := NewWorld()
+
+	// not occupied
+	if owner := w.ContinentOwner("Australia"); owner != "" {
+		t.Fatal(owner)
+	}
+
+	// single owner
+	for _, c := range w.Continent("Australia").Countries {
+		w.Country(c).Occupier = NewArmy(w, 1, "P1", c)
+	}
+	if owner := w.ContinentOwner("Australia"); owner != "P1" {
+		t.Fatal(owner)
+	}
+
+	// different owners
+	w.Country("Indonesia").Occupier.Player = "P2"
+	if owner := w.ContinentOwner("Australia"); owner != "" {
+		t.Fatal(owner)
+	}
+	if owner := w.ContinentOwner("unknown"); owner != "" {
+		t.Fatal(owner)
+	}
+}
+
+// twoPlayerWorld returns a started world with the players P1 and P2, who take their turns in the order
+// in which they have joined (P1 is active). The configure functions are called before the population
+// is initialized, e.g. to set rules that affect the start or to add more players.
+func twoPlayerWorld(t testing.TB, configure ...func(w *World)) *World {
+	t.Helper()
+	w := NewWorld()
+	w.TurnOrder = TurnOrderJoin
+	if err := w.AddPlayer("P1", color.RGBA{R: 255, A: 255}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.AddPlayer("P2", color.RGBA{G: 255, A: 255}); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range configure {
+		f(w)
+	}
+	w.InitPopulation()
+	return w
+}
+
+func TestWorld_ContinentRecruitBonus(t *testing.T) {
+	w := twoPlayerWorld(t)
+	player := w.PlayerQueue[0]
+	for _, c := range w.Continent("Australia").Countries {
+		w.Country(c).Occupier.Player = player.Name
+	}
+
+	// default off
+	player.Reinforcement = 8
+	if err := w.AttackOrMove("New Guinea", "New Guinea", 4, player.Name); err != nil {
+		t.Fatal(err)
+	}
+	if s := w.Country("New Guinea").Invader.Strength; s != 4 {
+		t.Fatal(s)
+	}
+
+	// 50% bonus in a controlled continent
+	w.ContinentRecruitBonus = 50
+	if err := w.AttackOrMove("Eastern Australia", "Eastern Australia", 4, player.Name); err != nil {
+		t.Fatal(err)
+	}
+	if s := w.Country("Eastern Australia").Invader.Strength; s != 6 || player.Reinforcement != 0 {
+		t.Fatal(s, player.Reinforcement)
+	}
+
+	// no bonus without continent control
+	w.Country("Indonesia").Occupier.Player = "other"
+	player.Reinforcement = 4
+	if err := w.AttackOrMove("Western Australia", "Western Australia", 4, player.Name); err != nil {
+		t.Fatal(err)
+	}
+	if s := w.Country("Western Australia").Invader.Strength; s != 4 {
+		t.Fatal(s)
+	}
+}
+
+func TestWorld_FrontlineCountries(t *testing.T) {
+	w := twoPlayerWorld(t)
+
+	for _, player := range []string{"P1", "P2"} {
+		list := w.FrontlineCountries(player)
+		if len(list) == 0 {
+			t.Fatal("no frontline for " + player)
+		}
+		for i, c := range list {
+			if c.Occupier.Player != player || !c.IsFrontline(player) {
+				t.Fatal(c.Name)
+			}
+			if i > 0 && list[i-1].Name >= c.Name {
+				t.Fatal("not sorted")
+			}
+		}
+	}
+
+	// all countries of a single player
+	for _, c := range w.Countries {
+		c.Occupier.Player = "P1"
+	}
+	if len(w.FrontlineCountries("P1")) != 0 {
+		t.Fatal("no enemies left")
+	}
+}
+
+func TestWorld_InitPopulation_deterministic(t *testing.T) {
+	layout := func() string {
+		w := NewWorld()
+		w.SetSeed(1234)
+		_ = w.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+		_ = w.AddPlayer("P2", color.RGBA{G: 255, A: 255})
+		_ = w.AddPlayer("P3", color.RGBA{B: 255, A: 255})
+		w.InitPopulation()
+		return w.Json()
+	}
+
+	first := layout()
+	for i := 0; i < 20; i++ {
+		if layout() != first {
+			t.Fatal("starting layout is not reproducible")
+		}
+	}
+}
+
+func TestWorld_MinReinforcementPerTurn(t *testing.T) {
+	w := NewWorld()
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 1, "P2", c.Name)
+	}
+	w.Country("Japan").Occupier.Player = "P1"
+
+	// default: no floor
+	if all, countries, _, _ := w.CalcReinforcement("P1"); all != 1 || countries != 1 {
+		t.Fatal(all, countries)
+	}
+
+	// floor
+	w.MinReinforcementPerTurn = 3
+	if all, countries, continents, sackBonus := w.CalcReinforcement("P1"); all != 3 || countries != 1 || continents != 0 || sackBonus != 0 {
+		t.Fatal(all, countries, continents, sackBonus)
+	}
+
+	// not for eliminated players
+	if all, _, _, _ := w.CalcReinforcement("P3"); all != 0 {
+		t.Fatal(all)
+	}
+
+	// no effect above the floor
+	for _, c := range w.Continent("Australia").Countries {
+		w.Country(c).Occupier.Player = "P1"
+	}
+	if all, _, _, _ := w.CalcReinforcement("P1"); all != 5+2 {
+		t.Fatal(all)
+	}
+}
+
+func TestWorld_ConnectedComponents(t *testing.T) {
+	w := NewWorld()
+
+	// no countries
+	if groups := w.ConnectedComponents("P1"); len(groups) != 0 {
+		t.Fatal(groups)
+	}
+
+	// Australia, cut by an enemy in New Guinea: {Eastern Australia, Western Australia} is still connected
+	for _, c := range w.Continent("Australia").Countries {
+		w.Country(c).Occupier = NewArmy(w, 1, "P1", c)
+	}
+	w.Country("New Guinea").Occupier.Player = "P2"
+	w.Country("Japan").Occupier = NewArmy(w, 1, "P1", "Japan")
+
+	groups := w.ConnectedComponents("P1")
+	want := [][]string{{"Eastern Australia", "Indonesia", "Western Australia"}, {"Japan"}}
+	if !reflect.DeepEqual(groups, want) {
+		t.Fatal(groups)
+	}
+
+	// Western Australia lost: Eastern Australia is cut off
+	w.Country("Western Australia").Occupier.Player = "P2"
+	groups = w.ConnectedComponents("P1")
+	want = [][]string{{"Eastern Australia"}, {"Indonesia"}, {"Japan"}}
+	if !reflect.DeepEqual(groups, want) {
+		t.Fatal(groups)
+	}
+	if groups := w.ConnectedComponents("P2"); !reflect.DeepEqual(groups, [][]string{{"New Guinea", "Western Australia"}}) {
+		t.Fatal(groups)
+	}
+}
+
 func TestWorld_CalcReinforcement(t *testing.T) {
 	// init
 	w := NewWorld()
@@ -134,6 +357,101 @@ func TestWorld_CalcReinforcement(t *testing.T) {
 	}
 }
 
+func TestWorld_CalcReinforcement_continentBonusScale(t *testing.T) {
+	w := NewWorld()
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 1, "P2", c.Name)
+	}
+	for _, name := range []string{"Europe", "Australia"} { // 6 + 2 points
+		for _, c := range w.Continent(name).Countries {
+			w.Country(c).Occupier.Player = "P1"
+		}
+	}
+
+	for _, tt := range []struct {
+		scale float64
+		want  int
+	}{
+		{0, 8}, {1, 8}, {2, 16}, {0.5, 4}, {0.25, 3}, {0.01, 0}, // rounded per continent
+		{-1, 0}, // clamped
+	} {
+		w.ContinentBonusScale = tt.scale
+		if _, _, continents, _ := w.CalcReinforcement("P1"); continents != tt.want {
+			t.Fatal(tt.scale, continents)
+		}
+		pools := w.CalcContinentReinforcement("P1")
+		if want := len(w.Continent("Europe").Countries) + w.continentPoints(w.Continent("Europe")); pools["Europe"] != want {
+			t.Fatal(tt.scale, pools)
+		}
+	}
+}
+
+func TestWorld_CalcReinforcement_func(t *testing.T) {
+	w := NewWorld()
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 1, "P2", c.Name)
+	}
+	for _, c := range w.Continent("Europe").Countries {
+		w.Country(c).Occupier.Player = "P1"
+	}
+	def := DefaultReinforcement(w, "P1")
+
+	// double the default
+	w.ReinforcementFunc = func(w *World, player string) ReinforcementBreakdown {
+		r := DefaultReinforcement(w, player)
+		r.Countries *= 2
+		r.All *= 2
+		return r
+	}
+	all, countries, continents, _ := w.CalcReinforcement("P1")
+	if all != def.All*2 || countries != def.Countries*2 || continents != def.Continents {
+		t.Fatal(all, countries, continents, def)
+	}
+	if pools := w.CalcContinentReinforcement("P1"); len(pools) != 1 || pools["Europe"] != all {
+		t.Fatal(pools)
+	}
+
+	// runtime config: kept by the copies, but not serialized
+	if c := w.DeepCopy(); c.ReinforcementFunc == nil {
+		t.Fatal("not copied")
+	}
+	if c := w.Clone(); c == nil || c.ReinforcementFunc == nil {
+		t.Fatal("not cloned")
+	}
+	if strings.Contains(w.Json(), "ReinforcementFunc") {
+		t.Fatal("serialized")
+	}
+
+	// default
+	w.ReinforcementFunc = nil
+	if all, _, _, _ := w.CalcReinforcement("P1"); all != def.All {
+		t.Fatal(all, def)
+	}
+}
+
+func TestWorld_SetCountryOwner(t *testing.T) {
+	w := NewWorld()
+	_ = w.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+
+	if err := w.SetCountryOwner("Atlantis", "P1", 3); err == nil || err.Error() != "country not found" {
+		t.Fatal(err)
+	}
+	if err := w.SetCountryOwner("Alaska", "P2", 3); err == nil || err.Error() != "player not found" {
+		t.Fatal(err)
+	}
+	if err := w.SetCountryOwner("Alaska", "P1", 0); err == nil || err.Error() != "strength must be greater than 0" {
+		t.Fatal(err)
+	}
+
+	if err := w.SetCountryOwner("Alaska", "P1", 3); err != nil {
+		t.Fatal(err)
+	}
+	a := w.Country("Alaska").Occupier
+	if a.Player != "P1" || a.Strength != 3 || a.HomeBase != "Alaska" || a.PlayerObj().Name != "P1" || a.HomeBaseObj().Name != "Alaska" {
+		t.Fatal(a)
+	}
+}
+
 func TestWorld_AddPlayer(t *testing.T) {
 	w := NewWorld()
 
@@ -150,10 +468,39 @@ func TestWorld_AddPlayer(t *testing.T) {
 	if err := w.AddPlayer("     ", color.RGBA{R: 255, G: 0, B: 0, A: 255}); err == nil {
 		t.Fatal("no error:", err) // player empty
 	}
-	if len(w.PlayerQueue) != 1 {
+	for name, want := range map[string]string{
+		"a|b":                   "player name contains invalid characters",
+		"line\nbreak":           "player name contains invalid characters",
+		"bell\a":                "player name contains invalid characters",
+		"tab\tname":             "player name contains invalid characters",
+		"bad\xffutf8":           "player name is not valid UTF-8",
+		strings.Repeat("x", 33): "player name is too long",
+		NeutralPlayer:           "player name is reserved",
+	} {
+		if err := w.AddPlayer(name, color.RGBA{}); err == nil || err.Error() != want {
+			t.Fatal(name, err)
+		}
+	}
+	if err := w.AddPlayer("Jürgen "+strings.Repeat("ß", 25), color.RGBA{}); err != nil {
+		t.Fatal(err) // 32 characters, but more bytes
+	}
+	if len(w.PlayerQueue) != 2 {
 		t.Fatal("invalid player count")
 	}
 
+	// late player
+	late := NewWorld()
+	_ = late.AddPlayer("user1", color.RGBA{})
+	_ = late.AddPlayer("user2", color.RGBA{})
+	late.InitPopulation()
+	order := slices.Clone(late.PlayerQueue)
+	if err := late.AddPlayer("user3", color.RGBA{}); err == nil || err.Error() != "game already started" {
+		t.Fatal(err)
+	}
+	if !slices.Equal(late.PlayerQueue, order) {
+		t.Fatal("turn order changed")
+	}
+
 	// valid player
 	p := w.Player("user1")
 	if p == nil {
@@ -238,6 +585,7 @@ func TestWorld_InitPopulation(t *testing.T) {
 
 func TestWorld_AttackOrMove(t *testing.T) {
 	w := NewWorld() // empty world
+	w.TurnOrder = TurnOrderJoin
 
 	// freeze
 	w.Freeze = true
@@ -281,7 +629,7 @@ func TestWorld_AttackOrMove(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if err := w.AttackOrMove("Alaska", "test", 1, ""); err == nil || err.Error() != "at least one man must stay behind" {
+	if err := w.AdminAttackOrMove("Alaska", "test", 1); err == nil || err.Error() != "at least one man must stay behind" {
 		t.Fatal(err)
 	}
 
@@ -289,7 +637,7 @@ func TestWorld_AttackOrMove(t *testing.T) {
 	w.Country("Alaska").Occupier.Strength += 1
 
 	// second checks (#3)
-	if err := w.AttackOrMove("Alaska", "test", 1, ""); err == nil || err.Error() != "attacker and defender are not neighbors" {
+	if err := w.AdminAttackOrMove("Alaska", "test", 1); err == nil || err.Error() != "attacker and defender are not neighbors" {
 		t.Fatal(err)
 	}
 
@@ -299,7 +647,7 @@ func TestWorld_AttackOrMove(t *testing.T) {
 		t.Fatal(err)
 	}
 	w.Country("Alaska").Occupier.Player = "Player1"
-	if err := w.AttackOrMove("Alaska", "Alaska", 1, ""); err == nil || err.Error() != "cannot recruit in this region" {
+	if err := w.AdminAttackOrMove("Alaska", "Alaska", 1); err == nil || err.Error() != "cannot recruit in this region" {
 		t.Fatal(err)
 	}
 
@@ -336,28 +684,155 @@ func TestWorld_AttackOrMove(t *testing.T) {
 	}
 }
 
+func TestWorld_MaxCountryStrength(t *testing.T) {
+	w := NewWorld()
+	w.NoLog = true
+	w.MaxCountryStrength = 10
+	w.PlayerQueue = []*Player{{Name: "P1", Reinforcement: 5}, {Name: "P2"}}
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 1, "P2", c.Name)
+	}
+	w.Country("Alberta").Occupier = NewArmy(w, 8, "P1", "Alberta") // recruiting region
+	w.Country("Ontario").Occupier = NewArmy(w, 5, "P1", "Ontario")
+
+	// moves up to the limit
+	if err := w.AttackOrMove("Ontario", "Alberta", 2, "P1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.AttackOrMove("Ontario", "Alberta", 1, "P1"); err == nil || err.Error() != "country strength limit reached" {
+		t.Fatal(err)
+	}
+	if err := w.AttackOrMove("Alberta", "Alberta", 1, "P1"); err == nil || err.Error() != "country strength limit reached" {
+		t.Fatal(err)
+	}
+
+	// attacks are not limited, but the survivors are clamped
+	w.Country("Quebec").Invader = NewArmy(w, 50, "P1", "Ontario")
+
+	// the merge is clamped (e.g. a recruit bonus)
+	w.Country("Alberta").Invader.Strength += 3
+	if _, err := w.EndTurn("P1"); err != nil {
+		t.Fatal(err)
+	}
+	if s := w.Country("Alberta").Occupier.Strength; s != 10 {
+		t.Fatal(s)
+	}
+	if q := w.Country("Quebec").Occupier; q.Player != "P1" || q.Strength != 10 {
+		t.Fatal(q)
+	}
+
+	// disabled
+	w.MaxCountryStrength = 0
+	if _, err := w.EndTurn("P2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.AttackOrMove("Ontario", "Alberta", 2, "P1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWorld_AttackOrMove_reinforcementDeferred(t *testing.T) {
+	w := NewWorld()
+	w.NoLog = true
+	w.PlayerQueue = []*Player{{Name: "P1", Reinforcement: 5}, {Name: "P2"}}
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 1, "P2", c.Name)
+	}
+	w.Country("Alberta").Occupier.Player = "P1" // recruiting region
+
+	// the reinforcement waits as invader until the end of the turn
+	if err := w.AttackOrMove("Alberta", "Alberta", 5, "P1"); err != nil {
+		t.Fatal(err)
+	}
+	alberta := w.Country("Alberta")
+	if alberta.Occupier.Strength != 1 || alberta.Invader == nil || alberta.Invader.Strength != 5 {
+		t.Fatal(alberta.Occupier, alberta.Invader)
+	}
+
+	// the new units cannot attack in the same turn
+	if err := w.AttackOrMove("Alberta", "Ontario", 1, "P1"); err == nil || err.Error() != "at least one man must stay behind" {
+		t.Fatal(err)
+	}
+
+	// but in the next turn
+	if _, err := w.EndTurn("P1"); err != nil {
+		t.Fatal(err)
+	}
+	if alberta.Occupier.Strength != 6 || alberta.Invader != nil {
+		t.Fatal(alberta.Occupier, alberta.Invader)
+	}
+	if _, err := w.EndTurn("P2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.AttackOrMove("Alberta", "Ontario", 5, "P1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWorld_CanAttackOrMove(t *testing.T) {
+	w := twoPlayerWorld(t)
+	active := w.PlayerQueue[0].Name
+	front := w.FrontlineCountries(active)[0]
+	front.Occupier.Strength = 5
+
+	commands := [][]interface{}{
+		{"", "", 1, active},
+		{front.Name, front.Name, 0, active},
+		{front.Name, "Atlantis", 1, active},
+		{front.Name, front.Neighbors[0], 4, active},
+		{front.Name, front.Neighbors[0], 5, active},
+		{front.Name, front.Name, 1, active},
+		{front.Name, front.Name, 1000, active},
+		{front.Name, front.Neighbors[0], 1, "P3"},
+	}
+	for _, cmd := range commands {
+		attacker, defender, strength, player := cmd[0].(string), cmd[1].(string), cmd[2].(int), cmd[3].(string)
+
+		// dry run does not change the world
+		before := w.Json()
+		dryErr := w.CanAttackOrMove(attacker, defender, strength, player)
+		if w.Json() != before {
+			t.Fatal("dry run changed the world", cmd)
+		}
+
+		// same result as the real command
+		clone := w.Clone()
+		realErr := clone.AttackOrMove(attacker, defender, strength, player)
+		if (dryErr == nil) != (realErr == nil) || (dryErr != nil && dryErr.Error() != realErr.Error()) {
+			t.Fatal(cmd, dryErr, realErr)
+		}
+	}
+
+	// freeze
+	w.Freeze = true
+	if err := w.CanAttackOrMove(front.Name, front.Neighbors[0], 1, active); err == nil || err.Error() != "world is frozen" {
+		t.Fatal(err)
+	}
+}
+
 func TestWorld_EndTurn(t *testing.T) {
 	w := NewWorld()
+	w.TurnOrder = TurnOrderJoin
 
 	// freeze
 	w.Freeze = true
-	if err := w.EndTurn("Player1"); err == nil || err.Error() != "world is frozen" {
+	if _, err := w.EndTurn("Player1"); err == nil || err.Error() != "world is frozen" {
 		t.Fatal(err)
 	}
 	w.Freeze = false
 
 	// error
-	if err := w.EndTurn("Player1"); err == nil || err.Error() != "no other player found" {
+	if _, err := w.EndTurn("Player1"); err == nil || err.Error() != "no other player found" {
 		t.Fatal(err)
 	}
 	_ = w.AddPlayer("Player1", color.RGBA{R: 255, G: 255, B: 255, A: 255})
-	if err := w.EndTurn("Player1"); err == nil || err.Error() != "no other player found" {
+	if _, err := w.EndTurn("Player1"); err == nil || err.Error() != "no other player found" {
 		t.Fatal(err)
 	}
 	_ = w.AddPlayer("Player2", color.RGBA{R: 0, G: 0, B: 0, A: 0})
 	w.PlayerQueue[0].Name = "PlayerA"
 	w.PlayerQueue[1].Name = "PlayerB"
-	if err := w.EndTurn("PlayerB"); err == nil || err.Error() != "cannot end enemy turn" {
+	if _, err := w.EndTurn("PlayerB"); err == nil || err.Error() != "cannot end enemy turn" {
 		t.Fatal(err)
 	}
 
@@ -368,7 +843,7 @@ func TestWorld_EndTurn(t *testing.T) {
 	}
 
 	// success
-	if err := w.EndTurn("PlayerA"); err != nil {
+	if _, err := w.EndTurn("PlayerA"); err != nil {
 		t.Fatal(err)
 	}
 	if w.PlayerQueue[0].Name != "PlayerB" || w.PlayerQueue[1].Name != "PlayerA" {
@@ -376,15 +851,171 @@ func TestWorld_EndTurn(t *testing.T) {
 	}
 
 	//
-	if err := w.EndTurn("PlayerB"); err != nil {
+	if _, err := w.EndTurn("PlayerB"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.EndTurn("PlayerA"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.EndTurn("PlayerB"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWorld_EndTurn_eliminated(t *testing.T) {
+	// P3 occupies Egypt, P2 occupies Congo, P1 the rest
+	newWorld := func() *World {
+		w := NewWorld()
+		w.NoLog = true
+		w.PlayerQueue = []*Player{{Name: "P1"}, {Name: "P2"}, {Name: "P3"}}
+		for _, c := range w.Countries {
+			c.Occupier = NewArmy(w, 1, "P1", c.Name)
+		}
+		w.Country("Congo").Occupier.Player = "P2"
+		w.Country("Egypt").Occupier.Player = "P3"
+		return w
+	}
+	names := func(w *World) string {
+		var s []string
+		for _, p := range w.PlayerQueue {
+			s = append(s, p.Name)
+		}
+		return strings.Join(s, ",")
+	}
+
+	// P3 is wiped out before its turn: the round ends after P2
+	w := newWorld()
+	w.Country("Egypt").Invader = NewArmy(w, 1000, "P1", "North Africa")
+	if _, err := w.EndTurn("P1"); err != nil {
+		t.Fatal(err)
+	}
+	if q := names(w); q != "P2,P1" || w.SubRound != 1 {
+		t.Fatal(q, w.SubRound)
+	}
+	if _, err := w.EndTurn("P2"); err != nil {
+		t.Fatal(err)
+	}
+	if q := names(w); q != "P1,P2" || w.Round != 1 || w.SubRound != 0 {
+		t.Fatal(q, w.Round, w.SubRound)
+	}
+
+	// P1 is wiped out after its turn: the round ends after P3
+	w = newWorld()
+	for _, c := range w.Countries {
+		c.Occupier.Player = "P2"
+	}
+	w.Country("Egypt").Occupier.Player = "P3"
+	w.Country("Alaska").Occupier.Player = "P1"
+	if _, err := w.EndTurn("P1"); err != nil {
+		t.Fatal(err)
+	}
+	w.Country("Alaska").Invader = NewArmy(w, 1000, "P2", "Alberta")
+	if _, err := w.EndTurn("P2"); err != nil {
+		t.Fatal(err)
+	}
+	if q := names(w); q != "P3,P2" || w.SubRound != 1 {
+		t.Fatal(q, w.SubRound)
+	}
+	if _, err := w.EndTurn("P3"); err != nil {
+		t.Fatal(err)
+	}
+	if w.Round != 1 || w.SubRound != 0 || len(w.PlayerQueue) != 2 {
+		t.Fatal(names(w), w.Round, w.SubRound)
+	}
+}
+
+func TestWorld_EndTurn_result(t *testing.T) {
+	// P3 occupies Egypt, P2 occupies Congo, P1 the rest
+	w := NewWorld()
+	w.NoLog = true
+	w.PlayerQueue = []*Player{{Name: "P1"}, {Name: "P2"}, {Name: "P3"}}
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 1, "P1", c.Name)
+	}
+	w.Country("Congo").Occupier.Player = "P2"
+	w.Country("Egypt").Occupier.Player = "P3"
+
+	// error
+	if r, err := w.EndTurn("P2"); err == nil || r.Player != "" || r.Battles != nil {
+		t.Fatal(r, err)
+	}
+
+	// P1 wipes out P3
+	w.Country("Egypt").Invader = NewArmy(w, 1000, "P1", "North Africa")
+	r, err := w.EndTurn("P1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Player != "P1" || len(r.Battles) != 1 || r.Battles[0].Country != "Egypt" || !r.Battles[0].Captured {
+		t.Fatal(r)
+	}
+	if len(r.Captures) != 1 || r.Captures[0].OldOwner != "P3" || r.Captures[0].NewOwner != "P1" {
+		t.Fatal(r.Captures)
+	}
+	if !slices.Equal(r.Eliminated, []string{"P3"}) || r.NewRound || r.Reinforcements != nil || r.Finished {
+		t.Fatal(r)
+	}
+
+	// P2 completes the round
+	before := w.Player("P1").Reinforcement
+	r, err = w.EndTurn("P2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.NewRound || len(r.Battles) != 0 || len(r.Captures) != 0 || len(r.Eliminated) != 0 || r.Finished {
+		t.Fatal(r)
+	}
+	if len(r.Reinforcements) != 2 || r.Reinforcements["P1"] < 1 || r.Reinforcements["P1"] != w.Player("P1").Reinforcement-before {
+		t.Fatal(r.Reinforcements)
+	}
+}
+
+func TestWorld_FirstConquestBonus(t *testing.T) {
+	w := NewWorld()
+	w.NoLog = true
+	w.FirstConquestBonus = 5
+	w.PlayerQueue = []*Player{{Name: "P1"}, {Name: "P2"}}
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 1, "P2", c.Name)
+	}
+	w.Country("North Africa").Occupier.Player = "P1"
+
+	// first conquest in Africa
+	w.Country("Egypt").Invader = NewArmy(w, 1000, "P1", "North Africa")
+	if _, err := w.EndTurn("P1"); err != nil {
+		t.Fatal(err)
+	}
+	p1 := w.Player("P1")
+	if p1.Reinforcement != 5 || !slices.Equal(p1.ConqueredContinents, []string{"Africa"}) {
+		t.Fatal(p1.Reinforcement, p1.ConqueredContinents)
+	}
+
+	// second conquest in Africa: no bonus
+	if _, err := w.EndTurn("P2"); err != nil {
+		t.Fatal(err)
+	}
+	before := p1.Reinforcement
+	w.Country("East Africa").Invader = NewArmy(w, 1000, "P1", "Egypt")
+	if _, err := w.EndTurn("P1"); err != nil {
 		t.Fatal(err)
 	}
-	if err := w.EndTurn("PlayerA"); err != nil {
+	if p1.Reinforcement != before || len(p1.ConqueredContinents) != 1 {
+		t.Fatal(p1.Reinforcement, p1.ConqueredContinents)
+	}
+
+	// disabled
+	w.FirstConquestBonus = 0
+	if _, err := w.EndTurn("P2"); err != nil {
 		t.Fatal(err)
 	}
-	if err := w.EndTurn("PlayerB"); err != nil {
+	before = p1.Reinforcement
+	w.Country("Southern Europe").Invader = NewArmy(w, 1000, "P1", "Egypt")
+	if _, err := w.EndTurn("P1"); err != nil {
 		t.Fatal(err)
 	}
+	if p1.Reinforcement != before || len(p1.ConqueredContinents) != 1 {
+		t.Fatal(p1.Reinforcement, p1.ConqueredContinents)
+	}
 }
 
 func TestWorldClone(t *testing.T) {
@@ -421,6 +1052,7 @@ func TestWorldClone(t *testing.T) {
 	// remove lock and random
 	clonedWorld.lock = originalWorld.lock
 	clonedWorld.rnd = originalWorld.rnd
+	clonedWorld.src = originalWorld.src
 
 	// check links
 	if clonedWorld.RndCountryList()[0].world == nil || clonedWorld.RndCountryList()[0].Occupier.world == nil {
@@ -454,3 +1086,657 @@ func TestWorldClone(t *testing.T) {
 		t.Error("Modifying the cloned world should not affect the original world")
 	}
 }
+
+// TestWorld_Clone_config sets every option of the world, the countries and the players to a value other than
+// the default and checks that all of them survive the JSON round trip (Clone and FromJson) and DeepCopy.
+// New options are covered automatically; only runtime options (json:"-") are excluded.
+func TestWorld_Clone_config(t *testing.T) {
+	w := twoPlayerWorld(t)
+
+	fields := fillScalars(reflect.ValueOf(w).Elem(), "world", 1)
+	fields = append(fields, fillScalars(reflect.ValueOf(w.Country("Alaska")).Elem(), "country", 100, "Name", "Continent")...)
+	fields = append(fields, fillScalars(reflect.ValueOf(w.PlayerQueue[0]).Elem(), "player", 200, "Name")...)
+	if len(fields) < 30 {
+		t.Fatal("too few options", len(fields))
+	}
+
+	loaded := new(World)
+	if err := loaded.FromJson(w.Json()); err != nil {
+		t.Fatal(err)
+	}
+	for name, c := range map[string]*World{"Clone": w.Clone(), "FromJson": loaded, "DeepCopy": w.DeepCopy()} {
+		if c == nil {
+			t.Fatal(name, "failed")
+		}
+		values := [][2]reflect.Value{
+			{reflect.ValueOf(w).Elem(), reflect.ValueOf(c).Elem()},
+			{reflect.ValueOf(w.Country("Alaska")).Elem(), reflect.ValueOf(c.Country("Alaska")).Elem()},
+			{reflect.ValueOf(w.PlayerQueue[0]).Elem(), reflect.ValueOf(c.PlayerQueue[0]).Elem()},
+		}
+		for _, f := range fields {
+			scope, field, _ := strings.Cut(f, ".")
+			pair := values[map[string]int{"world": 0, "country": 1, "player": 2}[scope]]
+			if a, b := pair[0].FieldByName(field).Interface(), pair[1].FieldByName(field).Interface(); !reflect.DeepEqual(a, b) {
+				t.Errorf("%s: %s is %v, want %v", name, f, b, a)
+			}
+		}
+	}
+}
+
+// fillScalars sets all exported bool, number and string fields of the struct (also in nested structs)
+// to distinct values other than the default, and returns their names prefixed with the scope.
+// Maps, slices, pointers, funcs and fields with the tag json:"-" are left alone.
+func fillScalars(v reflect.Value, scope string, seed int, skip ...string) []string {
+	var names []string
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if !f.IsExported() || f.Tag.Get("json") == "-" || slices.Contains(skip, f.Name) {
+			continue
+		}
+		if fillValue(v.Field(i), seed+i) {
+			names = append(names, scope+"."+f.Name)
+		}
+	}
+	return names
+}
+
+// fillValue sets a scalar or a struct of scalars to a value other than the default (see fillScalars).
+func fillValue(v reflect.Value, n int) bool {
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(int64(n%100 + 2))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(uint64(n%100 + 2))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(n) + 0.5)
+	case reflect.String:
+		v.SetString(fmt.Sprintf("option%d", n))
+	case reflect.Struct:
+		filled := false
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() && fillValue(v.Field(i), n+i+1) {
+				filled = true
+			}
+		}
+		return filled
+	default:
+		return false
+	}
+	return true
+}
+
+func TestWorld_CountryJson(t *testing.T) {
+	w := NewWorld()
+	_ = w.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	w.InitPopulation()
+
+	js, err := w.CountryJson("Alaska")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := new(Country)
+	if err := json.Unmarshal([]byte(js), c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Name != "Alaska" || c.Continent != "North America" || len(c.Neighbors) != 3 || !c.BorderRegion || c.Occupier.Player != "P1" {
+		t.Fatal(js)
+	}
+
+	if _, err := w.CountryJson("Atlantis"); err == nil || err.Error() != "country not found" {
+		t.Fatal(err)
+	}
+}
+
+func TestWorld_DeepCopy(t *testing.T) {
+	w := NewWorld()
+	_ = w.AddPlayer("Fritz", color.RGBA{R: 255, A: 255})
+	_ = w.AddPlayer("Bob", color.RGBA{G: 255, A: 255})
+	w.InitPopulation()
+	w.Round = 5
+	w.MinReinforcementPerTurn = 3
+	w.RndCountryList()[0].Invader = NewArmy(w, 19, "Bob", "home")
+	_ = w.RequestTruce("Fritz", "Bob", 2)
+
+	c := w.DeepCopy()
+
+	// same state as the JSON clone
+	if c.Json() != w.Json() || c.Json() != w.Clone().Json() {
+		t.Fatal("copy does not match the original")
+	}
+
+	// world links and runtime fields
+	if c.lock == w.lock || c.rnd == nil || c.rnd == w.rnd {
+		t.Fatal("lock or random shared")
+	}
+	for _, cnt := range c.Countries {
+		if cnt.world != c || cnt.Occupier.world != c || (cnt.Invader != nil && cnt.Invader.world != c) {
+			t.Fatal("world link", cnt.Name)
+		}
+	}
+
+	// independent of the original
+	c.Country("Alaska").Occupier.Strength = 99
+	c.Country("Alaska").Neighbors[0] = "Atlantis"
+	c.Continent("Europe").Countries[0] = "Atlantis"
+	c.PlayerQueue[0].Reinforcement = 99
+	c.Truces[0].Rounds = 99
+	if w.Country("Alaska").Occupier.Strength == 99 || w.Country("Alaska").Neighbors[0] == "Atlantis" ||
+		w.Continent("Europe").Countries[0] == "Atlantis" || w.PlayerQueue[0].Reinforcement == 99 || w.Truces[0].Rounds == 99 {
+		t.Fatal("copy shares data with the original")
+	}
+}
+
+func BenchmarkWorld_Clone(b *testing.B) {
+	w := twoPlayerWorld(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = w.Clone()
+	}
+}
+
+func BenchmarkWorld_DeepCopy(b *testing.B) {
+	w := twoPlayerWorld(b)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = w.DeepCopy()
+	}
+}
+
+func TestWorld_AddPlayer_colorForName(t *testing.T) {
+	w := NewWorld()
+
+	// deterministic color
+	if ColorForName("user1") != ColorForName("  user1  ") {
+		t.Fatal("color is not deterministic")
+	}
+
+	// no color -> name color
+	if err := w.AddPlayer("user1", color.RGBA{}); err != nil {
+		t.Fatal(err)
+	}
+	if w.Player("user1").Color != ColorForName("user1") {
+		t.Fatalf("invalid color: %#v", w.Player("user1").Color)
+	}
+
+	// collisions are resolved
+	for i := 2; i <= len(PlayerColors)+3; i++ {
+		if err := w.AddPlayer(fmt.Sprintf("user%d", i), color.RGBA{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, p1 := range w.PlayerQueue {
+		for _, p2 := range w.PlayerQueue[i+1:] {
+			if p1.Color == p2.Color {
+				t.Fatalf("color collision: %s, %s", p1.Name, p2.Name)
+			}
+		}
+	}
+}
+
+func BenchmarkWorld_EndTurn(b *testing.B) {
+	generated, err := GenerateRandomWorld(1, MaxGeneratedCountries, 12)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, bm := range []struct {
+		name    string
+		world   *World
+		players int
+	}{
+		{name: "classic", world: NewWorld(), players: 4},
+		{name: "generated", world: generated, players: 6},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			w := benchmarkTurn(bm.world, bm.players)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				c := w.DeepCopy()
+				b.StartTimer()
+				if _, err := c.EndTurn(c.PlayerQueue[0].Name); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// benchmarkTurn populates the world and gives the active player an order in every country,
+// so EndTurn has a pending invader to resolve in most countries.
+func benchmarkTurn(w *World, players int) *World {
+	w.NoLog = true
+	w.SetLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	w.SetSeed(1)
+	for i := 1; i <= players; i++ {
+		_ = w.AddPlayer(fmt.Sprintf("P%d", i), color.RGBA{})
+	}
+	w.InitPopulation()
+	for _, c := range w.Countries {
+		c.Occupier.Strength = 20
+	}
+
+	active := w.PlayerQueue[0].Name
+	for _, c := range w.sortedCountryList() {
+		if c.Occupier.Player != active {
+			continue
+		}
+		// attack the first enemy, otherwise move to the first neighbor
+		target := c.Neighbors[0]
+		for _, n := range c.Neighbors {
+			if w.Countries[n].Occupier.Player != active {
+				target = n
+				break
+			}
+		}
+		_ = w.AttackOrMove(c.Name, target, 19, active)
+	}
+	return w
+}
+
+func TestWorld_IsTurn(t *testing.T) {
+	w := NewWorld()
+	_ = w.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	_ = w.AddPlayer("P2", color.RGBA{B: 255, A: 255})
+
+	// lobby
+	if active, _ := w.IsTurn(w.PlayerQueue[0].Name); active {
+		t.Fatal("game not started")
+	}
+
+	w.InitPopulation()
+	first, second := w.PlayerQueue[0].Name, w.PlayerQueue[1].Name
+	w.SetTimeBank(first, 3*time.Second)
+	if active, left := w.IsTurn(first); !active || left != 3*time.Second {
+		t.Fatal(active, left)
+	}
+	if active, left := w.IsTurn(second); active || left != 0 {
+		t.Fatal(active, left)
+	}
+	if active, _ := w.IsTurn("P3"); active {
+		t.Fatal("unknown player")
+	}
+
+	// frozen
+	w.Freeze = true
+	if active, _ := w.IsTurn(first); active {
+		t.Fatal("world is frozen")
+	}
+	w.Freeze = false
+
+	// next turn
+	_, _ = w.EndTurn(first)
+	if active, _ := w.IsTurn(second); !active {
+		t.Fatal("second player")
+	}
+}
+
+func TestWorld_distances(t *testing.T) {
+	w := NewWorld()
+	alaska := w.Country("Alaska")
+
+	// limited to one hop
+	dist := w.distances([]*Country{alaska}, 1)
+	if len(dist) != 4 || dist["Alaska"] != 0 || dist["Alberta"] != 1 || dist["Kamchatka"] != 1 {
+		t.Fatal(dist)
+	}
+
+	// no limit: all countries are reachable
+	dist = w.distances([]*Country{alaska}, -1)
+	if len(dist) != len(w.Countries) || dist["Ontario"] != 2 {
+		t.Fatal(dist)
+	}
+
+	// no start: nothing is reachable
+	if dist = w.distances(nil, -1); len(dist) != 0 {
+		t.Fatal(dist)
+	}
+}
+
+func TestWorld_FromJson_negativeStrength(t *testing.T) {
+	w := NewWorld()
+	_ = w.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	w.Country("Alaska").Occupier = &Army{world: w, Strength: 3, Player: "P1", HomeBase: "Alaska"}
+
+	// negative occupier
+	w.Country("Alaska").Occupier.Strength = -5
+	if err := NewWorld().FromJson(w.Json()); err == nil || err.Error() != "negative strength of the occupier of Alaska" {
+		t.Fatal(err)
+	}
+
+	// a fogged occupier is only allowed in the view of a player
+	w.Country("Alaska").Occupier.Strength = FogStrength
+	if err := NewWorld().FromJson(w.Json()); err == nil || err.Error() != "negative strength of the occupier of Alaska" {
+		t.Fatal(err)
+	}
+	if err := NewWorld().FromViewJson(w.Json()); err != nil {
+		t.Fatal(err)
+	}
+
+	// a rejected JSON leaves the world unchanged
+	loaded := NewWorld()
+	loaded.Round = 7
+	before := loaded.Json()
+	if err := loaded.FromJson(w.Json()); err == nil || loaded.Json() != before {
+		t.Fatal("world changed", err)
+	}
+
+	// negative invader
+	w.Country("Alaska").Occupier.Strength = 3
+	w.Country("Alaska").Invader = &Army{world: w, Strength: -1, Player: "P1", HomeBase: "Alberta"}
+	if err := NewWorld().FromJson(w.Json()); err == nil || err.Error() != "negative strength of the invader of Alaska" {
+		t.Fatal(err)
+	}
+}
+
+func TestWorld_AttackOrMoveRounds(t *testing.T) {
+	w := twoPlayerWorld(t)
+	_ = w.SetCountryOwner("Alaska", "P1", 20)
+	_ = w.SetCountryOwner("Northwest Territory", "P1", 5)
+	_ = w.SetCountryOwner("Alberta", "P2", 50)
+	_ = w.SetCountryOwner("Kamchatka", "P2", 5)
+
+	// disabled
+	if err := w.AttackOrMoveRounds("Alaska", "Alberta", 10, 1, "P1"); err == nil || err.Error() != "stepwise attacks disabled" {
+		t.Fatal(err)
+	}
+	// 0 rounds is a normal order
+	if err := w.AttackOrMoveRounds("Alaska", "Northwest Territory", 1, 0, "P1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// errors
+	w.StepwiseAttacks = true
+	tests := []struct {
+		defender string
+		rounds   int
+		want     string
+	}{
+		{defender: "Alberta", rounds: -1, want: "round limit must not be negative"},
+		{defender: "Northwest Territory", rounds: 1, want: "round limit only applies to attacks"},
+		{defender: "Brazil", rounds: 1, want: "attacker and defender are not neighbors"},
+	}
+	for _, tt := range tests {
+		if err := w.AttackOrMoveRounds("Alaska", tt.defender, 1, tt.rounds, "P1"); err == nil || err.Error() != tt.want {
+			t.Fatalf("%s: got %v, want %q", tt.defender, err, tt.want)
+		}
+	}
+
+	// one round: both armies survive, the attackers retreat and the losses remain
+	if err := w.AttackOrMoveRounds("Alaska", "Alberta", 10, 1, "P1"); err != nil {
+		t.Fatal(err)
+	}
+	if w.Country("Alberta").Invader.RoundLimit != 1 {
+		t.Fatal("round limit not set")
+	}
+	if _, err := w.EndTurn("P1"); err != nil {
+		t.Fatal(err)
+	}
+	alaska, alberta := w.Country("Alaska").Occupier, w.Country("Alberta").Occupier
+	if alberta.Player != "P2" || alaska.Player != "P1" || w.Country("Alberta").Invader != nil {
+		t.Fatal("wrong owners")
+	}
+	if alaska.Strength+alberta.Strength != 19+50-2 || alaska.Strength < 19-2 {
+		t.Fatal("wrong losses", alaska.Strength, alberta.Strength)
+	}
+}
+
+func TestWorld_EndTurn_conquest(t *testing.T) {
+	w := twoPlayerWorld(t)
+	_ = w.SetCountryOwner("Alaska", "P1", 1000)
+	_ = w.SetCountryOwner("Alberta", "P2", 1)
+	source := w.Country("Alaska").Occupier
+	defeated := w.Country("Alberta").Occupier
+
+	if err := w.AttackOrMove("Alaska", "Alberta", 999, "P1"); err != nil {
+		t.Fatal(err)
+	}
+	invader := w.Country("Alberta").Invader
+	if _, err := w.EndTurn("P1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the conquered country has a fresh, correctly linked occupier
+	c := w.Country("Alberta")
+	occupier := c.Occupier
+	if occupier == invader || occupier == defeated || c.Invader != nil {
+		t.Fatal("the occupier must be a fresh army")
+	}
+	if occupier.world != w || occupier.Player != "P1" || occupier.HomeBase != "Alberta" || occupier.RoundLimit != 0 {
+		t.Fatal(occupier)
+	}
+	if occupier.Strength != invader.Strength || occupier.Strength < 1 || occupier.HomeBaseObj() != c {
+		t.Fatal(occupier.Strength, invader.Strength)
+	}
+
+	// the source country keeps its own army
+	if w.Country("Alaska").Occupier != source || source.Strength != 1 || source.HomeBase != "Alaska" {
+		t.Fatal(source)
+	}
+
+	// no country references the invader or the defeated army
+	for _, c := range w.Countries {
+		if c.Occupier == invader || c.Invader == invader || c.Occupier == defeated || c.Invader == defeated {
+			t.Fatal("dangling reference in", c.Name)
+		}
+	}
+
+	// a stale pointer cannot change the world
+	strength := occupier.Strength
+	invader.Strength = -7
+	if occupier.Strength != strength {
+		t.Fatal("stale invader changed the occupier")
+	}
+}
+
+func TestWorld_AttackOrMove_multiFront(t *testing.T) {
+	w := twoPlayerWorld(t)
+	_ = w.SetCountryOwner("Alaska", "P1", 5)
+	_ = w.SetCountryOwner("Alberta", "P2", 1)
+	_ = w.SetCountryOwner("Kamchatka", "P2", 1)
+
+	// two attacks that together would empty Alaska
+	if err := w.AttackOrMove("Alaska", "Alberta", 3, "P1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.CanAttackOrMove("Alaska", "Kamchatka", 2, "P1"); err == nil || err.Error() != "at least one man must stay behind" {
+		t.Fatal(err)
+	}
+	if err := w.AttackOrMove("Alaska", "Kamchatka", 2, "P1"); err == nil || err.Error() != "at least one man must stay behind" {
+		t.Fatal(err)
+	}
+
+	// the rest of the pool can still be used
+	if err := w.AttackOrMove("Alaska", "Kamchatka", 1, "P1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.AttackOrMove("Alaska", "Northwest Territory", 1, "P1"); err == nil {
+		t.Fatal("Alaska is exhausted")
+	}
+	committed := w.Country("Alberta").Invader.Strength + w.Country("Kamchatka").Invader.Strength
+	if a := w.Country("Alaska").Occupier; a.Strength != 1 || a.Strength+committed != 5 {
+		t.Fatal(a.Strength, committed)
+	}
+
+	// one defender stays behind after the battles
+	if _, err := w.EndTurn("P1"); err != nil {
+		t.Fatal(err)
+	}
+	if a := w.Country("Alaska").Occupier; a.Player != "P1" || a.Strength < 1 {
+		t.Fatal(a)
+	}
+}
+
+func TestWorld_AttackOrMove_peacefulRounds(t *testing.T) {
+	// P2 occupies Egypt, P1 the rest
+	w := NewWorld()
+	w.NoLog = true
+	w.PeacefulRounds = 1
+	w.PlayerQueue = []*Player{{Name: "P1", Reinforcement: 5}, {Name: "P2"}}
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 5, "P1", c.Name)
+	}
+	w.Country("Egypt").Occupier.Player = "P2"
+	w.Country("North Africa").RecruitingRegion = true
+
+	// buildup phase
+	if err := w.AttackOrMove("North Africa", "Egypt", 2, "P1"); err == nil || err.Error() != "no attacks in peaceful rounds" {
+		t.Fatal(err)
+	}
+	if err := w.CanAttackOrMove("North Africa", "Egypt", 2, "P1"); err == nil {
+		t.Fatal("attack allowed")
+	}
+	if err := w.AttackOrMove("North Africa", "Congo", 2, "P1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.AttackOrMove("North Africa", "North Africa", 2, "P1"); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{"P1", "P2"} {
+		if _, err := w.EndTurn(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// combat
+	if err := w.AttackOrMove("North Africa", "Egypt", 2, "P1"); err != nil || w.Round != 1 {
+		t.Fatal(err, w.Round)
+	}
+}
+
+func TestWorld_AttackOrMove_minGarrison(t *testing.T) {
+	w := NewWorld()
+	w.NoLog = true
+	w.MinGarrison = 2
+	w.PlayerQueue = []*Player{{Name: "P1"}, {Name: "P2"}}
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 6, "P1", c.Name)
+	}
+	w.Country("Egypt").Occupier.Player = "P2"
+	w.Country("North Africa").MinGarrison = 3
+
+	// global garrison: all orders of the turn count together
+	if err := w.AttackOrMove("Congo", "East Africa", 3, "P1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.AttackOrMove("Congo", "South Africa", 2, "P1"); err == nil || err.Error() != "at least 2 men must stay behind" {
+		t.Fatal(err)
+	}
+	if err := w.AttackOrMove("Congo", "South Africa", 1, "P1"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the garrison of the country
+	if g := w.Country("North Africa").Garrison(); g != 3 {
+		t.Fatal(g)
+	}
+	if err := w.CanAttackOrMove("North Africa", "Egypt", 4, "P1"); err == nil || err.Error() != "at least 3 men must stay behind" {
+		t.Fatal(err)
+	}
+	if err := w.AttackOrMove("North Africa", "Egypt", 3, "P1"); err != nil {
+		t.Fatal(err)
+	}
+	if targets := w.Country("North Africa").Occupier.LegalTargets(); len(targets) != 0 {
+		t.Fatal(targets)
+	}
+
+	// default: one man
+	w.MinGarrison = 0
+	if g := w.Country("Congo").Garrison(); g != 1 || w.Rules().MinGarrison != 1 {
+		t.Fatal(g)
+	}
+}
+
+func TestWorld_EndTurn_discardReinforcement(t *testing.T) {
+	w := NewWorld()
+	w.NoLog = true
+	w.PlayerQueue = []*Player{{Name: "P1", Reinforcement: 5}, {Name: "P2", Reinforcement: 4}}
+	for _, c := range w.Countries {
+		c.Occupier = NewArmy(w, 5, "P1", c.Name)
+	}
+	w.Country("Egypt").Occupier.Player = "P2"
+	w.Country("North Africa").RecruitingRegion = true
+
+	// default: the units are kept
+	if turn, err := w.EndTurn("P1"); err != nil || turn.Discarded != 0 || w.Player("P1").Reinforcement != 5 {
+		t.Fatal(err, turn.Discarded, w.Player("P1").Reinforcement)
+	}
+
+	// use it or lose it (the reinforcement of the new round is kept)
+	w.DiscardReinforcement = true
+	turn, err := w.EndTurn("P2")
+	if err != nil || turn.Discarded != 4 || w.Player("P2").Reinforcement != turn.Reinforcements["P2"] {
+		t.Fatal(err, turn.Discarded, w.Player("P2").Reinforcement)
+	}
+	if !turn.NewRound || w.Player("P1").Reinforcement <= 5 {
+		t.Fatal("no new reinforcement", w.Player("P1").Reinforcement)
+	}
+	if err := w.AttackOrMove("North Africa", "North Africa", 2, "P1"); err != nil {
+		t.Fatal(err)
+	}
+	left := w.Player("P1").Reinforcement
+	if turn, err := w.EndTurn("P1"); err != nil || turn.Discarded != left || w.Player("P1").Reinforcement != 0 {
+		t.Fatal(err, turn.Discarded, w.Player("P1").Reinforcement)
+	}
+
+	// a bonus won in the battles of the turn is kept
+	w.FirstConquestBonus = 5
+	w.Country("Congo").Occupier.Player = "P2" // P2 survives the loss of Egypt
+	if _, err := w.EndTurn("P2"); err != nil {
+		t.Fatal(err)
+	}
+	left = w.Player("P1").Reinforcement
+	w.Country("Egypt").Invader = NewArmy(w, 1000, "P1", "North Africa")
+	if turn, err := w.EndTurn("P1"); err != nil || turn.Discarded != left || w.Player("P1").Reinforcement != 5 {
+		t.Fatal(err, turn.Discarded, w.Player("P1").Reinforcement)
+	}
+}
+
+func TestWorld_AttackOrMove_recruitOwnership(t *testing.T) {
+	w := twoPlayerWorld(t)
+
+	// one recruiting and one other country for each player
+	countries := map[bool]map[bool]string{true: {}, false: {}} // own -> recruiting -> country
+	for _, c := range w.sortedCountryList() {
+		for _, own := range []bool{true, false} {
+			if _, ok := countries[own][c.RecruitingRegion]; !ok {
+				owner := "P1"
+				if !own {
+					owner = "P2"
+				}
+				_ = w.SetCountryOwner(c.Name, owner, 3)
+				countries[own][c.RecruitingRegion] = c.Name
+				break
+			}
+		}
+	}
+
+	tests := []struct {
+		own        bool
+		recruiting bool
+		want       string
+	}{
+		{own: true, recruiting: true, want: ""},
+		{own: true, recruiting: false, want: "cannot recruit in this region"},
+		{own: false, recruiting: true, want: "cannot command enemy armies"},
+		{own: false, recruiting: false, want: "cannot command enemy armies"},
+	}
+	for _, tt := range tests {
+		country := countries[tt.own][tt.recruiting]
+		w.Player("P1").Reinforcement = 1
+
+		got := ""
+		if err := w.AttackOrMove(country, country, 1, "P1"); err != nil {
+			got = err.Error()
+		}
+		if got != tt.want {
+			t.Fatalf("own=%v recruiting=%v (%s): got %q, want %q", tt.own, tt.recruiting, country, got, tt.want)
+		}
+		if tt.want != "" && w.Country(country).Invader != nil {
+			t.Fatalf("%s: rejected recruitment queued", country)
+		}
+	}
+}
@@ -18,27 +18,46 @@ func main() {
 	const host = "localhost"
 	const port = "1234"
 
-	// init client
+	// init client (TCP connection to the server)
 	client, err := remote.NewClient(host, port)
 	if err != nil {
 		panic(err)
 	}
 
+	// run the AI
+	play(client, name, clr)
+}
+
+// play runs the AI with any client implementation (see remote.GameClient).
+// Use remote.NewLocalClient to run the same AI in-process, or a mock client for testing.
+func play(client remote.GameClient, name string, clr color.RGBA) {
+
 	// add player
 	if err := client.AddPlayer(name, clr); err != nil {
 		panic(err)
 	}
 
+	// confirm in the lobby (an error only means the game has already started)
+	_ = client.Ready()
+
 	// Loop indefinitely, checking if it's the player's turn.
 	for {
+		// Check if it's the specified player's turn (the server knows the player of the connection).
+		myTurn, _, err := client.MyTurn()
+		if err != nil {
+			fmt.Printf("my turn error: %v\n", err)
+		}
+
 		// load world
 		world := new(core.World)
-		if err := client.Status(world); err != nil {
-			fmt.Printf("load word error: %v\n", err)
+		if myTurn {
+			if err := client.Status(world); err != nil {
+				fmt.Printf("load word error: %v\n", err)
+				myTurn = false
+			}
 		}
 
-		// Check if it's the specified player's turn.
-		if !world.Freeze && len(world.PlayerQueue) > 1 && world.PlayerQueue[0].Name == name {
+		if myTurn {
 			// --------- RUN AI -------------------------------------------------------------------
 
 			// TODO: implement your ai here
@@ -58,6 +58,16 @@ def add_player(name, color_r, color_g, color_b):
     return command("PLAYER|%s|%d|%d|%d" % (name, color_r, color_g, color_b))
 
 
+# ready confirms in the lobby that the game can start.
+def ready():
+    return command("READY")
+
+
+# my_turn returns True if it is the turn of the player (the server knows the player of the connection).
+def my_turn():
+    return command("MYTURN").split("|")[0] == "YES"
+
+
 # status returns a json with all world data.
 def world_status():
     return command("STATUS")
@@ -85,6 +95,8 @@ if __name__ == '__main__':
 
     # commands:
     #   add_player(name, color_r, color_g, color_b) -> error
+    #   ready() -> error
+    #   my_turn() -> bool
     #   world_status() -> json
     #   attack_or_move(attacker, defender, strength) -> error
     #   reinforcement(country, strength) -> error
@@ -95,17 +107,18 @@ if __name__ == '__main__':
     err = add_player(name=playerName, color_r=252, color_g=3, color_b=236)
     if err != "OK":
         exit(err)
+    ready()  # an error only means the game has already started
 
     # Main AI loop
     while True:
         time.sleep(0.30)  # Prevent server denial of service (DoS) by pacing requests.
 
-        # Get the current state of the game world from the server.
-        json_str = world_status()
-        world = json.loads(json_str)
-
         # Check if it's the specified player's turn.
-        if not world['Freeze'] and len(world['PlayerQueue']) > 1 and world['PlayerQueue'][0]['Name'] == playerName:
+        if my_turn():
+            # Get the current state of the game world from the server.
+            json_str = world_status()
+            world = json.loads(json_str)
+
             print("MY TURN")
             #--------------------------------------------------------------------------------------
 
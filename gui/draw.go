@@ -2,17 +2,19 @@ package gui
 
 import (
 	"RISK-CodeConflict/core"
+	"RISK-CodeConflict/gui/labels"
+	"RISK-CodeConflict/gui/resources"
 	"fmt"
-	"github.com/golang/freetype/truetype"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"github.com/hajimehoshi/ebiten/v2/vector"
-	"golang.org/x/image/font"
-	"golang.org/x/image/font/gofont/gomono"
 	"image/color"
 	"math"
+	"slices"
+	"sort"
 	"strings"
+	"unicode/utf8"
 )
 
 // drawAllMark draws marks on the screen for the selected country and its neighbors.
@@ -37,6 +39,13 @@ func (g *GUI) drawAllMark(screen *ebiten.Image, bgImgWidth, bgImgHeight float64)
 		}
 	}
 
+	// Mark the capitals with a golden ring (see core.World.CapitalRule)
+	for _, p := range g.world.PlayerQueue {
+		if c := g.world.Countries[p.Capital]; c != nil {
+			g.drawMark(screen, bgImgWidth, bgImgHeight, c, 0.065, color.RGBA{}, capitalColor)
+		}
+	}
+
 	// Check if there is a selected country
 	if g.selectCountry != nil {
 		clr := color.Black // Default color for the mark
@@ -62,6 +71,9 @@ func (g *GUI) drawAllMark(screen *ebiten.Image, bgImgWidth, bgImgHeight float64)
 	}
 }
 
+// capitalColor is the color of the ring that marks a capital (see drawAllMark).
+var capitalColor = color.RGBA{R: 255, G: 200, B: 0, A: 255}
+
 // drawMark draws a mark (filled circle) on the screen at a specified position and size.
 // The size of the mark is relative to the background image size.
 func (g *GUI) drawMark(screen *ebiten.Image, bgImgWidth, bgImgHeight float64, country *core.Country, markSizeRelToBg float64, clr, clr2 color.Color) {
@@ -73,6 +85,11 @@ func (g *GUI) drawMark(screen *ebiten.Image, bgImgWidth, bgImgHeight float64, co
 	countryPosX := float64(country.Position[0])*bgImgWidth/core.CountryPosScaleWidth - float64(g.viewport[0])
 	countryPosY := float64(country.Position[1])*bgImgHeight/core.CountryPosScaleHeight - float64(g.viewport[1])
 
+	// Skip marks outside the visible area
+	if !onScreen(screen, countryPosX, countryPosY, bgImgWidth*cullMargin) {
+		return
+	}
+
 	// Draw a filled circle (mark) on the background image at the calculated position and size
 	vector.DrawFilledCircle(screen, float32(countryPosX), float32(countryPosY), float32(radius), clr, false)
 	drawCircle(screen, countryPosX, countryPosY, radius, clr2)
@@ -82,17 +99,23 @@ func (g *GUI) drawMark(screen *ebiten.Image, bgImgWidth, bgImgHeight float64, co
 
 // drawAllStats renders the military statistics (e.g., army strength) for all countries
 // on the game map. It draws visual markers representing the occupier's and invader's army
-// strengths at the respective positions of each country.
+// strengths at the respective positions of each country. The markers of densely packed countries
+// are moved apart and scaled down (see statPlacements).
 //
 // Parameters:
 // - screen: The *ebiten.Image object representing the game's display screen.
 // - bgImgWidth: The width of the background image used as the game map.
 // - bgImgHeight: The height of the background image used as the game map.
 func (g *GUI) drawAllStats(screen *ebiten.Image, bgImgWidth, bgImgHeight float64) {
+	placements := g.statPlacements(bgImgWidth, bgImgHeight)
+
 	// Countries
 	for _, c := range g.world.Countries {
-		countryPosX := float64(c.Position[0])
-		countryPosY := float64(c.Position[1])
+		p := placements[c.Name]
+		countryPosX := float64(c.Position[0]) + p.DX
+		countryPosY := float64(c.Position[1]) + p.DY
+		// Skip the stats of countries outside the visible area (movement lines may still cross it)
+		visible := g.countryOnScreen(screen, bgImgWidth, bgImgHeight, countryPosX, countryPosY)
 		// Invader
 		if c.Invader != nil && c.Invader.Strength > 0 {
 			// Invader movement
@@ -102,15 +125,71 @@ func (g *GUI) drawAllStats(screen *ebiten.Image, bgImgWidth, bgImgHeight float64
 				g.drawMovement(screen, bgImgWidth, bgImgHeight, countryPosX-30, countryPosY-30, homePosX, homePosY, c.Invader.PlayerObj().Color)
 			}
 			// Invader stats
-			g.drawStats(screen, bgImgWidth, bgImgHeight, countryPosX-30, countryPosY-30, 0.011, c.Invader.PlayerObj().Color, c.Invader.Strength)
+			if visible {
+				g.drawStats(screen, bgImgWidth, bgImgHeight, countryPosX-30, countryPosY-30, 0.011*p.Scale, c.Invader.PlayerObj().Color, c.Invader.Strength)
+			}
 		}
 		// Occupier stats
-		if c.Occupier != nil {
-			g.drawStats(screen, bgImgWidth, bgImgHeight, countryPosX, countryPosY, 0.02, c.Occupier.PlayerObj().Color, c.Occupier.Strength)
+		if c.Occupier != nil && visible {
+			g.drawStats(screen, bgImgWidth, bgImgHeight, countryPosX, countryPosY, occupierMarkSize*p.Scale, c.Occupier.PlayerObj().Color, c.Occupier.Strength)
 		}
 	}
 }
 
+// cullMargin is the margin around the screen, relative to the width of the map, within which countries
+// are still drawn (see countryOnScreen). It covers the largest mark and the offset of the invader stats,
+// so marks that are only partially visible are not cut off.
+const cullMargin = 0.05
+
+// countryOnScreen reports whether a position in country coordinates (see core.CountryPosScaleWidth)
+// is within the visible area (plus cullMargin) at the current zoom and viewport.
+// The draw functions skip the countries outside, which saves most of the work when zoomed in.
+func (g *GUI) countryOnScreen(screen *ebiten.Image, bgImgWidth, bgImgHeight, countryPosX, countryPosY float64) bool {
+	posX := countryPosX*bgImgWidth/core.CountryPosScaleWidth - float64(g.viewport[0])
+	posY := countryPosY*bgImgHeight/core.CountryPosScaleHeight - float64(g.viewport[1])
+	return onScreen(screen, posX, posY, bgImgWidth*cullMargin)
+}
+
+// onScreen reports whether a screen position is within the screen plus a margin in pixels.
+func onScreen(screen *ebiten.Image, posX, posY, margin float64) bool {
+	b := screen.Bounds()
+	return posX >= float64(b.Min.X)-margin && posX <= float64(b.Max.X)+margin &&
+		posY >= float64(b.Min.Y)-margin && posY <= float64(b.Max.Y)+margin
+}
+
+// occupierMarkSize is the size of the occupier marker relative to the width of the map (see drawStats).
+const occupierMarkSize = 0.02
+
+// statPlacements returns the placement of the occupier markers of all countries (see labels.Layout).
+// The placement is computed in country coordinates (see core.CountryPosScaleWidth), which do not depend
+// on the zoom level, so it is only computed once.
+func (g *GUI) statPlacements(bgImgWidth, bgImgHeight float64) map[string]labels.Placement {
+	if g.statLayout != nil {
+		return g.statLayout
+	}
+
+	names := make([]string, 0, len(g.world.Countries))
+	for name := range g.world.Countries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// marker size in country coordinates (the marker size is relative to the width of the map)
+	w := occupierMarkSize * core.CountryPosScaleWidth
+	h := occupierMarkSize * bgImgWidth * core.CountryPosScaleHeight / bgImgHeight
+	boxes := make([]labels.Box, len(names))
+	for i, name := range names {
+		c := g.world.Countries[name]
+		boxes[i] = labels.Box{X: float64(c.Position[0]), Y: float64(c.Position[1]), W: w, H: h}
+	}
+
+	g.statLayout = make(map[string]labels.Placement, len(names))
+	for i, p := range labels.Layout(boxes, g.labelOptions) {
+		g.statLayout[names[i]] = p
+	}
+	return g.statLayout
+}
+
 // drawStats draws a visual marker representing the army strength for a country at its map position.
 // It also displays the numerical strength of the army next to the visual marker.
 //
@@ -140,16 +219,11 @@ func (g *GUI) drawStats(screen *ebiten.Image, bgImgWidth, bgImgHeight, countryPo
 	// stat text (army count)
 	txt := fmt.Sprintf("%d", strength)
 	txtSize := radius * 1.4
-	// Parse the TrueType font from the provided font data
-	// Create a new font face with the specified size and full hinting for better readability
-	ttFont, _ := truetype.Parse(gomono.TTF)
-	fontFace := truetype.NewFace(ttFont, &truetype.Options{
-		Size:    txtSize,
-		Hinting: font.HintingFull,
-	})
+	// Get the cached font face with the specified size (see SetLabelFont)
+	fontFace := labelFace(txtSize)
 	// Adjust the position to center the text horizontally and vertically relative to the given position
-	posX -= float64(len(txt)) * txtSize * 0.31 // Adjust horizontally
-	posY += txtSize * 0.35                     // Adjust vertically
+	posX -= labelWidth(txt, txtSize) / 2 // Adjust horizontally
+	posY += txtSize * 0.35               // Adjust vertically
 	// Draw the main text at the adjusted position with the specified color
 	text.Draw(screen, txt, fontFace, int(posX), int(posY), color.Black)
 }
@@ -173,36 +247,214 @@ func (g *GUI) drawControls(screen *ebiten.Image) {
 	// generate text
 	sb := new(strings.Builder)
 	sb.WriteString(fmt.Sprintf("Round: %d.%d\n", g.world.Round, g.world.SubRound+1))
-	sb.WriteString("Press Enter to end the turn.\n\nPlayer queue:\n")
+	switch {
+	case g.waitingForOpponents():
+		sb.WriteString("Waiting for opponents ...\n")
+		if g.addOpponent != nil {
+			sb.WriteString("Press I to add an AI opponent.\n")
+		}
+	case g.world.Phase == core.PhaseLobby:
+		sb.WriteString("Waiting for players ...\n")
+	case g.world.Phase == core.PhaseFinished:
+		sb.WriteString(fmt.Sprintf("Game over, winner: %s\n", g.world.Winner()))
+	}
+	if !g.waitingForOpponents() {
+		sb.WriteString("Press Enter to end the turn.\n")
+	}
+	if g.confirmEndTurn && len(g.world.PlayerQueue) > 0 {
+		sb.WriteString(fmt.Sprintf("WARNING: %d units are not deployed and will be lost!\nPress Enter again to end the turn.\n",
+			g.world.PlayerQueue[0].Reinforcement))
+	}
+	sb.WriteString("Press L to show the legend.\n\nPlayer queue (> active, * you):\n")
+	sb.WriteString(g.scoreboard())
+	// print
+	ebitenutil.DebugPrintAt(screen, sb.String(), 10, 10)
+}
+
+// scoreboard returns the player queue with the reinforcement, the number of countries and the total strength
+// of every player (see core.World.PlayerStrength), e.g. " > Alice* [5] 12 countries, 34 units".
+// The active player is marked with ">", the local players with "*". A "+" after the units means that
+// some strengths are hidden by the fog of war.
+func (g *GUI) scoreboard() string {
+	sb := new(strings.Builder)
 	for i, po := range g.world.PlayerQueue {
 		if i == 0 {
 			sb.WriteString(" > ")
 		} else {
 			sb.WriteString(" - ")
 		}
-		sb.WriteString(fmt.Sprintf("%s [%d]\n", po.Name, po.Reinforcement))
+		sb.WriteString(po.Name)
+		if slices.Contains(g.localPlayers, po.Name) {
+			sb.WriteString("*")
+		}
+		stats := g.world.PlayerStrength(po.Name)
+		fogged := ""
+		if stats.Fogged {
+			fogged = "+"
+		}
+		sb.WriteString(fmt.Sprintf(" [%d] %d countries, %d%s units\n", stats.Reinforcement, stats.Countries, stats.Strength, fogged))
 	}
-	// print
-	ebitenutil.DebugPrintAt(screen, sb.String(), 10, 10)
+	return sb.String()
+}
+
+// drawTurnBorder frames the screen in the color of the active player, with the name of the player in a small tab
+// at the top edge, so a new turn is noticed at once (e.g. in hotseat games). The frame is thin and half transparent
+// to keep the map visible; it is only drawn while the game is running.
+func (g *GUI) drawTurnBorder(screen *ebiten.Image) {
+	if g.world.Freeze || g.world.Phase != core.PhasePlaying || len(g.world.PlayerQueue) < 1 {
+		return
+	}
+	active := g.world.PlayerQueue[0]
+	clr := active.Color
+	clr.A = 180
+
+	// frame
+	const thickness = 4
+	w, h := float32(g.screenWidth), float32(g.screenHeight)
+	vector.StrokeRect(screen, thickness/2, thickness/2, w-thickness, h-thickness, thickness, clr, false)
+
+	// tab with the name (debug font: 6x16 pixels per character)
+	txt := active.Name
+	if slices.Contains(g.localPlayers, active.Name) {
+		txt += " (you)"
+	}
+	tabW := float32(utf8.RuneCountInString(txt)*6 + 16)
+	x0 := (w - tabW) / 2
+	vector.DrawFilledRect(screen, x0, 0, tabW, 20, clr, false)
+	ebitenutil.DebugPrintAt(screen, txt, int(x0)+8, 2)
+}
+
+// drawTargeting shows the number of units a right-click will commit next to the cursor.
+// It is only drawn in targeting mode (see updateTargeting).
+func (g *GUI) drawTargeting(screen *ebiten.Image) {
+	if !g.targeting || g.targetCountry == nil {
+		return
+	}
+
+	// generate text
+	action := "move/attack"
+	if g.targetCountry == g.selectCountry {
+		action = "recruit"
+	}
+	txt := fmt.Sprintf("%s: %d\n(mouse wheel)", action, g.targetStrength)
+
+	// print next to the cursor
+	x, y := ebiten.CursorPosition()
+	ebitenutil.DebugPrintAt(screen, txt, x+16, y+16)
+}
+
+// oddsKey identifies a battle whose win probability is cached (see winProbability).
+type oddsKey struct {
+	attacker, defender int
+	fortress           bool
+}
+
+// drawAllOdds labels each attackable neighbor of the selected country with the probability that an attack
+// with all movable units conquers it (see core.WinProbability): green if the odds are favorable, red if not.
+// Only a selected country of the active player has attack options. Own neighbors and fogged neighbors
+// (see core.FogStrength) get no label.
+func (g *GUI) drawAllOdds(screen *ebiten.Image, bgImgWidth, bgImgHeight float64) {
+	sc := g.selectCountry
+	if sc == nil || sc.Occupier == nil || sc.Occupier.Strength < 2 || len(g.world.PlayerQueue) == 0 {
+		return
+	}
+	if sc.Occupier.Player != g.world.PlayerQueue[0].Name {
+		return
+	}
+	radius := (bgImgWidth * 0.053) / 2
+
+	for _, nc := range sc.NeighborsObj() {
+		if nc.Occupier == nil || nc.Occupier.Player == sc.Occupier.Player || nc.Occupier.Strength < 0 {
+			continue
+		}
+		if !g.countryOnScreen(screen, bgImgWidth, bgImgHeight, float64(nc.Position[0]), float64(nc.Position[1])) {
+			continue
+		}
+		p := g.winProbability(sc.Occupier.Strength-sc.Garrison(), nc.Occupier.Strength, nc.FortressRegion)
+
+		// label below the mark of the neighbor
+		clr := color.RGBA{R: 200, G: 30, B: 30, A: 220}
+		if p >= 0.5 {
+			clr = color.RGBA{R: 30, G: 150, B: 30, A: 220}
+		}
+		txt := fmt.Sprintf("%d%%", int(math.Round(p*100)))
+		posX := float64(nc.Position[0])*bgImgWidth/core.CountryPosScaleWidth - float64(g.viewport[0])
+		posY := float64(nc.Position[1])*bgImgHeight/core.CountryPosScaleHeight - float64(g.viewport[1]) + radius
+		w := float32(len(txt)*6 + 6)
+		vector.DrawFilledRect(screen, float32(posX)-w/2, float32(posY), w, 18, clr, false)
+		ebitenutil.DebugPrintAt(screen, txt, int(posX)-len(txt)*3, int(posY))
+	}
+}
+
+// winProbability is core.WinProbability with a cache, because the odds are drawn every frame.
+func (g *GUI) winProbability(attacker, defender int, fortress bool) float64 {
+	key := oddsKey{attacker: attacker, defender: defender, fortress: fortress}
+	if p, ok := g.odds[key]; ok {
+		return p
+	}
+	if g.odds == nil {
+		g.odds = make(map[oddsKey]float64)
+	}
+	p := core.WinProbability(attacker, defender, fortress)
+	g.odds[key] = p
+	return p
 }
 
 //--------------------------------------------------------------------------------------------------------------------//
 
-// drawCircle draws a circle on the given image with the specified center (cx, cy), radius, and color.
-func drawCircle(img *ebiten.Image, cx, cy, radius float64, col color.Color) {
-	// Loop over all points in the bounding box of the circle
-	for x := cx - radius; x <= cx+radius; x++ {
-		for y := cy - radius; y <= cy+radius; y++ {
-			// Calculate the distance from the center of the circle
-			dx := x - cx
-			dy := y - cy
-			dist := math.Sqrt(dx*dx + dy*dy)
-
-			// If the distance is approximately equal to the radius, set the pixel color
-			if math.Abs(dist-radius) < 1 {
-				img.Set(int(x-1), int(y), col)
-				img.Set(int(x), int(y), col)
-			}
+// legendEntry is a line of the map legend (see drawLegend).
+type legendEntry struct {
+	img  *ebiten.Image // symbol of the region type (nil: color swatch)
+	clr  color.Color   // color of the swatch (name color)
+	text string        // description
+}
+
+// drawLegend draws the legend of the map symbols and name colors in the bottom left corner.
+// It is toggled with the L key (see updateLegend).
+func (g *GUI) drawLegend(screen *ebiten.Image) {
+	if !g.showLegend {
+		return
+	}
+
+	entries := []legendEntry{
+		{img: resources.Imgs.Fortress, text: "fortress: defensive bonus, recruiting"},
+		{img: resources.Imgs.Village, text: "village: recruiting region"},
+		{img: resources.Imgs.Field, text: "field: border region"},
+		{clr: color.RGBA{R: 255, G: 222, B: 3, A: 255}, text: "yellow name: border region"},
+		{clr: color.RGBA{R: 255, G: 0, B: 0, A: 255}, text: "red name: fortress region"},
+		{clr: color.RGBA{R: 255, G: 255, B: 255, A: 255}, text: "white name: recruiting region"},
+		{clr: capitalColor, text: "golden ring: capital"},
+	}
+
+	// background
+	const lineHeight = 28
+	const width = 300
+	height := float32(len(entries)*lineHeight + 10)
+	x0 := float32(10)
+	y0 := float32(g.screenHeight) - height - 10
+	vector.DrawFilledRect(screen, x0, y0, width, height, color.RGBA{A: 180}, false)
+
+	// entries
+	for i, e := range entries {
+		y := y0 + 5 + float32(i*lineHeight)
+		if e.img != nil {
+			// symbol scaled to the line height
+			op := new(ebiten.DrawImageOptions)
+			scale := float64(lineHeight-4) / float64(e.img.Bounds().Dx())
+			op.GeoM.Scale(scale, scale)
+			op.GeoM.Translate(float64(x0+5), float64(y))
+			op.Filter = ebiten.FilterLinear
+			screen.DrawImage(e.img, op)
+		} else {
+			// color swatch
+			vector.DrawFilledRect(screen, x0+9, y+6, lineHeight-12, lineHeight-12, e.clr, false)
 		}
+		ebitenutil.DebugPrintAt(screen, e.text, int(x0)+lineHeight+10, int(y)+6)
 	}
 }
+
+// drawCircle draws a circle outline (2 pixels wide, anti-aliased) on the given image
+// with the specified center (cx, cy), radius, and color.
+func drawCircle(img *ebiten.Image, cx, cy, radius float64, col color.Color) {
+	vector.StrokeCircle(img, float32(cx), float32(cy), float32(radius), 2, col, true)
+}
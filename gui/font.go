@@ -0,0 +1,74 @@
+package gui
+
+import (
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/math/fixed"
+)
+
+// labelFont is the font of the country names and army strengths (see SetLabelFont).
+var labelFont = mustParseFont(gomono.TTF)
+
+// labelFaces caches the font faces of labelFont by size, so the font is not prepared again for every label.
+// The sizes depend on the zoom, so the cache is cleared when it holds maxLabelFaces faces.
+var labelFaces = make(map[float64]font.Face)
+
+// maxLabelFaces limits the number of cached font faces (see labelFaces).
+const maxLabelFaces = 32
+
+// SetLabelFont replaces the default font (Go Mono) of the map labels, e.g. with a font that has the glyphs
+// of the non-ASCII country names of a custom map. It must be called before RunGUI.
+//
+// Parameters:
+//   - ttf: The TrueType font data.
+//
+// Returns:
+//   - An error if the font cannot be parsed. The default font is kept in this case.
+func SetLabelFont(ttf []byte) error {
+	f, err := truetype.Parse(ttf)
+	if err != nil {
+		return err
+	}
+	labelFont = f
+	labelFaces = make(map[float64]font.Face)
+	return nil
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// labelFace returns the face of labelFont with the given size and full hinting for better readability.
+func labelFace(size float64) font.Face {
+	face, ok := labelFaces[size]
+	if !ok {
+		if len(labelFaces) >= maxLabelFaces {
+			labelFaces = make(map[float64]font.Face)
+		}
+		face = truetype.NewFace(labelFont, &truetype.Options{
+			Size:    size,
+			Hinting: font.HintingFull,
+		})
+		labelFaces[size] = face
+	}
+	return face
+}
+
+// labelWidth returns the width of a text drawn with labelFace in pixels.
+// It adds up the unhinted advances of the glyphs, which is much cheaper than measuring with the face.
+func labelWidth(txt string, size float64) float64 {
+	scale := fixed.Int26_6(size * 64)
+	var width fixed.Int26_6
+	for _, r := range txt {
+		width += labelFont.HMetric(scale, labelFont.Index(r)).AdvanceWidth
+	}
+	return float64(width) / 64
+}
+
+// mustParseFont parses the embedded default font, which cannot fail.
+func mustParseFont(ttf []byte) *truetype.Font {
+	f, err := truetype.Parse(ttf)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
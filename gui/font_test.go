@@ -0,0 +1,57 @@
+package gui
+
+import (
+	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gomono"
+	"testing"
+)
+
+// labelSizes are the text sizes of a draw pass: the names (preprocessText) and the stats (drawStats)
+// of all countries at the default zoom.
+var labelSizes = func() []float64 {
+	sizes := make([]float64, 0, 84)
+	for i := 0; i < 42; i++ {
+		sizes = append(sizes, 22.9, 14.7)
+	}
+	return sizes
+}()
+
+func TestLabelFace(t *testing.T) {
+	if labelFace(12) != labelFace(12) {
+		t.Fatal("face not cached")
+	}
+	for i := 0; i < 2*maxLabelFaces; i++ {
+		labelFace(float64(i))
+	}
+	if len(labelFaces) > maxLabelFaces {
+		t.Fatal(len(labelFaces))
+	}
+	if w := labelWidth("Alaska", 10); w < 30 || w > 40 { // Go Mono: 0.6 per glyph
+		t.Fatal(w)
+	}
+	if err := SetLabelFont([]byte("no font")); err == nil {
+		t.Fatal("invalid font accepted")
+	}
+}
+
+// BenchmarkDrawPass_cached prepares the fonts of all labels of a draw pass with the cache (see labelFace).
+func BenchmarkDrawPass_cached(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for _, size := range labelSizes {
+			_ = labelFace(size)
+			_ = labelWidth("Northwest Territory", size)
+		}
+	}
+}
+
+// BenchmarkDrawPass_parsed prepares the fonts of all labels of a draw pass like before the cache:
+// the font is parsed and a new face is created for every label.
+func BenchmarkDrawPass_parsed(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for _, size := range labelSizes {
+			ttFont, _ := truetype.Parse(gomono.TTF)
+			_ = truetype.NewFace(ttFont, &truetype.Options{Size: size, Hinting: font.HintingFull})
+		}
+	}
+}
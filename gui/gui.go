@@ -2,7 +2,9 @@ package gui
 
 import (
 	"RISK-CodeConflict/core"
+	"RISK-CodeConflict/gui/labels"
 	"RISK-CodeConflict/gui/resources"
+	"RISK-CodeConflict/remote"
 	"github.com/hajimehoshi/ebiten/v2"
 	"image"
 )
@@ -32,6 +34,27 @@ type GUI struct {
 
 	selectCountry *core.Country // saves a country selected via the GUI
 
+	odds map[oddsKey]float64 // cached win probabilities of the attack options (see winProbability)
+
+	targeting      bool          // A flag indicating whether the mouse hovers a valid target (the wheel sets the strength).
+	targetCountry  *core.Country // The hovered target of the selected country.
+	targetStrength int           // The number of units a right-click will commit.
+	lastCursorX    int           // The last recorded X position of the cursor, used to move the targeting feedback.
+	lastCursorY    int           // The last recorded Y position of the cursor, used to move the targeting feedback.
+
+	showLegend bool // A flag indicating whether the legend of the map symbols is shown (toggled with L).
+
+	confirmEndTurn bool // Enter was pressed once with unspent reinforcement that would be lost (see updateTurn).
+
+	client  remote.GameClient // The client of a remote game (nil: the world is local, see RunRemoteGUI).
+	updates chan *core.World  // The changed worlds of a remote game (see pollStatus).
+
+	addOpponent  func()   // Adds an AI opponent to a single-player world (nil: not offered, see updateOpponents).
+	localPlayers []string // The players commanded at this screen (marked in the player queue, see drawControls).
+
+	labelOptions labels.Options              // The options of the collision-aware placement of names and stats.
+	statLayout   map[string]labels.Placement // The placement of the stats of each country (see statPlacements).
+
 	lastRound    int // save last round to detect changes
 	lastSubRound int // save last sub-round to detect changes
 }
@@ -49,17 +72,22 @@ func (g *GUI) Update() error {
 
 	// Call all update functions
 	//----------------------------
+	g.updateRemote()
+	g.updateTargeting()
 	g.updateZoomAndViewport()
 	g.updateActiveCountry()
 	g.updateAttackCountry()
 	g.updateTurn()
+	g.updateOpponents()
+	g.updateLegend()
 	//----------------------------
 
 	// auto redraw on changes
 	if g.world != nil && (g.world.Round != g.lastRound || g.world.SubRound != g.lastSubRound) {
 		g.lastRound = g.world.Round
 		g.lastSubRound = g.world.SubRound
-		g.redraw = true // redraw
+		g.confirmEndTurn = false // the warning belongs to the last turn
+		g.redraw = true          // redraw
 	}
 
 	return nil
@@ -83,7 +111,7 @@ func (g *GUI) Draw(screen *ebiten.Image) {
 		// Debug output to track when preprocess is called
 		//println("call preprocess", time.Now().String(), "zoom:", g.zoom, "lastZoom:", g.lastZoom) // DEBUG GUI
 		// Call the preprocess function to create the basic image with updated parameters (zoom)
-		g.preprocessedImg = preprocess(float64(g.screenWidth)*g.zoom, float64(g.screenHeight)*g.zoom, g.world.Countries)
+		g.preprocessedImg = preprocess(float64(g.screenWidth)*g.zoom, float64(g.screenHeight)*g.zoom, g.world.Countries, g.labelOptions)
 		// Store the current zoom level as the last known zoom level
 		g.lastZoom = g.zoom
 	}
@@ -102,7 +130,11 @@ func (g *GUI) Draw(screen *ebiten.Image) {
 	bgImgHeight := float64(g.preprocessedImg.Bounds().Dy())
 	g.drawAllMark(screen, bgImgWidth, bgImgHeight)
 	g.drawAllStats(screen, bgImgWidth, bgImgHeight)
+	g.drawAllOdds(screen, bgImgWidth, bgImgHeight)
+	g.drawTurnBorder(screen)
 	g.drawControls(screen)
+	g.drawTargeting(screen)
+	g.drawLegend(screen)
 	//----------------------------------------------------------------
 
 	// Debugging: Print a message indicating the Draw method has been called
@@ -134,9 +166,40 @@ func (g *GUI) Layout(_, _ int) (int, int) {
 
 // RunGUI initializes the game window and starts the GUI loop.
 // The Draw function is called with 30 Ticks per second.
+// With labelLayout, overlapping country names and stats are moved apart and scaled down (see labels.Layout).
+// As long as the world has less than two players, the GUI shows that it is waiting for opponents;
+// if addOpponent is not nil, the I key calls it to add an AI opponent.
+// The local players are the human players at this screen; they are marked in the player queue.
 //
 // This function is blocking!
-func RunGUI(screenWidth, screenHeight int, title string, world *core.World, autoRedraw bool) error {
+func RunGUI(screenWidth, screenHeight int, title string, world *core.World, autoRedraw, labelLayout bool, addOpponent func(), localPlayers ...string) error {
+	gui := newGUI(screenWidth, screenHeight, world, autoRedraw, labelLayout)
+	gui.addOpponent = addOpponent
+	gui.localPlayers = localPlayers
+	return runGame(gui, title)
+}
+
+// newGUI creates a GUI for the world with the default zoom and viewport (see RunGUI).
+func newGUI(screenWidth, screenHeight int, world *core.World, autoRedraw, labelLayout bool) *GUI {
+	gui := &GUI{
+		world:        world,
+		screenWidth:  screenWidth,
+		screenHeight: screenHeight,
+		zoom:         1,
+		redraw:       true,
+		autoRedraw:   autoRedraw,
+		labelOptions: labels.Options{MinScale: 1}, // labels are neither moved nor scaled
+	}
+	if labelLayout {
+		gui.labelOptions = labels.DefaultOptions
+	}
+	return gui
+}
+
+// runGame sets up the window and runs the game loop of the GUI.
+//
+// This function is blocking!
+func runGame(gui *GUI, title string) error {
 
 	// Constants for the configuration
 	const (
@@ -149,23 +212,13 @@ func RunGUI(screenWidth, screenHeight int, title string, world *core.World, auto
 	// Setup window properties
 	ebiten.SetWindowTitle(title)                                   // Set the window title
 	ebiten.SetWindowIcon([]image.Image{resources.Imgs.Icon})       // Set the window icon
-	ebiten.SetWindowSize(screenWidth, screenHeight)                // Set the window size
+	ebiten.SetWindowSize(gui.screenWidth, gui.screenHeight)        // Set the window size
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled) // Allow resizing of the window
 	ebiten.SetTPS(tps)                                             // Set the ticks per second (game loop frequency)
 	ebiten.SetWindowDecorated(decorated)                           // Enable window decoration (title bar, borders)
 	ebiten.SetWindowFloating(floating)                             // Disable always-on-top behavior
 	ebiten.SetScreenClearedEveryFrame(clearEveryFrame)             // Disable automatic clearing of the screen every frame
 
-	// Create a new GUI instance
-	gui := &GUI{
-		world:        world,
-		screenWidth:  screenWidth,
-		screenHeight: screenHeight,
-		zoom:         1,
-		redraw:       true,
-		autoRedraw:   autoRedraw,
-	}
-
 	// Run the game loop (this call is blocking)
 	return ebiten.RunGame(gui)
 }
@@ -189,8 +242,9 @@ func (g *GUI) updateZoomAndViewport() {
 	const zoomSpeed = 10
 
 	// Handle mouse wheel input for zooming
+	// In targeting mode, the wheel sets the attack strength instead (see updateTargeting).
 	_, dy := ebiten.Wheel()
-	if dy != 0 {
+	if dy != 0 && !g.targeting {
 		// Get the current mouse position relative to the window
 		mouseX, mouseY := ebiten.CursorPosition()
 
@@ -0,0 +1,74 @@
+// Package hittest contains the screen math of the GUI that maps mouse positions to countries on the map.
+// It does not depend on ebiten, so it can be used and tested without a display.
+package hittest
+
+import (
+	"RISK-CodeConflict/core"
+)
+
+// BoxSize is the edge length of the square click box around a country at zoom level 1.0 (in pixels).
+const BoxSize = 100
+
+// ScreenPos returns the position of the country on the screen.
+// The country position (see core.Country.Position) is scaled to the size of the map image
+// and shifted by the viewport.
+//
+// Parameters:
+//   - country: The country.
+//   - viewport: The top left position (x, y) of the viewport on the map image.
+//   - imgWidth, imgHeight: The size of the map image at the current zoom level.
+//
+// Returns:
+//   - x, y: The screen position of the country.
+func ScreenPos(country *core.Country, viewport [2]int, imgWidth, imgHeight int) (x, y int) {
+	x = country.Position[0]*imgWidth/core.CountryPosScaleWidth - viewport[0]
+	y = country.Position[1]*imgHeight/core.CountryPosScaleHeight - viewport[1]
+	return
+}
+
+// CountryAtScreenPos returns the country of the list whose click box contains the screen position (x, y).
+// The click box is a square of BoxSize*zoom pixels around the screen position of the country (see ScreenPos),
+// including its border. The boxes of neighboring countries can overlap; in that case the country whose
+// center is nearest to (x, y) is returned. On equal distance, the country with the smaller name wins,
+// so the result does not depend on the order of the list.
+//
+// Parameters:
+//   - countries: The countries to check (e.g. all countries of the world or only the neighbors of a country).
+//   - viewport: The top left position (x, y) of the viewport on the map image.
+//   - zoom: The zoom level, where 1.0 represents 100%.
+//   - imgWidth, imgHeight: The size of the map image at the current zoom level.
+//   - x, y: The screen position (e.g. the mouse cursor).
+//
+// Returns:
+//   - The nearest country at the position or nil if no click box contains the position.
+func CountryAtScreenPos(countries []*core.Country, viewport [2]int, zoom float64, imgWidth, imgHeight, x, y int) *core.Country {
+	dim := int(BoxSize * zoom)
+
+	var result *core.Country
+	bestDist := 0
+	for _, country := range countries {
+		if country == nil {
+			continue
+		}
+		cx, cy := ScreenPos(country, viewport, imgWidth, imgHeight)
+
+		// object dimension
+		x1 := cx - dim/2
+		y1 := cy - dim/2
+		x2 := x1 + dim
+		y2 := y1 + dim
+
+		// check position
+		if x < x1 || x > x2 || y < y1 || y > y2 {
+			continue
+		}
+
+		// keep the nearest country (squared distance to the center)
+		dist := (x-cx)*(x-cx) + (y-cy)*(y-cy)
+		if result == nil || dist < bestDist || (dist == bestDist && country.Name < result.Name) {
+			result = country
+			bestDist = dist
+		}
+	}
+	return result
+}
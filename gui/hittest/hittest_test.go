@@ -0,0 +1,90 @@
+package hittest
+
+import (
+	"RISK-CodeConflict/core"
+	"testing"
+)
+
+func TestScreenPos(t *testing.T) {
+	c := &core.Country{Name: "A", Position: [2]int{889, 500}}
+
+	// full size, no viewport
+	if x, y := ScreenPos(c, [2]int{0, 0}, core.CountryPosScaleWidth, core.CountryPosScaleHeight); x != 889 || y != 500 {
+		t.Fatal(x, y)
+	}
+	// zoomed image with viewport
+	if x, y := ScreenPos(c, [2]int{100, 50}, 2*core.CountryPosScaleWidth, 2*core.CountryPosScaleHeight); x != 1678 || y != 950 {
+		t.Fatal(x, y)
+	}
+}
+
+func TestCountryAtScreenPos(t *testing.T) {
+	a := &core.Country{Name: "A", Position: [2]int{500, 500}}
+	list := []*core.Country{nil, a}
+	w, h := core.CountryPosScaleWidth, core.CountryPosScaleHeight
+
+	tests := []struct {
+		viewport [2]int
+		zoom     float64
+		imgScale int
+		x, y     int
+		want     *core.Country
+	}{
+		// zoom 1: box from 450 to 550
+		{zoom: 1, imgScale: 1, x: 500, y: 500, want: a},
+		{zoom: 1, imgScale: 1, x: 450, y: 450, want: a}, // top left corner
+		{zoom: 1, imgScale: 1, x: 550, y: 550, want: a}, // bottom right corner
+		{zoom: 1, imgScale: 1, x: 449, y: 500, want: nil},
+		{zoom: 1, imgScale: 1, x: 500, y: 551, want: nil},
+		// zoom 2: box from 900 to 1100 (image twice as large)
+		{zoom: 2, imgScale: 2, x: 900, y: 1100, want: a},
+		{zoom: 2, imgScale: 2, x: 899, y: 1000, want: nil},
+		{zoom: 2, imgScale: 2, x: 500, y: 500, want: nil},
+		// zoom 2 with viewport
+		{viewport: [2]int{400, 300}, zoom: 2, imgScale: 2, x: 600, y: 700, want: a},
+		{viewport: [2]int{400, 300}, zoom: 2, imgScale: 2, x: 1000, y: 1000, want: nil},
+		// zoom 0.5: box from 475 to 525
+		{zoom: 0.5, imgScale: 1, x: 525, y: 475, want: a},
+		{zoom: 0.5, imgScale: 1, x: 526, y: 500, want: nil},
+	}
+	for i, tt := range tests {
+		got := CountryAtScreenPos(list, tt.viewport, tt.zoom, w*tt.imgScale, h*tt.imgScale, tt.x, tt.y)
+		if got != tt.want {
+			t.Fatalf("%d: got %v, want %v", i, got, tt.want)
+		}
+	}
+
+	// empty list
+	if got := CountryAtScreenPos(nil, [2]int{}, 1, w, h, 500, 500); got != nil {
+		t.Fatal(got)
+	}
+}
+
+func TestCountryAtScreenPos_overlap(t *testing.T) {
+	a := &core.Country{Name: "A", Position: [2]int{500, 500}}
+	b := &core.Country{Name: "B", Position: [2]int{560, 500}}
+	w, h := core.CountryPosScaleWidth, core.CountryPosScaleHeight
+
+	// the boxes overlap from 510 to 550: the nearest center wins, independent of the order
+	for _, list := range [][]*core.Country{{a, b}, {b, a}} {
+		for _, tt := range []struct {
+			x    int
+			want *core.Country
+		}{
+			{x: 515, want: a},
+			{x: 529, want: a},
+			{x: 531, want: b},
+			{x: 545, want: b},
+			{x: 530, want: a}, // equal distance: smaller name
+		} {
+			if got := CountryAtScreenPos(list, [2]int{}, 1, w, h, tt.x, 500); got != tt.want {
+				t.Fatalf("x=%d: got %v, want %v", tt.x, got, tt.want)
+			}
+		}
+	}
+
+	// at high zoom the boxes overlap even more
+	if got := CountryAtScreenPos([]*core.Country{b, a}, [2]int{}, 4, 2*w, 2*h, 1050, 1000); got != a {
+		t.Fatal(got)
+	}
+}
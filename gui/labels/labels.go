@@ -0,0 +1,121 @@
+// Package labels contains the collision-aware placement of the labels the GUI draws on the map
+// (country names and army stats). It does not depend on ebiten, so it can be used and tested without a display.
+package labels
+
+import "math"
+
+// Box is the rectangle of a label, given by its center and its size.
+type Box struct {
+	X, Y float64 // The center of the label.
+	W, H float64 // The width and height of the label.
+}
+
+// Placement is the adjustment of a label computed by Layout.
+type Placement struct {
+	DX, DY float64 // The offset of the label from its original position.
+	Scale  float64 // The scale factor of the label (1.0 = original size).
+}
+
+// Options configure Layout.
+type Options struct {
+	Iterations int     // The number of passes that push overlapping labels apart (0 = labels are not moved).
+	MaxShift   float64 // The maximum offset of a label, relative to its height (e.g. 1.0 = one label height).
+	MinScale   float64 // The smallest scale factor for labels that still overlap after moving (1.0 = never scale).
+}
+
+// DefaultOptions moves labels by up to one label height and scales them down to 60%.
+var DefaultOptions = Options{Iterations: 50, MaxShift: 1.0, MinScale: 0.6}
+
+// Layout nudges overlapping labels apart and scales down the labels that still overlap,
+// so densely packed countries remain readable.
+//
+// Every pass pushes each pair of overlapping labels apart along the axis of the smaller overlap,
+// each label by half of the overlap. A label is never moved further than MaxShift label heights
+// from its original position, so it stays near its country. Labels that still overlap at the end
+// are scaled around their (moved) center just enough to separate them, but not below MinScale.
+// The result only depends on the order of the boxes, so the caller should sort them (e.g. by country name).
+//
+// Parameters:
+//   - boxes: The labels at their original positions.
+//   - opt: The options (see DefaultOptions).
+//
+// Returns:
+//   - One placement per box, in the same order.
+func Layout(boxes []Box, opt Options) []Placement {
+	placements := make([]Placement, len(boxes))
+	moved := make([]Box, len(boxes))
+	for i, b := range boxes {
+		placements[i].Scale = 1
+		moved[i] = b
+	}
+
+	// push overlapping labels apart
+	for pass := 0; pass < opt.Iterations; pass++ {
+		changed := false
+		for i := range moved {
+			for j := i + 1; j < len(moved); j++ {
+				ox, oy := overlap(moved[i], moved[j])
+				if ox <= 0 || oy <= 0 {
+					continue
+				}
+				changed = true
+				// labels at the same position are separated by moving the first one up or left
+				if ox < oy {
+					d := math.Copysign(ox/2, moved[j].X-moved[i].X)
+					shift(moved, boxes, i, -d, 0, opt.MaxShift)
+					shift(moved, boxes, j, d, 0, opt.MaxShift)
+				} else {
+					d := math.Copysign(oy/2, moved[j].Y-moved[i].Y)
+					shift(moved, boxes, i, 0, -d, opt.MaxShift)
+					shift(moved, boxes, j, 0, d, opt.MaxShift)
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	// scale down the labels that still overlap
+	for i := range moved {
+		placements[i].DX = moved[i].X - boxes[i].X
+		placements[i].DY = moved[i].Y - boxes[i].Y
+	}
+	for i := range moved {
+		for j := i + 1; j < len(moved); j++ {
+			if ox, oy := overlap(moved[i], moved[j]); ox <= 0 || oy <= 0 {
+				continue
+			}
+			s := math.Max(fit(moved[i].X, moved[j].X, moved[i].W, moved[j].W), fit(moved[i].Y, moved[j].Y, moved[i].H, moved[j].H))
+			s = math.Max(opt.MinScale, math.Min(1, s))
+			placements[i].Scale = math.Min(placements[i].Scale, s)
+			placements[j].Scale = math.Min(placements[j].Scale, s)
+		}
+	}
+	return placements
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// overlap returns the overlap of two boxes on both axes (not positive if they do not overlap on that axis).
+func overlap(a, b Box) (x, y float64) {
+	x = (a.W+b.W)/2 - math.Abs(a.X-b.X)
+	y = (a.H+b.H)/2 - math.Abs(a.Y-b.Y)
+	return
+}
+
+// shift moves the box i by (dx, dy), but not further than maxShift box heights from its original position.
+func shift(moved, boxes []Box, i int, dx, dy, maxShift float64) {
+	if dx == 0 && dy == 0 {
+		return
+	}
+	limit := maxShift * boxes[i].H
+	moved[i].X = boxes[i].X + math.Max(-limit, math.Min(limit, moved[i].X+dx-boxes[i].X))
+	moved[i].Y = boxes[i].Y + math.Max(-limit, math.Min(limit, moved[i].Y+dy-boxes[i].Y))
+}
+
+// fit returns the scale factor at which two labels with the centers a and b and the sizes sa and sb
+// just touch on one axis.
+func fit(a, b, sa, sb float64) float64 {
+	return math.Abs(a-b) / ((sa + sb) / 2)
+}
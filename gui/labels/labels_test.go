@@ -0,0 +1,91 @@
+package labels
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLayout(t *testing.T) {
+	tests := []struct {
+		name  string
+		boxes []Box
+		opt   Options
+		want  []Placement
+	}{
+		{
+			name:  "no overlap",
+			boxes: []Box{{X: 0, Y: 0, W: 10, H: 2}, {X: 20, Y: 0, W: 10, H: 2}},
+			opt:   DefaultOptions,
+			want:  []Placement{{Scale: 1}, {Scale: 1}},
+		},
+		{
+			name:  "vertical push",
+			boxes: []Box{{X: 0, Y: 0, W: 10, H: 2}, {X: 2, Y: 1, W: 10, H: 2}},
+			opt:   DefaultOptions,
+			want:  []Placement{{DY: -0.5, Scale: 1}, {DY: 0.5, Scale: 1}},
+		},
+		{
+			name:  "horizontal push",
+			boxes: []Box{{X: 0, Y: 0, W: 2, H: 10}, {X: 1, Y: 0, W: 2, H: 10}},
+			opt:   DefaultOptions,
+			want:  []Placement{{DX: -0.5, Scale: 1}, {DX: 0.5, Scale: 1}},
+		},
+		{
+			name:  "same position",
+			boxes: []Box{{X: 5, Y: 5, W: 10, H: 2}, {X: 5, Y: 5, W: 10, H: 2}},
+			opt:   DefaultOptions,
+			want:  []Placement{{DY: -1, Scale: 1}, {DY: 1, Scale: 1}},
+		},
+		{
+			name:  "max shift, then scale",
+			boxes: []Box{{X: 0, Y: 0, W: 10, H: 4}, {X: 0, Y: 1, W: 10, H: 4}},
+			opt:   Options{Iterations: 10, MaxShift: 0.25, MinScale: 0.1},
+			want:  []Placement{{DY: -1, Scale: 0.75}, {DY: 1, Scale: 0.75}},
+		},
+		{
+			name:  "min scale",
+			boxes: []Box{{X: 0, Y: 0, W: 10, H: 4}, {X: 0, Y: 1, W: 10, H: 4}},
+			opt:   Options{MinScale: 0.5},
+			want:  []Placement{{Scale: 0.5}, {Scale: 0.5}},
+		},
+		{
+			name:  "disabled",
+			boxes: []Box{{X: 0, Y: 0, W: 10, H: 4}, {X: 0, Y: 1, W: 10, H: 4}},
+			opt:   Options{MinScale: 1},
+			want:  []Placement{{Scale: 1}, {Scale: 1}},
+		},
+	}
+	for _, tt := range tests {
+		got := Layout(tt.boxes, tt.opt)
+		if len(got) != len(tt.want) {
+			t.Fatalf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+		for i := range got {
+			if math.Abs(got[i].DX-tt.want[i].DX) > 1e-9 || math.Abs(got[i].DY-tt.want[i].DY) > 1e-9 || math.Abs(got[i].Scale-tt.want[i].Scale) > 1e-9 {
+				t.Fatalf("%s: got %v, want %v", tt.name, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestLayout_dense(t *testing.T) {
+	// a cluster of labels: after the layout no two labels overlap (moved and scaled)
+	var boxes []Box
+	for i := 0; i < 5; i++ {
+		boxes = append(boxes, Box{X: float64(i % 2), Y: float64(i) * 0.5, W: 8, H: 2})
+	}
+	placements := Layout(boxes, Options{Iterations: 100, MaxShift: 3, MinScale: 0.01})
+	for i := range boxes {
+		for j := i + 1; j < len(boxes); j++ {
+			a, b := apply(boxes[i], placements[i]), apply(boxes[j], placements[j])
+			if ox, oy := overlap(a, b); ox > 1e-9 && oy > 1e-9 {
+				t.Fatalf("%d and %d overlap: %v %v", i, j, a, b)
+			}
+		}
+	}
+}
+
+// apply returns the box adjusted by the placement.
+func apply(b Box, p Placement) Box {
+	return Box{X: b.X + p.DX, Y: b.Y + p.DY, W: b.W * p.Scale, H: b.H * p.Scale}
+}
@@ -2,13 +2,12 @@ package gui
 
 import (
 	"RISK-CodeConflict/core"
+	"RISK-CodeConflict/gui/labels"
 	"RISK-CodeConflict/gui/resources"
-	"github.com/golang/freetype/truetype"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text"
-	"golang.org/x/image/font"
-	"golang.org/x/image/font/gofont/gomono"
 	"image/color"
+	"sort"
 )
 
 // preprocess generates a preprocessed image with the specified width and height,
@@ -16,18 +15,35 @@ import (
 // The countries parameter is a map where the key is not used and the value is a pointer to a core.Country object
 // (see core.World.Countries).
 //
+// The country names are placed with labels.Layout, so the names of densely packed countries do not overlap.
+//
 // The native size is 2475x1392, which has an aspect ratio of 16:9 (see preprocessBg).
-func preprocess(width, height float64, countries map[string]*core.Country) *ebiten.Image {
+func preprocess(width, height float64, countries map[string]*core.Country, labelOptions labels.Options) *ebiten.Image {
 
 	// Generate a background image with continents
 	img := preprocessBg(int(width), int(height))
 
+	// Place the country names (sorted, so the layout is stable)
+	const txtSizeRelToBg = 0.007 // Set text size here
+	const heightOffset = 3.2
+	names := make([]string, 0, len(countries))
+	for name := range countries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	nameBoxes := make([]labels.Box, len(names))
+	for i, name := range names {
+		posX, posY := preprocessPos(countries[name], width, height)
+		nameBoxes[i] = textBox(name, posX, posY, heightOffset, txtSizeRelToBg, width)
+	}
+	namePlacements := labels.Layout(nameBoxes, labelOptions)
+
 	// Iterate over all countries in the map and draw their objects
-	for _, country := range countries {
+	for i, name := range names {
+		country := countries[name]
 
 		// Calculate the correct scaled position of the country on the background
-		posX := float64(country.Position[0]) * width / core.CountryPosScaleWidth
-		posY := float64(country.Position[1]) * height / core.CountryPosScaleHeight
+		posX, posY := preprocessPos(country, width, height)
 
 		// Draw the fortress if the country has a fortress region
 		if country.FortressRegion {
@@ -50,10 +66,10 @@ func preprocess(width, height float64, countries map[string]*core.Country) *ebit
 			txtClr = color.RGBA{R: 255, G: 0, B: 0, A: 255} // red color for fortress regions
 		}
 
-		// Draw the country name text at the calculated position
+		// Draw the country name text at the calculated position (moved and scaled by the label layout)
 		// The text size is scaled relative to the background size
-		const heightOffset = 3.2
-		preprocessText(img, country.Name, posX, posY, heightOffset, 0.007, txtClr) // Set text size here
+		p := namePlacements[i]
+		preprocessText(img, country.Name, posX+p.DX, posY+p.DY, heightOffset/p.Scale, txtSizeRelToBg*p.Scale, txtClr)
 	}
 
 	// Return the preprocessed image
@@ -142,6 +158,19 @@ func preprocessObject(bgImg, objImg *ebiten.Image, posX, posY, objSizeRelToBg fl
 	bgImg.DrawImage(objImg, op)
 }
 
+// preprocessPos returns the position of the country on a background image of the given size.
+func preprocessPos(country *core.Country, width, height float64) (posX, posY float64) {
+	posX = float64(country.Position[0]) * width / core.CountryPosScaleWidth
+	posY = float64(country.Position[1]) * height / core.CountryPosScaleHeight
+	return
+}
+
+// textBox returns the rectangle covered by a text drawn with preprocessText (see labels.Layout).
+func textBox(txt string, posX, posY, relOffY, txtSizeRelToBg, bgWidth float64) labels.Box {
+	txtSize := (bgWidth * txtSizeRelToBg) / 0.9
+	return labels.Box{X: posX, Y: posY + relOffY*txtSize, W: labelWidth(txt, txtSize), H: txtSize}
+}
+
 // preprocessText draws text onto a background image at a specified position with a specified size and color.
 // The text size is relative to the width of the background image, and a 3D effect is applied by drawing a shadow.
 func preprocessText(bgImg *ebiten.Image, txt string, posX, posY, relOffY, txtSizeRelToBg float64, clr color.Color) {
@@ -152,17 +181,12 @@ func preprocessText(bgImg *ebiten.Image, txt string, posX, posY, relOffY, txtSiz
 	// Calculate the text size based on the background image width and the given relative size
 	txtSize := (float64(bgWidth) * txtSizeRelToBg) / 0.9
 
-	// Parse the TrueType font from the provided font data
-	// Create a new font face with the specified size and full hinting for better readability
-	ttFont, _ := truetype.Parse(gomono.TTF)
-	fontFace := truetype.NewFace(ttFont, &truetype.Options{
-		Size:    txtSize,
-		Hinting: font.HintingFull,
-	})
+	// Get the cached font face with the specified size (see SetLabelFont)
+	fontFace := labelFace(txtSize)
 
 	// Adjust the position to center the text horizontally and vertically relative to the given position
-	posX -= float64(len(txt)) * txtSize * 0.31 // Adjust horizontally
-	posY += txtSize * 0.35                     // Adjust vertically
+	posX -= labelWidth(txt, txtSize) / 2 // Adjust horizontally
+	posY += txtSize * 0.35               // Adjust vertically
 
 	// Add vertically offset
 	posY += relOffY * txtSize
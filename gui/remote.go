@@ -0,0 +1,92 @@
+package gui
+
+import (
+	"RISK-CodeConflict/core"
+	"RISK-CodeConflict/remote"
+	"time"
+)
+
+// RunRemoteGUI runs the GUI as live client of a remote game (see RunGUI). Every refresh interval,
+// the world is requested from the server (STATUS command) and the screen is redrawn if it has changed,
+// so the moves of the other players are shown during their turns. Attacks, moves and the end of the turn
+// are sent to the server with the client, so the GUI can only command the player of the client.
+//
+// This function is blocking!
+//
+// Parameters:
+//   - client: The client connected to the game. Without a player (see remote.GameClient.AddPlayer), the GUI is a spectator.
+//   - player: The name of the player of the client, "" for a spectator (marked in the player queue).
+//   - refresh: The interval of the status requests (at least 100ms).
+//
+// Returns:
+//   - An error if the first status request or the game loop fails.
+func RunRemoteGUI(screenWidth, screenHeight int, title string, client remote.GameClient, player string, refresh time.Duration, autoRedraw, labelLayout bool) error {
+	world := core.NewWorld()
+	if err := client.Status(world); err != nil {
+		return err
+	}
+
+	gui := newGUI(screenWidth, screenHeight, world, autoRedraw, labelLayout)
+	gui.client = client
+	if player != "" {
+		gui.localPlayers = []string{player}
+	}
+	gui.updates = make(chan *core.World, 1)
+	go pollStatus(client, max(refresh, 100*time.Millisecond), world.Json(), gui.updates)
+	return runGame(gui, title)
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// pollStatus requests the world from the server in an endless loop and sends every changed world to the channel.
+// If the GUI has not yet taken the last world, it is replaced by the newer one.
+func pollStatus(client remote.GameClient, refresh time.Duration, last string, updates chan *core.World) {
+	for range time.Tick(refresh) {
+		world := core.NewWorld()
+		if err := client.Status(world); err != nil {
+			world.Logger().Warn("status", "err", err)
+			continue
+		}
+		if js := world.Json(); js != last {
+			last = js
+			select {
+			case <-updates: // drop the stale world
+			default:
+			}
+			updates <- world
+		}
+	}
+}
+
+// updateRemote takes over the latest world of a remote game (see pollStatus). The selection is kept by name.
+func (g *GUI) updateRemote() {
+	select {
+	case world := <-g.updates:
+		if g.selectCountry != nil {
+			g.selectCountry = world.Countries[g.selectCountry.Name]
+		}
+		if g.targetCountry != nil {
+			g.targetCountry = world.Countries[g.targetCountry.Name]
+		}
+		g.world = world
+		g.redraw = true
+	default:
+	}
+}
+
+// attackOrMove sends an order of the player to the world, or to the server of a remote game (see core.World.AttackOrMove).
+func (g *GUI) attackOrMove(attacker, defender string, strength int, player string) error {
+	if g.client != nil {
+		return g.client.AttackOrMove(attacker, defender, strength)
+	}
+	return g.world.AttackOrMove(attacker, defender, strength, player)
+}
+
+// endTurn ends the turn of the player in the world, or on the server of a remote game (see core.World.EndTurn).
+func (g *GUI) endTurn(player string) error {
+	if g.client != nil {
+		return g.client.EndTurn()
+	}
+	_, err := g.world.EndTurn(player)
+	return err
+}
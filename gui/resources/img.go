@@ -4,9 +4,11 @@ import (
 	"embed"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"image/color"
 	_ "image/jpeg" // needed for ebitenutil.NewImageFromReader()
 	_ "image/png"  // needed for ebitenutil.NewImageFromReader()
-	"log"
+	"io/fs"
+	"log/slog"
 )
 
 // Imgs is the global variable that holds all image resources
@@ -22,31 +24,59 @@ type ImgResources struct {
 	Field       *ebiten.Image // 773 x 773
 }
 
+// PlaceholderColor is the fill color of the images that replace resources which cannot be loaded (see LoadImg).
+var PlaceholderColor = color.RGBA{R: 255, B: 255, A: 255}
+
 func init() {
 	Imgs = &ImgResources{
-		Icon:        loadGameImg("img/icon.png"),
-		BgOcean:     loadGameImg("img/bg_ocean.jpg"),
-		BgContinent: loadGameImg("img/bg_continent.png"),
-		Fortress:    loadGameImg("img/fortress.png"),
-		Village:     loadGameImg("img/village.png"),
-		Field:       loadGameImg("img/field.png"),
+		Icon:        LoadImg(gFS, "img/icon.png", 288, 288),
+		BgOcean:     LoadImg(gFS, "img/bg_ocean.jpg", 2475, 1532),
+		BgContinent: LoadImg(gFS, "img/bg_continent.png", 2475, 1392),
+		Fortress:    LoadImg(gFS, "img/fortress.png", 1024, 1024),
+		Village:     LoadImg(gFS, "img/village.png", 500, 500),
+		Field:       LoadImg(gFS, "img/field.png", 773, 773),
 	}
 }
 
 //go:embed img
 var gFS embed.FS
 
-func loadGameImg(name string) *ebiten.Image {
+// LoadImg loads an image from a file system, e.g. the embedded resources or a directory with custom artwork.
+// A missing or broken image does not stop the program: a warning is logged and a solid placeholder
+// of the expected size is returned instead (see PlaceholderColor), so the gui keeps running.
+//
+// Parameters:
+//   - fsys: The file system with the image.
+//   - name: The path of the PNG or JPEG image.
+//   - width: The width of the placeholder.
+//   - height: The height of the placeholder.
+func LoadImg(fsys fs.FS, name string, width, height int) *ebiten.Image {
+	eim, err := DecodeImg(fsys, name)
+	if err != nil {
+		slog.Warn("image replaced by a placeholder", "name", name, "err", err)
+		eim = ebiten.NewImage(width, height)
+		eim.Fill(PlaceholderColor)
+	}
+	return eim
+}
+
+// DecodeImg loads an image from a file system (see LoadImg).
+//
+// Returns:
+//   - The image.
+//   - An error if the file cannot be opened or decoded.
+func DecodeImg(fsys fs.FS, name string) (*ebiten.Image, error) {
 	// open reader
-	r, err := gFS.Open(name)
+	r, err := fsys.Open(name)
 	if err != nil {
-		log.Fatalf("err: loadGameImg: %v\n", err)
+		return nil, err
 	}
+	defer func() { _ = r.Close() }()
+
 	// get image
 	eim, _, err := ebitenutil.NewImageFromReader(r)
 	if err != nil {
-		log.Fatalf("err: loadGameImg: %v\n", err)
+		return nil, err
 	}
-	// return
-	return eim
+	return eim, nil
 }
@@ -2,6 +2,7 @@ package gui
 
 import (
 	"RISK-CodeConflict/core"
+	"RISK-CodeConflict/gui/hittest"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
@@ -16,7 +17,8 @@ import (
 // - Iterates through all countries to determine if the cursor position falls within the bounds of any country.
 // - Computes the position and dimensions of each country on the screen, considering the current zoom level and viewport offset.
 // - If a country is clicked (i.e., the mouse cursor is within the country's visual bounds), it sets this country as the currently selected one.
-// - Logs the name of the selected country or an "unselect" message if no country is selected.
+// - If the bounds of several countries contain the cursor, the country with the nearest center is selected.
+// - Logs the name of the selected country or an "unselect" message if no country is selected (debug level).
 // - Updates the `selectCountry` field with the newly selected country and triggers a screen redraw if the selection has changed.
 func (g *GUI) updateActiveCountry() {
 	// check input
@@ -34,42 +36,19 @@ func (g *GUI) updateActiveCountry() {
 	x, y := ebiten.CursorPosition()
 
 	// find country
-	var result *core.Country
+	list := make([]*core.Country, 0, len(g.world.Countries))
 	for _, country := range g.world.Countries {
-
-		// basic image size
-		var bgImgWidth int
-		var bgImgHeight int
-		if g.preprocessedImg != nil {
-			bgImgWidth = g.preprocessedImg.Bounds().Dx()
-			bgImgHeight = g.preprocessedImg.Bounds().Dy()
-		}
-
-		// Calculate the correct scaled position of the country on the screen
-		countryPosX := country.Position[0]*bgImgWidth/core.CountryPosScaleWidth - g.viewport[0]
-		countryPosY := country.Position[1]*bgImgHeight/core.CountryPosScaleHeight - g.viewport[1]
-
-		// object dimension
-		var dim = int(100 * g.zoom)
-		x1 := countryPosX - dim/2
-		y1 := countryPosY - dim/2
-		x2 := x1 + dim
-		y2 := y1 + dim
-
-		// check position
-		if x >= x1 && x <= x2 && y >= y1 && y <= y2 {
-			result = country
-			break
-		}
+		list = append(list, country)
 	}
+	result := g.countryAt(x, y, list)
 
 	// select country
 	if g.selectCountry != result {
 		// print action
 		if result != nil {
-			println("select", result.Name)
+			g.world.Logger().Debug("select", "country", result.Name)
 		} else {
-			println("unselect")
+			g.world.Logger().Debug("unselect")
 		}
 		// set new country
 		g.selectCountry = result
@@ -80,6 +59,7 @@ func (g *GUI) updateActiveCountry() {
 
 // updateAttackCountry handles the logic for selecting a country to attack based on the user's right mouse click.
 // It determines if the user clicks on a neighboring country of the currently selected country and triggers an attack.
+// The number of committed units is GUI.targetStrength, which is adjusted with the mouse wheel (see updateTargeting).
 func (g *GUI) updateAttackCountry() {
 	// check input
 	if g.world == nil || g.world.Countries == nil {
@@ -101,37 +81,7 @@ func (g *GUI) updateAttackCountry() {
 	x, y := ebiten.CursorPosition()
 
 	// find neighbor countries
-	var result *core.Country
-	var list []*core.Country
-	list = append(list, selectCountry.NeighborsObj()...)
-	list = append(list, selectCountry)
-	for _, country := range list {
-
-		// basic image size
-		var bgImgWidth int
-		var bgImgHeight int
-		if g.preprocessedImg != nil {
-			bgImgWidth = g.preprocessedImg.Bounds().Dx()
-			bgImgHeight = g.preprocessedImg.Bounds().Dy()
-		}
-
-		// Calculate the correct scaled position of the country on the screen
-		countryPosX := country.Position[0]*bgImgWidth/core.CountryPosScaleWidth - g.viewport[0]
-		countryPosY := country.Position[1]*bgImgHeight/core.CountryPosScaleHeight - g.viewport[1]
-
-		// object dimension
-		var dim = int(100 * g.zoom)
-		x1 := countryPosX - dim/2
-		y1 := countryPosY - dim/2
-		x2 := x1 + dim
-		y2 := y1 + dim
-
-		// check position
-		if x >= x1 && x <= x2 && y >= y1 && y <= y2 {
-			result = country
-			break
-		}
-	}
+	result := g.countryAt(x, y, g.targetList())
 
 	// attack country
 	if result != nil {
@@ -141,13 +91,13 @@ func (g *GUI) updateAttackCountry() {
 			activePlayer = g.world.PlayerQueue[0].Name
 		}
 
-		// ATTACK
-		strength := 1
-		if ebiten.IsKeyPressed(ebiten.KeyControl) {
-			strength = 5
+		// ATTACK (the strength is set via mouse wheel, see updateTargeting)
+		strength := g.targetStrength
+		if strength < 1 {
+			strength = 1
 		}
-		if err := g.world.AttackOrMove(selectCountry.Name, result.Name, strength, activePlayer); err != nil {
-			println("ERROR:", err.Error())
+		if err := g.attackOrMove(selectCountry.Name, result.Name, strength, activePlayer); err != nil {
+			g.world.Logger().Warn("attack or move", "err", err)
 		}
 
 		// update screen
@@ -168,17 +118,153 @@ func (g *GUI) updateTurn() {
 		return // Skip the turn update if Enter key is not pressed.
 	}
 
+	// Without opponents, there is no turn to end (see drawControls).
+	if g.waitingForOpponents() {
+		return
+	}
+
 	// Retrieve the active player from the queue.
 	activePlayer := ""
 	if len(g.world.PlayerQueue) > 0 {
 		activePlayer = g.world.PlayerQueue[0].Name // Get the first player in the queue.
 	}
 
+	// Unspent reinforcement would be lost (see core.World.DiscardReinforcement), so the first Enter only warns
+	// and the second one ends the turn (see drawControls).
+	if g.world.DiscardReinforcement && len(g.world.PlayerQueue) > 0 && g.world.PlayerQueue[0].Reinforcement > 0 && !g.confirmEndTurn {
+		g.confirmEndTurn = true
+		g.redraw = true
+		return
+	}
+	g.confirmEndTurn = false
+
 	// Process the end of the turn for the active player.
-	if err := g.world.EndTurn(activePlayer); err != nil {
-		println("ERROR:", err.Error()) // Print error message if ending the turn fails.
+	if err := g.endTurn(activePlayer); err != nil {
+		g.world.Logger().Warn("end turn", "err", err) // Log error message if ending the turn fails.
 	}
 
 	// Mark the screen for a redraw.
 	g.redraw = true
 }
+
+// updateOpponents adds an AI opponent with the I key while the world is waiting for opponents.
+func (g *GUI) updateOpponents() {
+	if g.addOpponent == nil || !g.waitingForOpponents() {
+		return
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyI) {
+		g.addOpponent()
+		g.redraw = true
+	}
+}
+
+// updateLegend toggles the legend of the map symbols with the L key (see drawLegend).
+func (g *GUI) updateLegend() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyL) {
+		g.showLegend = !g.showLegend
+		g.redraw = true
+	}
+}
+
+// updateTargeting checks whether the mouse hovers a valid target (a neighbor of the selected country or the
+// selected country itself). While hovering a target, the GUI is in targeting mode: the mouse wheel adjusts the
+// number of units a right-click will commit instead of zooming (see updateZoomAndViewport).
+// The strength is clamped to the available units (strength minus one man staying behind, or the reinforcement
+// pool if the target is the selected country itself). Holding Ctrl changes the strength in steps of 5.
+func (g *GUI) updateTargeting() {
+	// check input
+	if g.world == nil || g.world.Countries == nil || g.selectCountry == nil {
+		g.setTarget(nil)
+		return // skip
+	}
+
+	// find hovered target
+	x, y := ebiten.CursorPosition()
+	target := g.countryAt(x, y, g.targetList())
+	g.setTarget(target)
+	if target == nil {
+		return
+	}
+
+	// redraw the feedback near the cursor
+	if x != g.lastCursorX || y != g.lastCursorY {
+		g.lastCursorX, g.lastCursorY = x, y
+		g.redraw = true
+	}
+
+	// adjust strength via mouse wheel
+	if _, dy := ebiten.Wheel(); dy != 0 {
+		step := 1
+		if ebiten.IsKeyPressed(ebiten.KeyControl) {
+			step = 5
+		}
+		if dy > 0 {
+			g.targetStrength += step
+		} else {
+			g.targetStrength -= step
+		}
+		g.redraw = true
+	}
+
+	// clamp to available strength/reinforcement
+	maxStrength := 0
+	if target == g.selectCountry {
+		if len(g.world.PlayerQueue) > 0 {
+			maxStrength = g.world.PlayerQueue[0].Reinforcement
+		}
+	} else if g.selectCountry.Occupier != nil {
+		maxStrength = g.selectCountry.Occupier.Strength - g.selectCountry.Garrison() // the garrison must stay behind
+	}
+	if g.targetStrength > maxStrength {
+		g.targetStrength = maxStrength
+	}
+	if g.targetStrength < 1 {
+		g.targetStrength = 1
+	}
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// waitingForOpponents reports whether the game cannot be played because the world has less than two players.
+// EndTurn would only answer "no other player found" in this state.
+func (g *GUI) waitingForOpponents() bool {
+	return g.world != nil && g.world.Phase != core.PhaseFinished && len(g.world.PlayerQueue) < 2
+}
+
+// setTarget sets the hovered target country and switches the targeting mode on or off.
+// If the target changes, the strength is reset to 1 and the screen is marked for redraw.
+func (g *GUI) setTarget(target *core.Country) {
+	if g.targetCountry != target {
+		g.targetCountry = target
+		g.targetStrength = 1
+		g.redraw = true
+	}
+	g.targeting = target != nil
+}
+
+// targetList returns all valid targets of the selected country: its neighbors and the country itself.
+func (g *GUI) targetList() []*core.Country {
+	if g.selectCountry == nil {
+		return nil
+	}
+	list := make([]*core.Country, 0, len(g.selectCountry.Neighbors)+1)
+	list = append(list, g.selectCountry.NeighborsObj()...)
+	list = append(list, g.selectCountry)
+	return list
+}
+
+// countryAt returns the country of the list whose click box contains the screen position (x, y).
+// The click box is a square of 100*zoom pixels around the scaled country position (see hittest.CountryAtScreenPos).
+// If the boxes of several countries contain the position, the nearest country wins.
+// If no country is found, nil is returned.
+func (g *GUI) countryAt(x, y int, list []*core.Country) *core.Country {
+	// basic image size
+	var bgImgWidth int
+	var bgImgHeight int
+	if g.preprocessedImg != nil {
+		bgImgWidth = g.preprocessedImg.Bounds().Dx()
+		bgImgHeight = g.preprocessedImg.Bounds().Dy()
+	}
+
+	return hittest.CountryAtScreenPos(list, g.viewport, g.zoom, bgImgWidth, bgImgHeight, x, y)
+}
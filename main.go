@@ -8,6 +8,7 @@ import (
 	"flag"
 	"fmt"
 	"image/color"
+	"log/slog"
 	"os"
 	"time"
 )
@@ -26,85 +27,304 @@ func main() {
 	var aiPlayer int
 	var remotePlayer int
 	var humanPlayer int
+	var requireReady bool
+	var aiThink time.Duration
+	var aiPoll time.Duration
 	var noLog bool
+	var logLevel string
+	var logJSON bool
 	var autoRedraw bool
+	var labelLayout bool
+	var labelFont string
+	var connect bool
+	var playerName string
+	var refresh time.Duration
+	var maxConn int
+	var maxLineLength int
+	var recruitBonus int
+	var minReinforcement int
+	var continentBonusScale float64
+	var maxCountryStrength int
+	var minGarrison, fortressGarrison int
+	var continentPools bool
+	var firstConquestBonus int
+	var eliminationReinforcement bool
+	var setupRerolls int
+	var victory string
+	var departure string
+	var capitalRule string
+	var capitalBonus int
+	var neutralGarrison int
+	var fogOfWar bool
+	var fogRadius int
+	var turnOrder string
+	var scoutRounds int
+	var stepwise bool
+	var peacefulRounds int
+	var discardReinforcement bool
+	var mapSeed int64
+	var gameSeed int64
+	var mapCountries int
+	var mapContinents int
+	var victoryThreshold int
+	var timeBank time.Duration
+	var timeIncrement time.Duration
+	var adminToken string
+	var auditSize int
+	var battleLog int
+	var auditFile string
+	var statsFile string
+	var thumbWidth int
+	var thumbHeight int
+	var thumbRefresh time.Duration
 
 	// parse
 	flag.StringVar(&host, "host", "localhost", "Server host")
 	flag.StringVar(&port, "port", "1234", "Server port")
 	flag.IntVar(&aiPlayer, "ai", 0, "add RandomAI players")
 	flag.IntVar(&remotePlayer, "remote", 0, "waiting for remote client-AI players")
+	flag.DurationVar(&aiThink, "aiThink", ai.DefaultTiming.Think, "pause of the RandomAI players before ending their turn (0 = full speed)")
+	flag.DurationVar(&aiPoll, "aiPoll", ai.DefaultTiming.Poll, "interval at which the RandomAI players check whether it is their turn (at least 10ms)")
+	flag.StringVar(&turnOrder, "turnOrder", "", "turn order: join keeps the join order (default: shuffled once when the game starts)")
+	flag.BoolVar(&requireReady, "ready", false, "the game starts when all players have sent READY (or the admin sends START) instead of when it is full")
 	flag.IntVar(&humanPlayer, "human", 0, "add human players (control via the server gui)")
 	flag.BoolVar(&noLog, "noLog", false, "disables combat output in the server log")
+	flag.StringVar(&logLevel, "logLevel", "debug", "server log level (debug, info, warn, error); combat is logged at debug")
+	flag.BoolVar(&logJSON, "logJSON", false, "writes the server log as JSON")
 	flag.BoolVar(&autoRedraw, "autoRedraw", false, "forces the gui to redraw every frame")
+	flag.BoolVar(&labelLayout, "labelLayout", true, "moves and scales overlapping country names and stats in the gui")
+	flag.BoolVar(&connect, "connect", false, "runs the gui as client of the game server at -host and -port instead of starting a game")
+	flag.StringVar(&playerName, "name", "", "joins the game of -connect as player with this name (default: spectator)")
+	flag.DurationVar(&refresh, "refresh", 500*time.Millisecond, "interval at which the gui of -connect requests the world from the server")
+	flag.StringVar(&labelFont, "font", "", "TrueType font file for the country names and stats in the gui, e.g. for non-ASCII names (default: Go Mono)")
+	flag.IntVar(&maxConn, "maxConn", remote.DefaultMaxConnections, "maximum number of simultaneous connections (0 = unlimited)")
+	flag.IntVar(&maxLineLength, "maxLineLength", remote.DefaultMaxLineLength, "maximum length of a received command line in bytes (0 = unlimited)")
+	flag.IntVar(&recruitBonus, "recruitBonus", 0, "percent of extra units when recruiting in a fully controlled continent (0 = off)")
+	flag.IntVar(&minReinforcement, "minReinforcement", 0, "minimum reinforcements per round for each living player")
+	flag.Float64Var(&continentBonusScale, "continentBonusScale", 1, "scales the bonus points of all continents, e.g. 0.5 or 2 (must not be negative)")
+	flag.IntVar(&maxCountryStrength, "maxCountryStrength", 0, "maximum number of units in a single country (0 = unlimited)")
+	flag.IntVar(&minGarrison, "minGarrison", 1, "number of units that must stay behind when a country attacks or moves")
+	flag.IntVar(&fortressGarrison, "fortressGarrison", 0, "number of units that must stay behind in fortress regions (0 = minGarrison)")
+	flag.Int64Var(&mapSeed, "mapSeed", 1, "seed of the generated map (see -mapCountries)")
+	flag.Int64Var(&gameSeed, "gameSeed", 0, "seed of the dice, the setup and the turn order for a reproducible game (0 = random)")
+	flag.IntVar(&mapCountries, "mapCountries", 0, "plays on a generated map with this number of countries instead of the classic map (0 = classic)")
+	flag.IntVar(&mapContinents, "mapContinents", 6, "number of continents of the generated map")
+	flag.StringVar(&victory, "victory", "", "victory condition: domination, territory or capital (default: last player standing)")
+	flag.StringVar(&departure, "departure", "", "countries of a kicked player: neutral, transfer or remove (default: players cannot be kicked from a running game)")
+	flag.StringVar(&capitalRule, "capitalRule", "", "capture of a capital: eliminate, transfer or bonus (default: no capitals)")
+	flag.IntVar(&capitalBonus, "capitalBonus", 5, "reinforcement units for capturing a capital (capitalRule bonus)")
+	flag.IntVar(&neutralGarrison, "neutralGarrison", 3, "number of units defending a neutral country (0 = keep the units of the departed player)")
+	flag.BoolVar(&fogOfWar, "fog", false, "fog of war: players only see the strength of their own countries (see SCOUT)")
+	flag.IntVar(&fogRadius, "fogRadius", 0, "fog of war: players also see the countries within this number of hops of their own countries")
+	flag.IntVar(&scoutRounds, "scoutRounds", 2, "number of rounds a country revealed with SCOUT stays visible (fog of war)")
+	flag.BoolVar(&stepwise, "stepwise", false, "attacks can be limited to a number of dice rounds (see ATTACK)")
+	flag.IntVar(&peacefulRounds, "peacefulRounds", 0, "number of rounds at the start in which no attacks are allowed (0 = off)")
+	flag.BoolVar(&discardReinforcement, "discardReinforcement", false, "unspent reinforcement is lost at the end of the turn")
+	flag.IntVar(&victoryThreshold, "victoryThreshold", 70, "percent of all countries needed for the territory victory")
+	flag.IntVar(&firstConquestBonus, "firstConquestBonus", 0, "one-time reinforcement for the first conquest in each continent (0 = off)")
+	flag.BoolVar(&eliminationReinforcement, "eliminationReinforcement", false, "survivors get the growth of their income as soon as a player is eliminated")
+	flag.BoolVar(&continentPools, "continentPools", false, "reinforcements are earned and deployed per continent")
+	flag.IntVar(&setupRerolls, "setupRerolls", 0, "rolls additional starting layouts and keeps the most balanced one")
+	flag.DurationVar(&timeBank, "timeBank", 0, "match clock: thinking time of each player, e.g. 5m (0 = off, needs remote players)")
+	flag.DurationVar(&timeIncrement, "timeIncrement", 0, "match clock: time added to the time bank after each turn")
+	flag.StringVar(&adminToken, "adminToken", "", "enables the admin commands (PAUSE, RESUME) for clients sending ADMIN|{token}")
+	flag.IntVar(&auditSize, "auditSize", remote.DefaultAuditSize, "number of received commands kept for the AUDIT admin command")
+	flag.IntVar(&battleLog, "battleLog", remote.DefaultBattleLogSize, "number of battles kept with their dice for the LASTBATTLES command (0 = off)")
+	flag.StringVar(&statsFile, "stats", "", "keeps the statistics of all players across games in this JSON file (see STATS)")
+	flag.IntVar(&thumbWidth, "thumbWidth", remote.DefaultThumbnailWidth, "width of the map preview sent by THUMBNAIL in pixels (0 = disabled)")
+	flag.IntVar(&thumbHeight, "thumbHeight", remote.DefaultThumbnailHeight, "height of the map preview sent by THUMBNAIL in pixels")
+	flag.DurationVar(&thumbRefresh, "thumbRefresh", remote.DefaultThumbnailRefresh, "minimum time between two renderings of the map preview")
+	flag.StringVar(&auditFile, "auditFile", "", "appends every received command to this file as a JSON line")
 	flag.Parse()
 
+	// gui font
+	if labelFont != "" {
+		ttf, err := os.ReadFile(labelFont)
+		if err == nil {
+			err = gui.SetLabelFont(ttf)
+		}
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// gui as client of a remote game (blocking)
+	if connect {
+		client, err := remote.NewClient(host, port)
+		if err != nil {
+			panic(err)
+		}
+		if playerName != "" {
+			if err := client.AddPlayer(playerName, color.RGBA{}); err != nil {
+				panic(err)
+			}
+		}
+		if err := gui.RunRemoteGUI(1778, 1000, programName, client, playerName, refresh, autoRedraw, labelLayout); err != nil {
+			panic(err)
+		}
+		return
+	}
+
 	// player, host and port
 	if aiPlayer+remotePlayer+humanPlayer < 1 || host == "" || port == "" {
 		flag.Usage()
 		os.Exit(6)
 	}
 
+	// victory condition
+	switch core.VictoryMode(victory) {
+	case core.VictoryLastStanding, core.VictoryDomination, core.VictoryTerritory, core.VictoryCapital:
+	default:
+		flag.Usage()
+		os.Exit(6)
+	}
+
+	// departure policy
+	switch core.DeparturePolicy(departure) {
+	case core.DepartureRefuse, core.DepartureNeutral, core.DepartureTransfer, core.DepartureRemove:
+	default:
+		flag.Usage()
+		os.Exit(6)
+	}
+
+	// capital rule
+	switch core.CapitalRule(capitalRule) {
+	case core.CapitalOff, core.CapitalEliminate, core.CapitalTransfer, core.CapitalBonus:
+	default:
+		flag.Usage()
+		os.Exit(6)
+	}
+
+	// turn order
+	switch core.TurnOrder(turnOrder) {
+	case core.TurnOrderShuffle, core.TurnOrderJoin:
+	default:
+		flag.Usage()
+		os.Exit(6)
+	}
+
+	// continent bonus scale
+	if continentBonusScale < 0 {
+		flag.Usage()
+		os.Exit(6)
+	}
+
 	//---------------------------------------------------------------------------------------------------
 
-	// run program
-	colorIndex := 0
-	playerColors := []color.RGBA{
-		{R: 255, G: 0, B: 0, A: 255},     // Red
-		{R: 0, G: 255, B: 0, A: 255},     // Green
-		{R: 0, G: 0, B: 255, A: 255},     // Blue
-		{R: 255, G: 255, B: 0, A: 255},   // Yellow
-		{R: 255, G: 0, B: 255, A: 255},   // Magenta
-		{R: 254, G: 255, B: 255, A: 255}, // White
-		{R: 1, G: 0, B: 0, A: 255},       // dummy
-		{R: 2, G: 0, B: 0, A: 255},       // dummy
-		{R: 3, G: 0, B: 0, A: 255},       // dummy
-		{R: 4, G: 0, B: 0, A: 255},       // dummy
-		{R: 5, G: 0, B: 0, A: 255},       // dummy
-		{R: 6, G: 0, B: 0, A: 255},       // dummy
-		{R: 7, G: 0, B: 0, A: 255},       // dummy
-		{R: 8, G: 0, B: 0, A: 255},       // dummy
-		{R: 9, G: 0, B: 0, A: 255},       // dummy
-		{R: 10, G: 0, B: 0, A: 255},      // dummy
-		{R: 11, G: 0, B: 0, A: 255},      // dummy
-	}
-
-	//-----------------------------------------------
+	// logger
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(logLevel)); err != nil {
+		flag.Usage()
+		os.Exit(6)
+	}
+	var handler slog.Handler = slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: level})
+	if logJSON {
+		handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level})
+	}
 
 	// new world
 	w := core.NewWorld()
+	if mapCountries > 0 {
+		var err error
+		if w, err = core.GenerateRandomWorld(mapSeed, mapCountries, mapContinents); err != nil {
+			println(err.Error())
+			os.Exit(6)
+		}
+	}
+	if gameSeed != 0 {
+		w.SetSeed(gameSeed)
+	}
 	w.NoLog = noLog
+	w.ContinentRecruitBonus = recruitBonus
+	w.MinReinforcementPerTurn = minReinforcement
+	w.ContinentBonusScale = continentBonusScale
+	w.MaxCountryStrength = maxCountryStrength
+	w.MinGarrison = minGarrison
+	for _, c := range w.Countries {
+		if c.FortressRegion {
+			c.MinGarrison = fortressGarrison
+		}
+	}
+	w.DeparturePolicy = core.DeparturePolicy(departure)
+	w.NeutralGarrison = neutralGarrison
+	w.CapitalRule = core.CapitalRule(capitalRule)
+	w.CapitalBonus = capitalBonus
+	w.FogOfWar = fogOfWar
+	w.FogRadius = fogRadius
+	w.ScoutRounds = scoutRounds
+	w.StepwiseAttacks = stepwise
+	w.PeacefulRounds = peacefulRounds
+	w.DiscardReinforcement = discardReinforcement
+	w.RequireReady = requireReady
+	w.TurnOrder = core.TurnOrder(turnOrder)
+	w.ContinentReinforcementPools = continentPools
+	w.FirstConquestBonus = firstConquestBonus
+	w.EliminationReinforcement = eliminationReinforcement
+	w.SetupRerolls = setupRerolls
+	w.VictoryCondition = core.VictoryCondition{Mode: core.VictoryMode(victory), Threshold: victoryThreshold}
+	w.SetLogger(slog.New(handler))
 
 	// add human player
+	var humans []string
 	for i := 0; i < humanPlayer; i++ {
 		name := fmt.Sprintf("Human %d", i+1)
-		if err := w.AddPlayer(name, playerColors[colorIndex]); err != nil {
+		if err := w.AddPlayer(name, color.RGBA{}); err != nil { // color derived from the name
 			panic(err)
 		}
-		colorIndex++
+		_ = w.SetReady(name) // a local human is at the screen (see -ready)
+		humans = append(humans, name)
 	}
 
-	// start server
-	if aiPlayer+remotePlayer > 0 {
-		go remote.RunServer(host, port, w, aiPlayer+remotePlayer+humanPlayer)
+	// the world stays frozen until all players have joined
+	w.Freeze = true
+
+	// start server (only needed for remote players)
+	timing := ai.Timing{Think: aiThink, Poll: aiPoll}
+	if remotePlayer > 0 {
+		server := remote.NewServer(host, port, w, aiPlayer+remotePlayer+humanPlayer)
+		server.MaxConnections = maxConn
+		server.MaxLineLength = maxLineLength
+		server.TimeBank = timeBank
+		server.TimeIncrement = timeIncrement
+		server.AdminToken = adminToken
+		server.AuditSize = auditSize
+		server.BattleLogSize = battleLog
+		server.AuditFile = auditFile
+		server.StatsFile = statsFile
+		server.ThumbnailWidth = thumbWidth
+		server.ThumbnailHeight = thumbHeight
+		server.ThumbnailRefresh = thumbRefresh
+		server.Substitute = func(client remote.GameClient, player string) {
+			go ai.Takeover(client, player, timing) // a resigned player is replaced by a RandomAI
+		}
+		go server.Run()
 		time.Sleep(200 * time.Millisecond)
 	}
 
-	// add local AIs
+	// add local AIs (in-process, without TCP)
 	for i := 0; i < aiPlayer; i++ {
 		name := fmt.Sprintf("RandomAI %d", i+1)
-		go ai.Play(host, port, name, playerColors[colorIndex])
-		colorIndex++
+		go ai.PlayLocal(w, aiPlayer+remotePlayer+humanPlayer, name, color.RGBA{}, timing) // color derived from the name
 	}
 
-	// human only
-	if humanPlayer > 0 && aiPlayer+remotePlayer == 0 {
+	// human only (a single human waits in the lobby for AI opponents added via the gui)
+	var addOpponent func()
+	if humanPlayer > 1 && aiPlayer+remotePlayer == 0 {
 		w.InitPopulation()
 		w.Freeze = false
 	}
+	if humanPlayer == 1 && aiPlayer+remotePlayer == 0 {
+		addOpponent = func() {
+			if w.Started() {
+				return
+			}
+			// the first opponent fills the game and starts it (the human is already ready, see -ready)
+			go ai.PlayLocal(w, 2, "RandomAI 1", color.RGBA{}, timing)
+		}
+	}
 
 	// run gui (blocking)
-	if err := gui.RunGUI(1778, 1000, programName, w, autoRedraw); err != nil {
+	if err := gui.RunGUI(1778, 1000, programName, w, autoRedraw, labelLayout, addOpponent, humans...); err != nil {
 		panic(err)
 	}
 }
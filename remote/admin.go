@@ -0,0 +1,130 @@
+package remote
+
+import (
+	"crypto/subtle"
+	"errors"
+)
+
+// authorize checks the token of an ADMIN command against Server.AdminToken.
+//
+// Error cases:
+//   - Admin commands are disabled (no AdminToken configured).
+//   - The token is wrong.
+func (s *Server) authorize(token string) error {
+	if s.AdminToken == "" {
+		return errors.New("err: admin commands disabled") // ERROR EXIT
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(s.AdminToken)) != 1 {
+		return errors.New("err: invalid admin token") // ERROR EXIT
+	}
+	return nil // SUCCESS EXIT
+}
+
+// pause freezes the running game (PAUSE command). The players stay connected and can still request STATUS,
+// but all moves are rejected with "world is frozen" until the game is resumed. The match clock stops as well.
+//
+// Error cases:
+//   - The game is not running (not started yet or already paused).
+func (s *Server) pause() error {
+	s.World.LobbyLock().Lock()
+	defer s.World.LobbyLock().Unlock()
+
+	if s.World.Frozen() {
+		return errors.New("err: game is not running") // ERROR EXIT
+	}
+	s.World.SetFreeze(true)
+	s.paused = true
+	s.World.Logger().Info("game paused")
+	return nil // SUCCESS EXIT
+}
+
+// resume continues a game paused with pause (RESUME command).
+//
+// Error cases:
+//   - The game is not paused. A game that has not started yet cannot be unfrozen this way.
+func (s *Server) resume() error {
+	s.World.LobbyLock().Lock()
+	defer s.World.LobbyLock().Unlock()
+
+	if !s.paused {
+		return errors.New("err: game is not paused") // ERROR EXIT
+	}
+	s.World.SetFreeze(false)
+	s.paused = false
+	s.World.Logger().Info("game resumed")
+	return nil // SUCCESS EXIT
+}
+
+// setOwner gives a country to a player (OWNER command, see core.World.SetCountryOwner).
+// It is only allowed while the world is frozen (before the start or while paused), so the owner of a country
+// cannot change in the middle of a turn.
+//
+// Error cases:
+//   - The game is running.
+//   - The country or player does not exist, or the strength is less than 1.
+func (s *Server) setOwner(country, player string, strength int) error {
+	s.World.LobbyLock().Lock()
+	defer s.World.LobbyLock().Unlock()
+
+	if !s.World.Frozen() {
+		return errors.New("err: game is running (PAUSE first)") // ERROR EXIT
+	}
+	if err := s.World.SetCountryOwner(country, player, strength); err != nil {
+		return err // ERROR EXIT
+	}
+	s.World.Logger().Info("country owner set", "country", country, "player", player, "strength", strength)
+	return nil // SUCCESS EXIT
+}
+
+// start closes the lobby and starts the game with the players who have joined so far (START command),
+// even if not all of them are ready or the game is not full.
+//
+// Error cases:
+//   - The game has already started.
+//   - Less than two players have joined.
+func (s *Server) start() error {
+	s.World.LobbyLock().Lock()
+	defer s.World.LobbyLock().Unlock()
+
+	if s.World.Started() {
+		return errors.New("err: game already started") // ERROR EXIT
+	}
+	if s.World.PlayerCount() < 2 {
+		return errors.New("err: not enough players") // ERROR EXIT
+	}
+	startGame(s.World)
+	return nil // SUCCESS EXIT
+}
+
+// setMaxPlayers changes the number of seats in the lobby (MAXPLAYERS command, see core.World.SetMaxPlayers).
+// If the players who have already joined fill the new lobby, the game starts like after the last join.
+func (s *Server) setMaxPlayers(seats int) error {
+	s.World.LobbyLock().Lock()
+	defer s.World.LobbyLock().Unlock()
+
+	if err := s.World.SetMaxPlayers(seats); err != nil {
+		return err // ERROR EXIT
+	}
+	s.World.Logger().Info("lobby resized", "seats", seats)
+	if s.World.PlayerCount() == seats && !s.World.RequireReady {
+		startGame(s.World)
+	}
+	return nil // SUCCESS EXIT
+}
+
+// kick removes a player from the game (KICK command). In the lobby, the slot becomes free again.
+// In a running game, the countries of the player are handed over according to core.World.DeparturePolicy.
+//
+// Error cases:
+//   - The game is running and the world has no departure policy (see core.World.RemovePlayer).
+//   - The player does not exist.
+func (s *Server) kick(player string) error {
+	s.World.LobbyLock().Lock()
+	defer s.World.LobbyLock().Unlock()
+
+	if err := s.World.RemovePlayer(player); err != nil {
+		return err // ERROR EXIT
+	}
+	s.World.Logger().Info("player kicked", "player", player)
+	return nil // SUCCESS EXIT
+}
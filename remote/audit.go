@@ -0,0 +1,135 @@
+package remote
+
+import (
+	"encoding/json"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultAuditSize is the default number of commands kept by the audit log (see Server.AuditSize).
+const DefaultAuditSize = 1000
+
+// auditResultLen is the maximum length of a stored response (STATUS responses contain the whole world).
+const auditResultLen = 200
+
+// AuditEntry is a single command received by the server, including rejected commands (see Server.AuditSize).
+type AuditEntry struct {
+	Time     time.Time     // The time at which the command was received.
+	Duration time.Duration // The time the server needed to process the command.
+	Addr     string        // The remote address of the connection.
+	Player   string        // The player of the connection ("" if no player was created yet).
+	Command  string        // The received line (the token of ADMIN commands is removed).
+	Result   string        // The response of the server (shortened to auditResultLen characters).
+}
+
+// auditLog is the bounded audit trail of a server. The newest entries are kept in a ring buffer
+// and, if a file is set, every entry is also appended to the file as a JSON line.
+// The zero value is ready to use (memory only).
+type auditLog struct {
+	mux     sync.Mutex
+	entries ring[AuditEntry] // the newest entries
+	path    string           // optional file that receives every entry (see Server.AuditFile)
+}
+
+// add appends an entry to the audit log. The log keeps at most size entries in memory.
+func (a *auditLog) add(e AuditEntry, size int) {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	// file (opened for every entry, so no handle is left open when the server ends)
+	if a.path != "" {
+		if b, err := json.Marshal(e); err == nil {
+			_ = appendFile(a.path, append(b, '\n'))
+		}
+	}
+
+	// memory
+	a.entries.add(e, size)
+}
+
+// recent returns up to n of the newest entries in chronological order (all entries if n < 1).
+func (a *auditLog) recent(n int) []AuditEntry {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	return a.entries.recent(n)
+}
+
+// record adds a processed command to the audit log of the server.
+//
+// Parameters:
+//   - conn: The connection of the client, which remembers the response.
+//   - start: The time at which the command was received.
+//   - player: The player of the connection.
+//   - line: The received line.
+func (s *Server) record(conn *auditConn, start time.Time, player, line string) {
+	s.audit.add(AuditEntry{
+		Time:     start,
+		Duration: s.timeSource().Now().Sub(start),
+		Addr:     conn.RemoteAddr().String(),
+		Player:   player,
+		Command:  auditCommand(line),
+		Result:   conn.last,
+	}, s.AuditSize)
+}
+
+// openAuditFile checks that Server.AuditFile can be written and sends all following entries to it (see Run).
+// The file is created if it does not exist.
+func (s *Server) openAuditFile() error {
+	if err := appendFile(s.AuditFile, nil); err != nil {
+		return err
+	}
+	s.audit.mux.Lock()
+	s.audit.path = s.AuditFile
+	s.audit.mux.Unlock()
+	return nil
+}
+
+// appendFile appends the data to the file and closes it again. The file is created if it does not exist.
+func appendFile(path string, b []byte) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// auditJson returns up to n of the newest audit entries as JSON array (AUDIT command).
+func (s *Server) auditJson(n int) string {
+	b, err := json.Marshal(s.audit.recent(n))
+	if err != nil {
+		return errText(err)
+	}
+	return string(b)
+}
+
+// auditCommand prepares a received line for the audit log: secrets such as the admin token are removed.
+func auditCommand(line string) string {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "ADMIN|") {
+		return "ADMIN|***"
+	}
+	return line
+}
+
+// auditConn is a connection that remembers the last response sent to the client, so it can be stored in the audit log.
+type auditConn struct {
+	net.Conn
+	last string // the last response without line break
+}
+
+// Write sends the data to the client and remembers it as the last response.
+func (c *auditConn) Write(b []byte) (int, error) {
+	c.last = strings.TrimRight(string(b), "\r\n")
+	if len(c.last) > auditResultLen {
+		c.last = c.last[:auditResultLen] + "..."
+	}
+	return c.Conn.Write(b)
+}
@@ -0,0 +1,117 @@
+package remote
+
+import (
+	"RISK-CodeConflict/core"
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_auditLog(t *testing.T) {
+	var a auditLog
+	for _, cmd := range []string{"A", "B", "C", "D", "E"} {
+		a.add(AuditEntry{Command: cmd}, 3)
+	}
+	commands := func(entries []AuditEntry) string {
+		var s []string
+		for _, e := range entries {
+			s = append(s, e.Command)
+		}
+		return strings.Join(s, ",")
+	}
+
+	// bounded and in chronological order
+	if got := commands(a.recent(0)); got != "C,D,E" {
+		t.Fatal(got)
+	}
+	if got := commands(a.recent(2)); got != "D,E" {
+		t.Fatal(got)
+	}
+
+	// disabled
+	var off auditLog
+	off.add(AuditEntry{Command: "A"}, 0)
+	if len(off.recent(0)) != 0 {
+		t.Fatal("entry stored")
+	}
+}
+
+func TestServer_audit(t *testing.T) {
+	world := core.NewWorld()
+	world.Freeze = true
+	server := NewServer("127.0.0.1", "0", world, 2)
+	server.AdminToken = "secret"
+	server.AuditFile = filepath.Join(t.TempDir(), "audit.jsonl")
+	if err := server.openAuditFile(); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, serverConn := net.Pipe()
+	defer func() { _ = conn.Close() }()
+	go server.handleRequest(serverConn)
+	tp := textproto.NewReader(bufio.NewReader(conn))
+
+	send := func(line string) string {
+		t.Helper()
+		if _, err := conn.Write([]byte(line + "\n")); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := tp.ReadLine()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	send("AUDIT")
+	send("PLAYER|Player1")
+	send("MOVE|Alaska|Alberta|x")
+	send("MOVE|Alaska|Alberta|3")
+	send("ADMIN|secret")
+
+	// in memory: the rejected commands are included, the admin token is removed
+	var entries []AuditEntry
+	resp := send("AUDIT|4")
+	if err := json.Unmarshal([]byte(resp), &entries); err != nil {
+		t.Fatal(resp, err)
+	}
+	want := []AuditEntry{
+		{Player: "Player1", Command: "PLAYER|Player1", Result: "OK"},
+		{Player: "Player1", Command: "MOVE|Alaska|Alberta|x", Result: "err: malformed MOVE command"},
+		{Player: "Player1", Command: "MOVE|Alaska|Alberta|3", Result: "world is frozen"},
+		{Player: "Player1", Command: "ADMIN|***", Result: "OK"},
+	}
+	if len(entries) != len(want) {
+		t.Fatal(entries)
+	}
+	for i, e := range entries {
+		if e.Player != want[i].Player || e.Command != want[i].Command || e.Result != want[i].Result || e.Time.IsZero() {
+			t.Fatalf("entry %d: got %+v, want %+v", i, e, want[i])
+		}
+	}
+
+	// file: one JSON line per command (the AUDIT|4 command is recorded after its response)
+	_ = conn.Close()
+	b, err := os.ReadFile(server.AuditFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) < 5 || !strings.Contains(lines[0], `"Result":"err: not authorized"`) {
+		t.Fatal(lines)
+	}
+}
+
+func Test_auditCommand(t *testing.T) {
+	if got := auditCommand(" ADMIN|secret "); got != "ADMIN|***" {
+		t.Fatal(got)
+	}
+	if got := auditCommand("MOVE|Alaska|Alberta|3"); got != "MOVE|Alaska|Alberta|3" {
+		t.Fatal(got)
+	}
+}
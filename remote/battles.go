@@ -0,0 +1,61 @@
+package remote
+
+import (
+	"RISK-CodeConflict/core"
+	"encoding/json"
+	"errors"
+	"sync"
+)
+
+// DefaultBattleLogSize is the default number of battles kept with their dice for LASTBATTLES (see Server.BattleLogSize).
+const DefaultBattleLogSize = 50
+
+// battleLog keeps the newest battles of a server in a ring buffer (see Server.BattleLogSize).
+// The zero value is ready to use.
+type battleLog struct {
+	mux     sync.Mutex
+	battles ring[core.BattleEvent] // the newest battles
+}
+
+// add appends a battle to the log. The log keeps at most size battles.
+func (l *battleLog) add(e core.BattleEvent, size int) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.battles.add(e, size)
+}
+
+// recent returns up to n of the newest battles in chronological order (all battles if n < 1).
+func (l *battleLog) recent(n int) []core.BattleEvent {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	return l.battles.recent(n)
+}
+
+// recordBattles keeps every battle of the world with the dice of all rounds (see BattleLogSize and Run).
+func (s *Server) recordBattles() {
+	s.World.BattleDetails = true
+	s.World.Subscribe(func(e core.Event) {
+		if b, ok := e.(core.BattleEvent); ok {
+			s.battles.add(b, s.BattleLogSize)
+		}
+	})
+}
+
+// battlesJson returns up to n of the newest battles as JSON array (LASTBATTLES command).
+// The dice would reveal hidden strengths, so the battles are not sent with fog of war.
+// Errors are returned separately, so only the JSON is framed (see FeatureFrame).
+func (s *Server) battlesJson(n int) (string, error) {
+	if s.BattleLogSize <= 0 {
+		return "", errors.New("err: battle log disabled") // ERROR EXIT
+	}
+	if s.World.FogOfWar {
+		return "", errors.New("err: battles hidden by fog of war") // ERROR EXIT
+	}
+	b, err := json.Marshal(s.battles.recent(n))
+	if err != nil {
+		return "", err // ERROR EXIT
+	}
+	return string(b), nil // SUCCESS EXIT
+}
@@ -0,0 +1,89 @@
+package remote
+
+import (
+	"RISK-CodeConflict/core"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func Test_battleLog(t *testing.T) {
+	var l battleLog
+	for _, c := range []string{"A", "B", "C", "D", "E"} {
+		l.add(core.BattleEvent{Country: c}, 3)
+	}
+	countries := func(battles []core.BattleEvent) string {
+		var s []string
+		for _, b := range battles {
+			s = append(s, b.Country)
+		}
+		return strings.Join(s, ",")
+	}
+
+	// bounded and in chronological order
+	if got := countries(l.recent(0)); got != "C,D,E" {
+		t.Fatal(got)
+	}
+	if got := countries(l.recent(2)); got != "D,E" {
+		t.Fatal(got)
+	}
+
+	// disabled
+	var off battleLog
+	off.add(core.BattleEvent{Country: "A"}, 0)
+	if len(off.recent(0)) != 0 {
+		t.Fatal("battle stored")
+	}
+}
+
+func TestServer_battlesJson(t *testing.T) {
+	// P2 occupies Egypt, P1 the rest
+	world := core.NewWorld()
+	world.NoLog = true
+	world.PlayerQueue = []*core.Player{{Name: "P1"}, {Name: "P2"}}
+	for _, c := range world.Countries {
+		c.Occupier = core.NewArmy(world, 1, "P1", c.Name)
+	}
+	world.Country("Egypt").Occupier.Player = "P2"
+	server := NewServer("127.0.0.1", "0", world, 2)
+	server.recordBattles()
+
+	// empty
+	if resp, err := server.battlesJson(0); err != nil || resp != "[]" {
+		t.Fatal(resp, err)
+	}
+
+	// a recorded battle has the dice, even without battle log
+	world.Country("Egypt").Invader = core.NewArmy(world, 10, "P1", "North Africa")
+	if _, err := world.EndTurn("P1"); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.battlesJson(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var battles []core.BattleEvent
+	if err := json.Unmarshal([]byte(resp), &battles); err != nil {
+		t.Fatal(err)
+	}
+	if len(battles) != 1 || battles[0].Country != "Egypt" || len(battles[0].Details) < 1 || len(battles[0].Details[0].AttackerDice) != 3 {
+		t.Fatal(battles)
+	}
+
+	// fog of war
+	world.FogOfWar = true
+	if _, err := server.battlesJson(0); err == nil || err.Error() != "err: battles hidden by fog of war" {
+		t.Fatal(err)
+	}
+
+	// disabled
+	server.BattleLogSize = 0
+	if _, err := server.battlesJson(0); err == nil || err.Error() != "err: battle log disabled" {
+		t.Fatal(err)
+	}
+
+	// with frame, errors stay single lines
+	send := pipeServer(t, server)
+	send("HELLO|frame", "OK|frame")
+	send("LASTBATTLES", "err: battle log disabled")
+}
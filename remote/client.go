@@ -3,20 +3,75 @@ package remote
 import (
 	"RISK-CodeConflict/core"
 	"bufio"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"image/color"
+	"io"
 	"net"
-	"net/textproto"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 )
 
+// GameClient is the transport-agnostic set of methods an AI uses to play the game.
+// It is implemented by the TCP Client and the in-process LocalClient, so AIs (see ai.Play),
+// tests with mock clients and future transports can be used interchangeably.
+type GameClient interface {
+	// AddPlayer registers the player with the given name (zero color: derived from the name).
+	AddPlayer(name string, clr color.RGBA) error
+	// Status updates the provided World instance with the current world state.
+	Status(update *core.World) error
+	// Country returns the current state of a single country.
+	Country(name string) (*core.Country, error)
+	// Ready confirms in the lobby that the game can start (see core.World.RequireReady).
+	Ready() error
+	// PreferContinent sets the preferred start continent in the lobby ("" removes the preference).
+	PreferContinent(continent string) error
+	// MyTurn reports whether it is the turn of the player and returns the remaining time bank (0 without match clock).
+	MyTurn() (bool, time.Duration, error)
+	// Pool returns the reinforcement of the player and the pools per continent (nil without local reinforcements).
+	Pool() (int, map[string]int, error)
+	// EndTurn signals that the player has finished their turn.
+	EndTurn() error
+	// AttackOrMove attacks or moves from one country to another with a specified strength.
+	AttackOrMove(attacker, defender string, strength int) error
+	// AttackRounds attacks with a limit of dice rounds (see core.World.AttackOrMoveRounds).
+	AttackRounds(attacker, defender string, strength, rounds int) error
+	// Reinforcement reinforces a country with additional strength.
+	Reinforcement(country string, strength int) error
+	// ReinforceAll deploys reinforcements in several countries at once and returns one result per entry.
+	ReinforceAll(orders []core.Recruitment) ([]error, error)
+	// Truce offers or accepts a truce with another player.
+	Truce(other string, rounds int) error
+	// Scout spends one unit to reveal a fogged country (see core.World.Scout).
+	Scout(country string) error
+	// SetCapital moves the capital of the player in the first round (see core.World.SetCapital).
+	SetCapital(country string) error
+}
+
+// interface check: GameClient
+var _ GameClient = (*Client)(nil)
+var _ GameClient = (*LocalClient)(nil)
+
 // Client represents a remote connection to the game server, allowing communication and interaction with the game world.
 type Client struct {
-	conn *net.TCPConn      // TCP connection to the game server
-	tp   *textproto.Reader // Text protocol reader for the connection
-	mux  *sync.Mutex       // Mutex for thread-safe operations
+	conn   *net.TCPConn  // TCP connection to the game server
+	reader *bufio.Reader // Buffered reader for the responses of the server
+	mux    *sync.Mutex   // Mutex for thread-safe operations
+
+	// MaxResponseLength limits the length of a response in bytes (DefaultMaxResponseLength).
+	// The world state of STATUS is the longest response, so the limit must fit the biggest map. 0 means unlimited.
+	MaxResponseLength int
+
+	tokenPrefix string // Random prefix of the idempotency tokens of this client
+	tokenCount  uint64 // Number of idempotency tokens generated so far
+
+	gzip     bool // Status uses the compressed STATUSGZ command (negotiated with Hello)
+	envelope bool // Status responses are wrapped in an envelope (negotiated with Hello)
+	frame    bool // Data responses are length-prefixed (negotiated with Hello)
 }
 
 // NewClient creates a new Client instance and establishes a connection to the game server at the provided host and port.
@@ -37,21 +92,56 @@ func NewClient(host, port string) (*Client, error) {
 
 	// Create a new client instance
 	c := &Client{
-		conn: conn,
-		tp:   textproto.NewReader(bufio.NewReader(conn)),
-		mux:  new(sync.Mutex),
+		conn:   conn,
+		reader: bufio.NewReader(conn),
+		mux:    new(sync.Mutex),
+
+		MaxResponseLength: DefaultMaxResponseLength,
+
+		tokenPrefix: newTokenPrefix(),
 	}
 
 	// Return the client instance
 	return c, nil
 }
 
+// Hello negotiates optional protocol features with the server (HELLO command), e.g. FeatureGzip.
+// Without a handshake, the client uses the plain commands, so it works with every server.
+// If FeatureGzip is accepted, Status requests the compressed world state and inflates it transparently,
+// which reduces the bandwidth for big maps. If FeatureEnvelope is accepted, Status can tell a world state
+// from an error text without guessing and detects truncated responses. If FeatureFrame is accepted,
+// data responses are read by their length, so they may contain line breaks.
+//
+// Returns:
+//   - The features accepted by the server (a subset of the requested features).
+//   - An error if the server rejects the handshake (e.g. an old server without HELLO).
+func (c *Client) Hello(features ...string) ([]string, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	resp := strings.Split(c.command(strings.Join(append([]string{"HELLO"}, features...), "|")), "|")
+	if resp[0] != "OK" {
+		return nil, errors.New(strings.Join(resp, "|")) // error text
+	}
+
+	accepted := resp[1:]
+	c.gzip = slices.Contains(accepted, FeatureGzip)
+	c.envelope = slices.Contains(accepted, FeatureEnvelope)
+	c.frame = slices.Contains(accepted, FeatureFrame)
+	return accepted, nil
+}
+
 // AddPlayer registers or identifies the player with the given name on the server.
+// If clr is the zero value (color.RGBA{}), no color is sent and the server derives one from the name.
 func (c *Client) AddPlayer(name string, clr color.RGBA) error {
 	c.mux.Lock()
 	defer c.mux.Unlock()
 
-	resp := c.command(fmt.Sprintf("PLAYER|%s|%d|%d|%d", name, clr.R, clr.G, clr.B))
+	cmd := fmt.Sprintf("PLAYER|%s|%d|%d|%d", name, clr.R, clr.G, clr.B)
+	if clr == (color.RGBA{}) {
+		cmd = fmt.Sprintf("PLAYER|%s", name)
+	}
+	resp := c.command(cmd)
 
 	if strings.HasPrefix(resp, "OK") {
 		return nil // Operation successful
@@ -61,25 +151,113 @@ func (c *Client) AddPlayer(name string, clr color.RGBA) error {
 }
 
 // Status retrieves the current world status from the server and updates the provided World instance.
+// The world state is transferred compressed and in an envelope
+// if FeatureGzip and FeatureEnvelope were negotiated (see Hello).
 func (c *Client) Status(update *core.World) error {
 	c.mux.Lock()
 	defer c.mux.Unlock()
 
-	resp := c.command("STATUS")
-
 	if update == nil {
 		return errors.New("world is nil")
+	}
+
+	cmd := "STATUS"
+	if c.gzip {
+		cmd = "STATUSGZ"
+	}
+	resp, err := c.readData(c.command(cmd))
+	if err != nil {
+		return err
+	}
+	if c.envelope && !c.frame {
+		if resp, err = unwrapEnvelope(resp); err != nil {
+			return err
+		}
+	}
+	if c.gzip {
+		if resp, err = inflate(resp); err != nil {
+			return err
+		}
+	}
+	return update.FromViewJson(resp)
+}
+
+// Country retrieves the current state of a single country from the server (COUNTRY command).
+// It is a lightweight alternative to Status if only one country is of interest.
+// The returned country is not linked to a world, so NeighborsObj and ContinentObj cannot be used.
+func (c *Client) Country(name string) (*core.Country, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	resp, err := c.readData(c.command("COUNTRY|" + name))
+	if err != nil {
+		return nil, err
+	}
+	return parseCountry(resp)
+}
+
+// MyTurn asks the server whether it is the turn of the player (MYTURN command). This is more robust than
+// comparing the first player of the PlayerQueue in the world status, because the server knows the player
+// of the connection. It also works after a reconnect and returns the remaining time bank of the player
+// if the server uses a match clock (0 otherwise).
+func (c *Client) MyTurn() (bool, time.Duration, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return parseMyTurn(c.command("MYTURN"))
+}
+
+// Pool returns the reinforcement the player can deploy (POOL command), also if it is not their turn.
+// If the world uses local reinforcements (see core.World.ContinentReinforcementPools),
+// the pools per continent are returned as well; otherwise the map is nil.
+func (c *Client) Pool() (int, map[string]int, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return parsePool(c.command("POOL"))
+}
+
+// PreferContinent sets the continent in which the player would like to start (PREFER command).
+// It must be sent in the lobby; an empty name removes the preference (see core.World.SetStartContinent).
+func (c *Client) PreferContinent(continent string) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	cmd := "PREFER"
+	if continent != "" {
+		cmd += "|" + continent
+	}
+	resp := c.command(cmd)
+
+	if strings.HasPrefix(resp, "OK") {
+		return nil // Operation successful
+	} else {
+		return errors.New(resp)
+	}
+}
+
+// Ready confirms in the lobby that the player is ready to start (READY command).
+// If the server requires confirmation (see core.World.RequireReady), the game starts when all players are ready.
+func (c *Client) Ready() error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	resp := c.command("READY")
+
+	if strings.HasPrefix(resp, "OK") {
+		return nil // Operation successful
 	} else {
-		return update.FromJson(resp)
+		return errors.New(resp)
 	}
 }
 
 // EndTurn signals the server that the player has finished their turn.
+// The command carries an idempotency token, so the server answers a repeat without ending the turn twice.
 func (c *Client) EndTurn() error {
 	c.mux.Lock()
 	defer c.mux.Unlock()
 
-	resp := c.command("END")
+	resp := c.command("END|" + c.nextToken())
 
 	if strings.HasPrefix(resp, "OK") {
 		return nil // Operation successful
@@ -89,11 +267,27 @@ func (c *Client) EndTurn() error {
 }
 
 // AttackOrMove sends a command to the server to attack or move from one country to another with a specified strength.
+// The command carries an idempotency token, so the server answers a repeat without executing the move twice.
 func (c *Client) AttackOrMove(attacker, defender string, strength int) error {
 	c.mux.Lock()
 	defer c.mux.Unlock()
 
-	resp := c.command(fmt.Sprintf("MOVE|%s|%s|%d", attacker, defender, strength))
+	resp := c.command(fmt.Sprintf("MOVE|%s|%s|%d|%s", attacker, defender, strength, c.nextToken()))
+
+	if strings.HasPrefix(resp, "OK") {
+		return nil // Operation successful
+	} else {
+		return errors.New(resp)
+	}
+}
+
+// AttackRounds sends a command to the server to attack with a limit of dice rounds (see core.World.AttackOrMoveRounds).
+// Like AttackOrMove, the command carries an idempotency token.
+func (c *Client) AttackRounds(attacker, defender string, strength, rounds int) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	resp := c.command(fmt.Sprintf("ATTACK|%s|%s|%d|%d|%s", attacker, defender, strength, rounds, c.nextToken()))
 
 	if strings.HasPrefix(resp, "OK") {
 		return nil // Operation successful
@@ -107,11 +301,87 @@ func (c *Client) Reinforcement(country string, strength int) error {
 	return c.AttackOrMove(country, country, strength)
 }
 
+// ReinforceAll deploys reinforcements in several countries with a single RECRUITALL command.
+// The entries are applied in order; failed entries do not undo the successful ones.
+//
+// Returns:
+//   - One result per entry of orders: nil if the entry was applied, otherwise the reason.
+//   - An error if the whole command was rejected (e.g. no orders or a malformed response).
+func (c *Client) ReinforceAll(orders []core.Recruitment) ([]error, error) {
+	if len(orders) == 0 {
+		return nil, errors.New("no orders")
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	cmd := "RECRUITALL"
+	for _, o := range orders {
+		cmd += fmt.Sprintf("|%s:%d", o.Country, o.Strength)
+	}
+	resp := strings.Split(c.command(cmd), "|")
+	if len(resp) != len(orders) {
+		return nil, errors.New(strings.Join(resp, "|")) // error text
+	}
+
+	results := make([]error, len(orders))
+	for i, r := range resp {
+		if !strings.HasPrefix(r, "OK") {
+			results[i] = errors.New(r)
+		}
+	}
+	return results, nil
+}
+
+// Truce offers a truce to another player for the given number of rounds, or accepts the truce offered by that player.
+// While a truce is active, neither player can attack the other.
+func (c *Client) Truce(other string, rounds int) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	resp := c.command(fmt.Sprintf("TRUCE|%s|%d", other, rounds))
+
+	if strings.HasPrefix(resp, "OK") {
+		return nil // Operation successful
+	} else {
+		return errors.New(resp)
+	}
+}
+
+// Scout spends one unit of an own neighbor to reveal the strength of a country hidden by the fog of war.
+// The country stays revealed for the number of rounds configured on the server.
+func (c *Client) Scout(country string) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	resp := c.command("SCOUT|" + country)
+
+	if strings.HasPrefix(resp, "OK") {
+		return nil // Operation successful
+	} else {
+		return errors.New(resp)
+	}
+}
+
+// SetCapital sends a command to the server to move the capital of the player (see core.World.SetCapital).
+func (c *Client) SetCapital(country string) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	resp := c.command("CAPITAL|" + country)
+
+	if strings.HasPrefix(resp, "OK") {
+		return nil // Operation successful
+	} else {
+		return errors.New(resp)
+	}
+}
+
 //---------------- HELPER --------------------------------------------------------------------------------------------//
 
 // command sends the command string to the server and returns the response.
 func (c *Client) command(cmd string) string {
-	if c == nil || c.conn == nil || c.tp == nil {
+	if c == nil || c.conn == nil || c.reader == nil {
 		return "err: TcpClient connection closed."
 	}
 
@@ -127,7 +397,7 @@ func (c *Client) command(cmd string) string {
 	}
 
 	// Read response
-	resp, err := c.tp.ReadLine()
+	resp, err := readLine(c.reader, c.MaxResponseLength)
 	if err != nil {
 		return fmt.Sprintf("err: TcpClient read: %v", err)
 	}
@@ -135,3 +405,46 @@ func (c *Client) command(cmd string) string {
 	// Return server response
 	return resp
 }
+
+// readData returns the payload of a data response (see frame). The header line has already been read
+// by command; readData then reads exactly the announced number of bytes and the final line break.
+// Without FeatureFrame, the response line itself is the payload.
+//
+// Error cases:
+//   - The response is not framed (an error text, which is returned as error).
+//   - The length is invalid or larger than MaxResponseLength.
+//   - The connection fails or the payload is not terminated by a line break.
+func (c *Client) readData(resp string) (string, error) {
+	if !c.frame {
+		return resp, nil // SUCCESS EXIT: not negotiated
+	}
+	length, err := strconv.Atoi(strings.TrimPrefix(resp, "DATA|"))
+	if !strings.HasPrefix(resp, "DATA|") || err != nil || length < 0 {
+		return "", errors.New(resp) // ERROR EXIT: error text
+	}
+	if c.MaxResponseLength > 0 && length > c.MaxResponseLength {
+		_, _ = io.CopyN(io.Discard, c.reader, int64(length)+2) // skip the payload, so the next response can be read
+		return "", errLineTooLong                              // ERROR EXIT
+	}
+
+	buf := make([]byte, length+2) // payload and "\r\n"
+	if _, err := io.ReadFull(c.reader, buf); err != nil {
+		return "", fmt.Errorf("err: TcpClient read: %v", err) // ERROR EXIT
+	}
+	if string(buf[length:]) != "\r\n" {
+		return "", errors.New("invalid response: payload not terminated") // ERROR EXIT
+	}
+	return string(buf[:length]), nil // SUCCESS EXIT
+}
+
+// parseCountry converts the response of a COUNTRY command into a country.
+func parseCountry(resp string) (*core.Country, error) {
+	if !strings.HasPrefix(resp, "{") {
+		return nil, errors.New(resp) // error text
+	}
+	country := new(core.Country)
+	if err := json.Unmarshal([]byte(resp), country); err != nil {
+		return nil, err
+	}
+	return country, nil
+}
@@ -2,7 +2,9 @@ package remote
 
 import (
 	"RISK-CodeConflict/core"
+	"bufio"
 	"image/color"
+	"strings"
 	"testing"
 	"time"
 )
@@ -11,6 +13,7 @@ func TestClient_AddPlayer(t *testing.T) {
 	world := core.NewWorld()
 
 	go RunServer("127.0.0.1", "2222", world, 3)
+	time.Sleep(100 * time.Millisecond) // wait for the listener
 
 	client, err := NewClient("127.0.0.1", "2222")
 	if err != nil {
@@ -34,6 +37,7 @@ func TestClient_AttackOrMove_EndTurn(t *testing.T) {
 	world := core.NewWorld()
 
 	go RunServer("127.0.0.1", "3333", world, 2)
+	time.Sleep(100 * time.Millisecond) // wait for the listener
 
 	client, err := NewClient("127.0.0.1", "3333")
 	if err != nil {
@@ -73,3 +77,79 @@ func TestClient_AttackOrMove_EndTurn(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestClient_Truce(t *testing.T) {
+	world := core.NewWorld()
+
+	go RunServer("127.0.0.1", "4444", world, 2)
+	time.Sleep(100 * time.Millisecond) // wait for the listener
+
+	client, err := NewClient("127.0.0.1", "4444")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client2, err := NewClient("127.0.0.1", "4444")
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------
+
+	if err := client.AddPlayer("Player1", color.RGBA{R: 255, A: 255}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client2.AddPlayer("Player2", color.RGBA{G: 255, A: 255}); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond) // wait for the game start
+
+	if err := client.Truce("Player2", 0); err == nil || err.Error() != "truce rounds must be greater than 0" {
+		t.Fatal(err)
+	}
+	if err := client.Truce("Player2", 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := client2.Truce("Player1", 3); err != nil {
+		t.Fatal(err)
+	}
+	if !world.HasTruce("Player1", "Player2") {
+		t.Fatal("truce should be active")
+	}
+}
+
+func TestClient_readData(t *testing.T) {
+	payload := "{\n  \"multi\": \"line\"\r\n}"
+	stream := frame(payload) + "\r\n" + frame(strings.Repeat("x", 100)) + "\r\n" + "DATA|5\r\nabcdefg\r\n"
+
+	c := &Client{reader: bufio.NewReader(strings.NewReader(stream)), frame: true, MaxResponseLength: 50}
+	read := func() (string, error) {
+		header, err := readLine(c.reader, c.MaxResponseLength)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c.readData(header)
+	}
+
+	// multi-line payload
+	if s, err := read(); err != nil || s != payload {
+		t.Fatalf("%q %v", s, err)
+	}
+	// too long, but the next response can be read
+	if _, err := read(); err != errLineTooLong {
+		t.Fatal(err)
+	}
+	// wrong length
+	if _, err := read(); err == nil || err.Error() != "invalid response: payload not terminated" {
+		t.Fatal(err)
+	}
+
+	// error texts are not framed
+	if _, err := c.readData("err: world is frozen"); err == nil || err.Error() != "err: world is frozen" {
+		t.Fatal(err)
+	}
+	// without FeatureFrame, the line is the payload
+	c.frame = false
+	if s, err := c.readData("{}"); err != nil || s != "{}" {
+		t.Fatal(s, err)
+	}
+}
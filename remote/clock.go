@@ -0,0 +1,133 @@
+package remote
+
+import (
+	"time"
+)
+
+// clockInterval is the interval in which the match clock checks the active player.
+const clockInterval = 100 * time.Millisecond
+
+// Clock is the source of time of a server (see Server.Clock). The match clock, the audit log and the
+// thumbnail cache take the time from it, so tests can advance the time without sleeping.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTicker returns a channel that delivers the time in the given interval and a function that stops it.
+	NewTicker(interval time.Duration) (ticks <-chan time.Time, stop func())
+}
+
+// SystemClock is the real time of the operating system (default, see NewServer).
+type SystemClock struct{}
+
+// Now returns the current time (see time.Now).
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewTicker returns the channel of a time.Ticker and its Stop function.
+func (SystemClock) NewTicker(interval time.Duration) (<-chan time.Time, func()) {
+	t := time.NewTicker(interval)
+	return t.C, t.Stop
+}
+
+// turnKey identifies a single turn of the game (see core.World.Turn).
+type turnKey struct {
+	player   string
+	round    int
+	subRound int
+}
+
+// matchClock is the chess-clock-style timer of a server (see Server.TimeBank).
+// It is only used by the clock goroutine and needs no lock.
+type matchClock struct {
+	banks     map[string]time.Duration // The remaining time of every player (player -> time bank).
+	turn      turnKey                  // The turn that is currently timed.
+	turnStart time.Time                // The time at which the current turn started.
+	bankStart time.Duration            // The time bank of the active player at the start of the turn.
+	pausedAt  time.Time                // The time at which the game was paused (zero if not paused).
+}
+
+// runClock runs the match clock of the server. It is started by Run if TimeBank is greater than 0.
+// It remains BLOCKING.
+func (s *Server) runClock() {
+	ticks, stop := s.timeSource().NewTicker(clockInterval)
+	defer stop()
+
+	for now := range ticks {
+		s.tickClock(now)
+	}
+}
+
+// tickClock updates the time bank of the active player and publishes it in the world (see core.Player.TimeBank).
+//
+// The time bank of a player ticks down as long as the player is the first player in the PlayerQueue.
+// When the turn ends, TimeIncrement is added to the bank. If the bank runs out,
+// the turn of the player is ended by the server.
+//
+// Parameters:
+//   - now: The current time.
+func (s *Server) tickClock(now time.Time) {
+	w := s.World
+	c := &s.clock
+
+	// the clock only runs while the game is running
+	player, round, subRound := w.Turn()
+	if player == "" {
+		if c.turn.player != "" && c.pausedAt.IsZero() {
+			c.pausedAt = now // paused (see Server.pause)
+		}
+		return
+	}
+
+	// resumed: the pause does not count
+	if !c.pausedAt.IsZero() {
+		c.turnStart = c.turnStart.Add(now.Sub(c.pausedAt))
+		c.pausedAt = time.Time{}
+	}
+
+	// the game has started: every player gets the initial time bank
+	if c.banks == nil {
+		c.banks = make(map[string]time.Duration)
+		w.SetTimeBank("", s.TimeBank)
+	}
+
+	// new turn
+	turn := turnKey{player: player, round: round, subRound: subRound}
+	if turn != c.turn {
+		if c.turn.player != "" {
+			c.banks[c.turn.player] += s.TimeIncrement // increment for the finished turn
+			w.SetTimeBank(c.turn.player, c.banks[c.turn.player])
+		}
+		if _, ok := c.banks[player]; !ok {
+			c.banks[player] = s.TimeBank
+		}
+		c.turn = turn
+		c.turnStart = now
+		c.bankStart = c.banks[player]
+	}
+
+	// tick down
+	left := c.bankStart - now.Sub(c.turnStart)
+	if left < 0 {
+		left = 0
+	}
+	c.banks[player] = left
+	w.SetTimeBank(player, left)
+
+	// time is up
+	if left == 0 {
+		if _, err := w.EndTurn(player); err == nil {
+			w.Logger().Info("time bank exhausted, turn ended", "player", player)
+		}
+	}
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// timeSource returns the Clock of the server. Without a Clock, the SystemClock is used.
+func (s *Server) timeSource() Clock {
+	if s.Clock == nil {
+		return SystemClock{}
+	}
+	return s.Clock
+}
@@ -0,0 +1,201 @@
+package remote
+
+import (
+	"RISK-CodeConflict/core"
+	"image/color"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock for tests: the time only moves with Advance, and the ticker only fires with Tick.
+type fakeClock struct {
+	mux   sync.Mutex
+	now   time.Time
+	ticks chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), ticks: make(chan time.Time)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTicker(time.Duration) (<-chan time.Time, func()) {
+	return c.ticks, func() {}
+}
+
+// Advance moves the time forward.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Tick delivers the current time to the ticker. It blocks until the ticker loop has taken the tick,
+// so the previous tick has been processed completely.
+func (c *fakeClock) Tick() {
+	c.ticks <- c.Now()
+}
+
+func TestServer_tickClock(t *testing.T) {
+	world := core.NewWorld()
+	_ = world.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	_ = world.AddPlayer("P2", color.RGBA{G: 255, A: 255})
+	world.InitPopulation()
+
+	server := NewServer("127.0.0.1", "0", world, 2)
+	server.TimeBank = 10 * time.Second
+	server.TimeIncrement = 2 * time.Second
+	start := time.Now()
+
+	// frozen: the clock does not run
+	world.Freeze = true
+	server.tickClock(start)
+	if p := world.PlayerQueue[0]; p.TimeBank != 0 {
+		t.Fatal(p.TimeBank)
+	}
+	world.Freeze = false
+
+	// first turn
+	first, _, _ := world.Turn()
+	second := world.PlayerQueue[1].Name
+	server.tickClock(start)
+	server.tickClock(start.Add(3 * time.Second))
+	if b := world.Player(first).TimeBank; b != 7*time.Second {
+		t.Fatal(b)
+	}
+	if b := world.Player(second).TimeBank; b != 10*time.Second {
+		t.Fatal(b)
+	}
+
+	// END: the increment is added
+	if _, err := world.EndTurn(first); err != nil {
+		t.Fatal(err)
+	}
+	server.tickClock(start.Add(4 * time.Second))
+	if b := world.Player(first).TimeBank; b != 9*time.Second {
+		t.Fatal(b)
+	}
+
+	// time is up: the turn is ended automatically
+	server.tickClock(start.Add(20 * time.Second))
+	if b := world.Player(second).TimeBank; b != 0 {
+		t.Fatal(b)
+	}
+	if p, _, _ := world.Turn(); p != first {
+		t.Fatal("turn not ended", p)
+	}
+
+	// the next turn starts with the increment only
+	server.tickClock(start.Add(21 * time.Second)) // first player
+	_, _ = world.EndTurn(first)
+	server.tickClock(start.Add(22 * time.Second)) // second player
+	if b := world.Player(second).TimeBank; b != 2*time.Second {
+		t.Fatal(b)
+	}
+}
+
+func TestServer_runClock(t *testing.T) {
+	world := core.NewWorld()
+	_ = world.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	_ = world.AddPlayer("P2", color.RGBA{G: 255, A: 255})
+	world.InitPopulation()
+	first, second := world.PlayerQueue[0].Name, world.PlayerQueue[1].Name
+
+	clock := newFakeClock()
+	server := NewServer("127.0.0.1", "0", world, 2)
+	server.TimeBank = 10 * time.Second
+	server.Clock = clock
+	go server.runClock()
+
+	// the turn starts
+	clock.Tick()
+	clock.Advance(9 * time.Second)
+	clock.Tick()
+	clock.Tick() // wait until processed
+	if p, _, _ := world.Turn(); p != first || world.Player(first).TimeBank != time.Second {
+		t.Fatal(p, world.Player(first).TimeBank)
+	}
+
+	// the turn auto-ends after the timeout
+	clock.Advance(2 * time.Second)
+	clock.Tick()
+	clock.Tick() // wait until processed
+	if p, _, _ := world.Turn(); p != second || world.Player(first).TimeBank != 0 {
+		t.Fatal(p, world.Player(first).TimeBank)
+	}
+}
+
+func TestServer_tickClock_pause(t *testing.T) {
+	world := core.NewWorld()
+	_ = world.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	_ = world.AddPlayer("P2", color.RGBA{G: 255, A: 255})
+	world.InitPopulation()
+
+	server := NewServer("127.0.0.1", "0", world, 2)
+	server.TimeBank = 10 * time.Second
+	start := time.Now()
+	active := world.PlayerQueue[0].Name
+
+	server.tickClock(start)
+	server.tickClock(start.Add(2 * time.Second))
+
+	// pause for one minute
+	if err := server.pause(); err != nil {
+		t.Fatal(err)
+	}
+	server.tickClock(start.Add(3 * time.Second))
+	server.tickClock(start.Add(63 * time.Second))
+	if err := server.resume(); err != nil {
+		t.Fatal(err)
+	}
+	server.tickClock(start.Add(64 * time.Second))
+
+	// 2s before the pause + 1s after the pause
+	if b := world.Player(active).TimeBank; b != 7*time.Second {
+		t.Fatal(b)
+	}
+}
+
+func TestServer_myTurn(t *testing.T) {
+	world := core.NewWorld()
+	_ = world.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	_ = world.AddPlayer("P2", color.RGBA{G: 255, A: 255})
+	server := NewServer("127.0.0.1", "0", world, 2)
+
+	// lobby
+	if resp := server.myTurn("P1"); resp != "NO" {
+		t.Fatal(resp)
+	}
+	world.InitPopulation()
+	first, second := world.PlayerQueue[0].Name, world.PlayerQueue[1].Name
+	if resp := server.myTurn(first); resp != "YES" {
+		t.Fatal(resp)
+	}
+	if resp := server.myTurn(second); resp != "NO" {
+		t.Fatal(resp)
+	}
+
+	// match clock
+	server.TimeBank = 10 * time.Second
+	start := time.Now()
+	server.tickClock(start)
+	server.tickClock(start.Add(2500 * time.Millisecond))
+	if resp := server.myTurn(first); resp != "YES|7500" {
+		t.Fatal(resp)
+	}
+	if resp := server.myTurn(second); resp != "NO|10000" {
+		t.Fatal(resp)
+	}
+
+	// paused
+	world.Freeze = true
+	if resp := server.myTurn(first); resp != "NO|7500" {
+		t.Fatal(resp)
+	}
+}
@@ -0,0 +1,43 @@
+package remote
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"io"
+)
+
+// compress returns the gzip compressed text as base64 string, so it fits in a single protocol line.
+func compress(s string) (string, error) {
+	buf := new(bytes.Buffer)
+	enc := base64.NewEncoder(base64.StdEncoding, buf)
+	zw := gzip.NewWriter(enc)
+	if _, err := zw.Write([]byte(s)); err != nil {
+		return "", err
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil { // flush the last base64 block
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// inflate reverses compress.
+func inflate(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", errors.New(s) // error text of the server
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+	b, err = io.ReadAll(zr)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
@@ -0,0 +1,99 @@
+package remote
+
+import (
+	"RISK-CodeConflict/core"
+	"image/color"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCompress(t *testing.T) {
+	js := core.NewWorld().Json()
+	gz, err := compress(js)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gz) >= len(js)/2 || strings.ContainsAny(gz, "|\r\n") {
+		t.Fatal(len(gz), len(js))
+	}
+	if s, err := inflate(gz); err != nil || s != js {
+		t.Fatal(err)
+	}
+
+	// error text of the server
+	if _, err := inflate("err: gzip not negotiated"); err == nil || err.Error() != "err: gzip not negotiated" {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_Hello(t *testing.T) {
+	world := core.NewWorld()
+
+	go RunServer("127.0.0.1", "5557", world, 2)
+	time.Sleep(100 * time.Millisecond) // wait for the listener
+
+	client, err := NewClient("127.0.0.1", "5557")
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------
+
+	if err := client.AddPlayer("Player1", color.RGBA{R: 255, A: 255}); err != nil {
+		t.Fatal(err)
+	}
+
+	// uncompressed by default
+	plain := new(core.World)
+	if err := client.Status(plain); err != nil {
+		t.Fatal(err)
+	}
+
+	// negotiate compression
+	accepted, err := client.Hello(FeatureGzip, "unknown")
+	if err != nil || len(accepted) != 1 || accepted[0] != FeatureGzip || !client.gzip {
+		t.Fatal(accepted, err)
+	}
+	compressed := new(core.World)
+	if err := client.Status(compressed); err != nil {
+		t.Fatal(err)
+	}
+	if compressed.Json() != plain.Json() {
+		t.Fatal("different world")
+	}
+
+	// envelope
+	if accepted, err := client.Hello(FeatureGzip, FeatureEnvelope); err != nil || len(accepted) != 2 || !client.envelope {
+		t.Fatal(accepted, err)
+	}
+	wrapped := new(core.World)
+	if err := client.Status(wrapped); err != nil {
+		t.Fatal(err)
+	}
+	if wrapped.Json() != plain.Json() {
+		t.Fatal("different world")
+	}
+
+	// frame
+	if accepted, err := client.Hello(FeatureGzip, FeatureEnvelope, FeatureFrame); err != nil || len(accepted) != 3 || !client.frame {
+		t.Fatal(accepted, err)
+	}
+	framed := new(core.World)
+	if err := client.Status(framed); err != nil {
+		t.Fatal(err)
+	}
+	if framed.Json() != plain.Json() {
+		t.Fatal("different world")
+	}
+	if c, err := client.Country("Alaska"); err != nil || c.Name != "Alaska" {
+		t.Fatal(c, err)
+	}
+	if _, err := client.Country("Atlantis"); err == nil || err.Error() != "country not found" {
+		t.Fatal(err)
+	}
+
+	// no features
+	if accepted, err := client.Hello(); err != nil || len(accepted) != 0 || client.gzip || client.envelope || client.frame {
+		t.Fatal(accepted, err)
+	}
+}
@@ -0,0 +1,108 @@
+package remote
+
+import (
+	"RISK-CodeConflict/core"
+	"errors"
+	"image/color"
+	"strings"
+)
+
+// joinGame adds a player to the world and starts the game as soon as the required number of players is reached.
+// It is shared by the TCP server and the in-process LocalClient, so both transports follow the same rules.
+// Concurrent joins over both transports are serialized by the lobby lock of the world, so the population
+// cannot be initialized twice.
+//
+// Parameters:
+//   - w: The World object representing the game state.
+//   - maxPlayerCount: The number of players required before the game starts, unless the lobby
+//     has been resized (see seats).
+//   - name: The name of the new player.
+//   - clr: The color of the new player (zero value: derived from the name).
+//
+// Returns:
+//   - The trimmed player name, as stored in the world.
+//   - An error if the game is full or the player could not be added.
+func joinGame(w *core.World, maxPlayerCount int, name string, clr color.RGBA) (string, error) {
+	w.LobbyLock().Lock()
+	defer w.LobbyLock().Unlock()
+
+	// The game is full; the connection can only be used as a spectator.
+	maxPlayerCount = seats(w, maxPlayerCount)
+	if w.PlayerCount() >= maxPlayerCount {
+		return "", errors.New("err: game is full")
+	}
+
+	// Players can only join in the lobby (e.g. not in a loaded game that is already running).
+	if w.Started() {
+		return "", errors.New("err: game already started")
+	}
+
+	// Try adding the player to the world.
+	name = strings.TrimSpace(name)
+	if err := w.AddPlayer(name, clr); err != nil {
+		return "", err
+	}
+	w.Logger().Info("add player", "player", name)
+
+	// Check if the number of players matches the required count.
+	// If yes, initialize the world population and unfreeze the world to allow actions.
+	// With core.World.RequireReady, the game waits until all players are ready (see readyGame).
+	if w.PlayerCount() == maxPlayerCount {
+		w.Logger().Info("last player added")
+		if !w.RequireReady {
+			startGame(w)
+		}
+	}
+	return name, nil
+}
+
+// readyGame marks the player as ready (READY command) and starts the game if the lobby requires
+// confirmation (see core.World.RequireReady) and all players are ready.
+// Without RequireReady the command has no effect on the start, so clients can always send it.
+func readyGame(w *core.World, player string) error {
+	w.LobbyLock().Lock()
+	defer w.LobbyLock().Unlock()
+
+	if err := w.SetReady(player); err != nil {
+		return err
+	}
+	w.Logger().Info("player ready", "player", player)
+	if w.RequireReady && w.AllReady() {
+		startGame(w)
+	}
+	return nil
+}
+
+// leaveGame removes a player who disconnects from the lobby, so the slot is free for another player.
+// If the remaining players are all ready, the game starts. Players who leave a running game stay in it.
+func leaveGame(w *core.World, player string) {
+	w.LobbyLock().Lock()
+	defer w.LobbyLock().Unlock()
+
+	if w.Started() || w.RemovePlayer(player) != nil {
+		return
+	}
+	w.Logger().Info("player left the lobby", "player", player)
+	if w.RequireReady && w.AllReady() {
+		startGame(w)
+	}
+}
+
+// seats returns the number of seats in the lobby: core.World.MaxPlayers if it has been set
+// (MAXPLAYERS admin command), otherwise the limit of the transport that adds the player.
+// The caller must hold the lobby lock of the world (see core.World.LobbyLock).
+func seats(w *core.World, maxPlayerCount int) int {
+	if w.MaxPlayers > 0 {
+		return w.MaxPlayers
+	}
+	return maxPlayerCount
+}
+
+// startGame initializes the world population and unfreezes the world.
+// Both steps lock the world; orders in between are rejected, because the world is still frozen.
+// The caller must hold the lobby lock of the world (see core.World.LobbyLock).
+func startGame(w *core.World) {
+	w.InitPopulation()
+	w.SetFreeze(false)
+	w.Logger().Info("game started", "players", w.PlayerCount())
+}
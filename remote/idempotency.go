@@ -0,0 +1,95 @@
+package remote
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync"
+)
+
+// tokenCache stores the responses of MOVE and END commands that carry an idempotency token.
+// A client that repeats a command with the same token (e.g. after a lost response) receives the
+// original response again instead of executing the command a second time.
+//
+// Tokens are tracked per player and only for the current turn of that player:
+// a successful END discards all tokens of the player except the token of the END command itself.
+// The zero value is ready to use.
+type tokenCache struct {
+	mux     sync.Mutex
+	results map[string]map[string]string // player -> token -> response
+}
+
+// lookup returns the stored response for the token of the player.
+func (c *tokenCache) lookup(player, token string) (string, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	resp, ok := c.results[player][token]
+	return resp, ok
+}
+
+// store remembers the response for the token of the player.
+func (c *tokenCache) store(player, token, resp string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if c.results == nil {
+		c.results = make(map[string]map[string]string)
+	}
+	if c.results[player] == nil {
+		c.results[player] = make(map[string]string)
+	}
+	c.results[player][token] = resp
+}
+
+// reset discards all tokens of the player (the turn of the player is over).
+func (c *tokenCache) reset(player string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	delete(c.results, player)
+}
+
+// idempotent executes the command fn only if the token of the player was not used before.
+// Otherwise, the stored response of the first execution is returned.
+// Commands without a token are always executed (at-least-once semantics).
+//
+// Parameters:
+//   - player: The name of the player associated with the connection.
+//   - token: The optional idempotency token sent by the client ("" for none).
+//   - endTurn: True for END commands. A successful END starts a new set of tokens for the player.
+//   - fn: The command to execute. It returns the response for the client.
+//
+// Returns:
+//   - The response for the client.
+func (c *tokenCache) idempotent(player, token string, endTurn bool, fn func() string) string {
+	if token == "" {
+		return fn() // no token
+	}
+	if resp, ok := c.lookup(player, token); ok {
+		return resp // repeated command
+	}
+
+	resp := fn()
+	if endTurn && resp == "OK" {
+		c.reset(player)
+	}
+	c.store(player, token, resp)
+	return resp
+}
+
+//---------------- CLIENT --------------------------------------------------------------------------------------------//
+
+// newTokenPrefix returns a random prefix that makes the tokens of a client unique between all clients.
+func newTokenPrefix() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// nextToken returns a new idempotency token for the next MOVE or END command of the client.
+// The caller must hold the client mutex.
+func (c *Client) nextToken() string {
+	c.tokenCount++
+	return c.tokenPrefix + "-" + strconv.FormatUint(c.tokenCount, 10)
+}
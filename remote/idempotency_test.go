@@ -0,0 +1,60 @@
+package remote
+
+import (
+	"strconv"
+	"testing"
+)
+
+func Test_tokenCache_idempotent(t *testing.T) {
+	var cache tokenCache
+	calls := 0
+	move := func() string {
+		calls++
+		return "OK " + strconv.Itoa(calls)
+	}
+
+	// without token: always executed
+	cache.idempotent("p1", "", false, move)
+	cache.idempotent("p1", "", false, move)
+	if calls != 2 {
+		t.Fatal(calls)
+	}
+
+	// with token: executed once, repeat returns the first response
+	if resp := cache.idempotent("p1", "a", false, move); resp != "OK 3" {
+		t.Fatal(resp)
+	}
+	if resp := cache.idempotent("p1", "a", false, move); resp != "OK 3" || calls != 3 {
+		t.Fatal(resp, calls)
+	}
+
+	// tokens are per player
+	if resp := cache.idempotent("p2", "a", false, move); resp != "OK 4" {
+		t.Fatal(resp)
+	}
+
+	// successful END drops the old tokens but remembers its own
+	end := func() string { calls++; return "OK" }
+	if resp := cache.idempotent("p1", "e", true, end); resp != "OK" || calls != 5 {
+		t.Fatal(resp, calls)
+	}
+	if resp := cache.idempotent("p1", "e", true, end); resp != "OK" || calls != 5 {
+		t.Fatal(resp, calls)
+	}
+	if resp := cache.idempotent("p1", "a", false, move); resp != "OK 6" {
+		t.Fatal(resp)
+	}
+	if resp := cache.idempotent("p2", "a", false, move); resp != "OK 4" {
+		t.Fatal(resp)
+	}
+}
+
+func TestClient_nextToken(t *testing.T) {
+	c1 := &Client{tokenPrefix: newTokenPrefix()}
+	c2 := &Client{tokenPrefix: newTokenPrefix()}
+
+	t1, t2, t3 := c1.nextToken(), c1.nextToken(), c2.nextToken()
+	if t1 == t2 || t1 == t3 || t2 == t3 {
+		t.Fatal(t1, t2, t3)
+	}
+}
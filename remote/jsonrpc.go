@@ -0,0 +1,233 @@
+package remote
+
+import (
+	"RISK-CodeConflict/core"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// resultKind describes how the text response of a command is converted into a JSON-RPC response.
+type resultKind int
+
+const (
+	resultOK     resultKind = iota // "OK" is the result, any other text is an error
+	resultJSON                     // a JSON object or array is the result, any other text is an error
+	resultList                     // "|" separated results of RECRUITALL ("OK" or error text per entry)
+	resultText                     // the text is the result, unless it is an error ("err: ...")
+	resultParsed                   // the text is converted into a JSON object by rpcMethod.parse, any text it rejects is an error
+)
+
+// rpcMethod describes a method of the JSON protocol: the command it is translated to,
+// the names of its positional parameters and the kind of its result.
+type rpcMethod struct {
+	command string
+	params  []string
+	result  resultKind
+	parse   func(resp string) (any, error) // converts the text response (only resultParsed)
+}
+
+// rpcMethods defines the methods of the JSON protocol (see FeatureJSON). Every method is the lower case
+// name of a text command and is executed by the same code, so both protocols behave identically.
+// Optional parameters are at the end and may be omitted.
+var rpcMethods = map[string]rpcMethod{
+	"player":      {command: "PLAYER", params: []string{"name", "r", "g", "b"}},
+	"status":      {command: "STATUS", result: resultJSON},
+	"country":     {command: "COUNTRY", params: []string{"name"}, result: resultJSON},
+	"ready":       {command: "READY"},
+	"myturn":      {command: "MYTURN", result: resultParsed, parse: rpcMyTurnResult},
+	"turn":        {command: "TURN", result: resultJSON},
+	"rules":       {command: "RULES", result: resultJSON},
+	"pool":        {command: "POOL", result: resultParsed, parse: rpcPoolResult},
+	"prefer":      {command: "PREFER", params: []string{"continent"}},
+	"end":         {command: "END", params: []string{"token"}},
+	"move":        {command: "MOVE", params: []string{"attacker", "defender", "strength", "token"}},
+	"attack":      {command: "ATTACK", params: []string{"attacker", "defender", "strength", "rounds", "token"}},
+	"truce":       {command: "TRUCE", params: []string{"player", "rounds"}},
+	"scout":       {command: "SCOUT", params: []string{"country"}},
+	"capital":     {command: "CAPITAL", params: []string{"country"}},
+	"resign":      {command: "RESIGN"},
+	"stats":       {command: "STATS", params: []string{"name"}, result: resultJSON},
+	"players":     {command: "PLAYERS", result: resultParsed, parse: rpcPlayersResult},
+	"thumbnail":   {command: "THUMBNAIL", result: resultText},
+	"lastbattles": {command: "LASTBATTLES", params: []string{"count"}, result: resultJSON},
+	"recruitall":  {command: "RECRUITALL", result: resultList}, // params: {"orders": [{"country": ..., "strength": ...}]}
+	"admin":       {command: "ADMIN", params: []string{"token"}},
+	"pause":       {command: "PAUSE"},
+	"resume":      {command: "RESUME"},
+	"audit":       {command: "AUDIT", params: []string{"count"}, result: resultJSON},
+	"owner":       {command: "OWNER", params: []string{"country", "player", "strength"}},
+	"start":       {command: "START"},
+	"kick":        {command: "KICK", params: []string{"player"}},
+	"maxplayers":  {command: "MAXPLAYERS", params: []string{"seats"}},
+}
+
+// rpcRequest is a request of the JSON protocol, e.g. {"id":1,"method":"move","params":{"attacker":"Alaska",...}}.
+type rpcRequest struct {
+	ID     json.RawMessage            `json:"id,omitempty"` // Any JSON value; it is sent back with the response.
+	Method string                     `json:"method"`
+	Params map[string]json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcResponse is a response of the JSON protocol. It contains either the result or the error text.
+type rpcResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Result any             `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// decodeRPC translates a request of the JSON protocol into a line of the text protocol.
+// The line is then parsed and executed like any other command (see parseCommand).
+//
+// Returns:
+//   - The text command line.
+//   - The method and the ID of the request, so the response can be converted (see rpcConn).
+//
+// Error cases:
+//   - The line is not a JSON object ("err: invalid json request").
+//   - The method is unknown ("err: invalid method").
+//   - A parameter is neither a string nor an integer, or contains the separator "|" ("err: invalid parameter {name}").
+func decodeRPC(line string) (string, rpcMethod, json.RawMessage, error) {
+	var req rpcRequest
+	if err := json.Unmarshal([]byte(line), &req); err != nil {
+		return "", rpcMethod{}, nil, errors.New("err: invalid json request") // ERROR EXIT
+	}
+	method, ok := rpcMethods[req.Method]
+	if !ok {
+		return "", rpcMethod{}, req.ID, errors.New("err: invalid method") // ERROR EXIT
+	}
+
+	args := []string{method.command}
+	if method.result == resultList {
+		var orders []core.Recruitment
+		if err := json.Unmarshal(req.Params["orders"], &orders); err != nil {
+			return "", method, req.ID, errors.New("err: invalid parameter orders") // ERROR EXIT
+		}
+		for _, o := range orders {
+			if strings.Contains(o.Country, "|") {
+				return "", method, req.ID, errors.New("err: invalid parameter orders") // ERROR EXIT
+			}
+			args = append(args, fmt.Sprintf("%s:%d", o.Country, o.Strength))
+		}
+	}
+	for _, name := range method.params {
+		raw, ok := req.Params[name]
+		if !ok {
+			break // the remaining parameters are omitted
+		}
+		value, err := rpcParam(raw)
+		if err != nil {
+			return "", method, req.ID, fmt.Errorf("err: invalid parameter %s", name) // ERROR EXIT
+		}
+		args = append(args, value)
+	}
+	return strings.Join(args, "|"), method, req.ID, nil // SUCCESS EXIT
+}
+
+// rpcParam returns the text of a parameter, which must be a string or an integer.
+func rpcParam(raw json.RawMessage) (string, error) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if strings.ContainsAny(s, "|\r\n") {
+			return "", errors.New("invalid character")
+		}
+		return s, nil
+	}
+	var n int
+	if err := json.Unmarshal(raw, &n); err != nil {
+		return "", err
+	}
+	return fmt.Sprint(n), nil
+}
+
+// encodeRPC converts the text response of a command into a response of the JSON protocol.
+func encodeRPC(id json.RawMessage, method rpcMethod, resp string) []byte {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	r := rpcResponse{ID: id}
+	switch {
+	case method.result == resultJSON && json.Valid([]byte(resp)) && (strings.HasPrefix(resp, "{") || strings.HasPrefix(resp, "[")):
+		r.Result = json.RawMessage(resp)
+	case method.result == resultList && !strings.HasPrefix(resp, "err: "):
+		r.Result = strings.Split(resp, "|")
+	case method.result == resultText && !strings.HasPrefix(resp, "err: "):
+		r.Result = resp
+	case method.result == resultParsed:
+		if v, err := method.parse(resp); err == nil {
+			r.Result = v
+		} else {
+			r.Error = resp
+		}
+	case resp == "OK" && method.result == resultOK:
+		r.Result = resp
+	default:
+		r.Error = resp
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		b, _ = json.Marshal(rpcResponse{ID: json.RawMessage("null"), Error: err.Error()})
+	}
+	return b
+}
+
+// rpcMyTurn is the result of the myturn method, e.g. {"myTurn":true,"timeBank":7500}.
+type rpcMyTurn struct {
+	MyTurn   bool  `json:"myTurn"`
+	TimeBank int64 `json:"timeBank,omitempty"` // The remaining time bank in milliseconds (only with a match clock).
+}
+
+// rpcPlayers is the result of the players method, e.g. {"players":2,"seats":4}.
+type rpcPlayers struct {
+	Players int `json:"players"`
+	Seats   int `json:"seats"`
+}
+
+// rpcPool is the result of the pool method, e.g. {"total":5,"continents":{"Asia":3,"Europe":2}}.
+type rpcPool struct {
+	Total      int            `json:"total"`
+	Continents map[string]int `json:"continents,omitempty"` // Only with local reinforcements.
+}
+
+// rpcMyTurnResult converts the response of MYTURN (see parseMyTurn).
+func rpcMyTurnResult(resp string) (any, error) {
+	active, left, err := parseMyTurn(resp)
+	return rpcMyTurn{MyTurn: active, TimeBank: left.Milliseconds()}, err
+}
+
+// rpcPlayersResult converts the response of PLAYERS (see parsePlayers).
+func rpcPlayersResult(resp string) (any, error) {
+	players, seats, err := parsePlayers(resp)
+	return rpcPlayers{Players: players, Seats: seats}, err
+}
+
+// rpcPoolResult converts the response of POOL (see parsePool). The errors of the world (e.g. "player not found")
+// have no "err: " prefix, so the numeric total decides.
+func rpcPoolResult(resp string) (any, error) {
+	total, continents, err := parsePool(resp)
+	return rpcPool{Total: total, Continents: continents}, err
+}
+
+// rpcConn is a connection that converts the text responses of the server into responses of the JSON protocol
+// once the connection has negotiated FeatureJSON. Every response is a single JSON object in one line.
+type rpcConn struct {
+	net.Conn
+	active bool            // FeatureJSON has been negotiated
+	id     json.RawMessage // the ID of the current request
+	method rpcMethod       // the method of the current request
+}
+
+// Write sends a response to the client, converted into JSON if the JSON protocol is active.
+func (c *rpcConn) Write(b []byte) (int, error) {
+	if !c.active {
+		return c.Conn.Write(b)
+	}
+	resp := encodeRPC(c.id, c.method, string(bytes.TrimRight(b, "\r\n")))
+	if _, err := c.Conn.Write(append(resp, '\r', '\n')); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
@@ -0,0 +1,137 @@
+package remote
+
+import (
+	"RISK-CodeConflict/core"
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+)
+
+func Test_decodeRPC(t *testing.T) {
+	tests := []struct {
+		line    string
+		want    string
+		wantErr string
+	}{
+		{line: `{"method":"status"}`, want: "STATUS"},
+		{line: `{"id":1,"method":"move","params":{"attacker":"Alaska","defender":"Alberta","strength":3}}`, want: "MOVE|Alaska|Alberta|3"},
+		{line: `{"id":"a","method":"move","params":{"attacker":"Alaska","defender":"Alberta","strength":3,"token":"t1"}}`, want: "MOVE|Alaska|Alberta|3|t1"},
+		{line: `{"method":"player","params":{"name":"Bob","r":1,"g":2,"b":3}}`, want: "PLAYER|Bob|1|2|3"},
+		{line: `{"method":"end","params":{"r":1}}`, want: "END"},
+		{line: `{"method":"recruitall","params":{"orders":[{"country":"Alaska","strength":2},{"country":"Peru","strength":1}]}}`, want: "RECRUITALL|Alaska:2|Peru:1"},
+		{line: `STATUS`, wantErr: "err: invalid json request"},
+		{line: `{"method":"hello"}`, wantErr: "err: invalid method"},
+		{line: `{"method":"country","params":{"name":"A|B"}}`, wantErr: "err: invalid parameter name"},
+		{line: `{"method":"move","params":{"attacker":"Alaska","defender":"Alberta","strength":1.5}}`, wantErr: "err: invalid parameter strength"},
+		{line: `{"method":"recruitall","params":{"orders":"Alaska"}}`, wantErr: "err: invalid parameter orders"},
+	}
+	for _, tt := range tests {
+		line, _, _, err := decodeRPC(tt.line)
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("%s: got %v, want %q", tt.line, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || line != tt.want {
+			t.Fatalf("%s: got %q (%v), want %q", tt.line, line, err, tt.want)
+		}
+	}
+}
+
+func Test_encodeRPC(t *testing.T) {
+	tests := []struct {
+		id     string
+		method string
+		resp   string
+		want   string
+	}{
+		{id: "1", method: "end", resp: "OK", want: `{"id":1,"result":"OK"}`},
+		{method: "end", resp: "cannot end enemy turn", want: `{"id":null,"error":"cannot end enemy turn"}`},
+		{id: `"x"`, method: "country", resp: `{"Name":"Alaska"}`, want: `{"id":"x","result":{"Name":"Alaska"}}`},
+		{id: "2", method: "country", resp: "country not found", want: `{"id":2,"error":"country not found"}`},
+		{id: "3", method: "recruitall", resp: "OK|world is frozen", want: `{"id":3,"result":["OK","world is frozen"]}`},
+		{id: "4", method: "recruitall", resp: "err: malformed RECRUITALL command", want: `{"id":4,"error":"err: malformed RECRUITALL command"}`},
+		{id: "5", method: "pool", resp: "7|Asia:2", want: `{"id":5,"result":{"total":7,"continents":{"Asia":2}}}`},
+		{id: "8", method: "pool", resp: "7", want: `{"id":8,"result":{"total":7}}`},
+		{id: "9", method: "myturn", resp: "YES|7500", want: `{"id":9,"result":{"myTurn":true,"timeBank":7500}}`},
+		{id: "10", method: "myturn", resp: "NO", want: `{"id":10,"result":{"myTurn":false}}`},
+		{id: "11", method: "myturn", resp: "err: no player", want: `{"id":11,"error":"err: no player"}`},
+		{id: "12", method: "players", resp: "2|4", want: `{"id":12,"result":{"players":2,"seats":4}}`},
+		{id: "6", method: "pool", resp: "player not found", want: `{"id":6,"error":"player not found"}`},
+		{id: "7", method: "pool", resp: "err: no player", want: `{"id":7,"error":"err: no player"}`},
+	}
+	for _, tt := range tests {
+		var id json.RawMessage
+		if tt.id != "" {
+			id = json.RawMessage(tt.id)
+		}
+		if got := string(encodeRPC(id, rpcMethods[tt.method], tt.resp)); got != tt.want {
+			t.Fatalf("got %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestServer_json(t *testing.T) {
+	server := NewServer("127.0.0.1", "0", core.NewWorld(), 4)
+
+	conn, serverConn := net.Pipe()
+	defer func() { _ = conn.Close() }()
+	go server.handleRequest(serverConn)
+	tp := textproto.NewReader(bufio.NewReader(conn))
+
+	send := func(line string) string {
+		t.Helper()
+		if _, err := conn.Write([]byte(line + "\n")); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := tp.ReadLine()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	// the handshake is answered in the text protocol; json excludes the other features
+	if resp := send("HELLO|gzip|json|frame"); resp != "OK|json" {
+		t.Fatal(resp)
+	}
+
+	tests := []struct {
+		line string
+		want string
+	}{
+		{line: `{"id":1,"method":"player","params":{"name":"Player1"}}`, want: `{"id":1,"result":"OK"}`},
+		{line: `{"id":2,"method":"player","params":{"name":"Player2"}}`, want: `{"id":2,"error":"err: player already created"}`},
+		{line: `{"id":3,"method":"country","params":{"name":"Atlantis"}}`, want: `{"id":3,"error":"country not found"}`},
+		{line: `{"id":4,"method":"move"}`, want: `{"id":4,"error":"err: malformed MOVE command"}`},
+		{line: `{"id":5,"method":"unknown"}`, want: `{"id":5,"error":"err: invalid method"}`},
+		{line: `STATUS`, want: `{"id":null,"error":"err: invalid json request"}`},
+	}
+	for _, tt := range tests {
+		if resp := send(tt.line); resp != tt.want {
+			t.Fatalf("%s: got %s, want %s", tt.line, resp, tt.want)
+		}
+	}
+
+	// structured results
+	var status struct {
+		ID     int
+		Result core.World
+	}
+	if err := json.Unmarshal([]byte(send(`{"id":6,"method":"status"}`)), &status); err != nil || status.ID != 6 || len(status.Result.Countries) != 42 {
+		t.Fatal(err, status.ID)
+	}
+	var country struct {
+		Result core.Country
+	}
+	if err := json.Unmarshal([]byte(send(`{"method":"country","params":{"name":"Alaska"}}`)), &country); err != nil || country.Result.Name != "Alaska" {
+		t.Fatal(err, country)
+	}
+	if resp := send(`{"id":7,"method":"recruitall","params":{"orders":[{"country":"Alaska","strength":1}]}}`); !strings.HasPrefix(resp, `{"id":7,"result":[`) {
+		t.Fatal(resp)
+	}
+}
@@ -0,0 +1,56 @@
+package remote
+
+import (
+	"bufio"
+	"errors"
+	"strings"
+)
+
+// Default limits of a protocol line, so a peer cannot exhaust the memory with an endless line.
+const (
+	DefaultMaxLineLength     = 64 << 10 // The default maximum length of a command line (see Server.MaxLineLength).
+	DefaultMaxResponseLength = 64 << 20 // The default maximum length of a response (see Client.MaxResponseLength).
+)
+
+// errLineTooLong is returned by readLine if a line exceeds the limit.
+var errLineTooLong = errors.New("line too long")
+
+// readLine reads a protocol line and removes the line break ("\n" or "\r\n").
+// A line longer than limit bytes is read to its end and discarded, so the next call starts
+// with the next line and the connection stays usable; readLine then returns errLineTooLong.
+//
+// Parameters:
+//   - r: The buffered reader of the connection.
+//   - limit: The maximum length of a line without the line break (0 = unlimited).
+//
+// Returns:
+//   - The line without the line break.
+//   - errLineTooLong or the read error of the connection (e.g. io.EOF).
+func readLine(r *bufio.Reader, limit int) (string, error) {
+	var sb strings.Builder
+	tooLong := false
+	for {
+		chunk, err := r.ReadSlice('\n')
+		if !tooLong {
+			if limit > 0 && sb.Len()+len(chunk) > limit+2 { // +2: line break
+				tooLong = true
+				sb.Reset()
+			} else {
+				sb.Write(chunk)
+			}
+		}
+		if errors.Is(err, bufio.ErrBufferFull) {
+			continue // the line continues
+		}
+		if err != nil {
+			return "", err // ERROR EXIT
+		}
+		break
+	}
+
+	line := strings.TrimSuffix(strings.TrimSuffix(sb.String(), "\n"), "\r")
+	if tooLong || (limit > 0 && len(line) > limit) {
+		return "", errLineTooLong // ERROR EXIT
+	}
+	return line, nil // SUCCESS EXIT
+}
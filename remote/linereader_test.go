@@ -0,0 +1,40 @@
+package remote
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_readLine(t *testing.T) {
+	long := strings.Repeat("x", 100)
+	input := "STATUS\r\n" + long + "\n" + "END\n" + long[:20] + "\r\n" + "last"
+
+	// a small buffer, so long lines are read in several chunks
+	r := bufio.NewReaderSize(strings.NewReader(input), 16)
+
+	tests := []struct {
+		line string
+		err  error
+	}{
+		{"STATUS", nil},
+		{"", errLineTooLong},
+		{"END", nil},
+		{long[:20], nil},
+		{"", io.EOF},
+	}
+	for i, tt := range tests {
+		line, err := readLine(r, 20)
+		if line != tt.line || !errors.Is(err, tt.err) {
+			t.Fatalf("%d: got %q (%v), want %q (%v)", i, line, err, tt.line, tt.err)
+		}
+	}
+
+	// unlimited
+	r = bufio.NewReaderSize(strings.NewReader(long+"\n"), 16)
+	if line, err := readLine(r, 0); line != long || err != nil {
+		t.Fatal(line, err)
+	}
+}
@@ -0,0 +1,224 @@
+package remote
+
+import (
+	"RISK-CodeConflict/core"
+	"errors"
+	"image/color"
+	"sync"
+	"time"
+)
+
+// LocalClient is an in-process client that talks directly to a core.World without any network connection.
+// It offers the same methods as the TCP Client, so AIs running in the same process as the server can use it
+// to skip the socket and protocol overhead. The same game rules apply (turn order, freeze, game start).
+type LocalClient struct {
+	world          *core.World // The shared game world
+	maxPlayerCount int         // The number of players required before the game starts
+	player         string      // The name of the player controlled by this client
+	mux            *sync.Mutex // Mutex for thread-safe operations
+}
+
+// NewLocalClient creates a new in-process client for the given world.
+// The game starts as soon as maxPlayerCount players have joined (over any transport).
+func NewLocalClient(world *core.World, maxPlayerCount int) *LocalClient {
+	return &LocalClient{
+		world:          world,
+		maxPlayerCount: maxPlayerCount,
+		mux:            new(sync.Mutex),
+	}
+}
+
+// AttachLocalClient creates an in-process client that controls a player who is already in the game,
+// e.g. to let an AI take over the seat of a human (see Server.Substitute). AddPlayer is not needed and fails.
+//
+// Returns:
+//   - The client of the player.
+//   - An error if the player is not in the game.
+func AttachLocalClient(world *core.World, player string) (*LocalClient, error) {
+	if !world.HasPlayer(player) {
+		return nil, errors.New("err: player not found")
+	}
+	c := NewLocalClient(world, 0) // the game has already started
+	c.player = player
+	return c, nil
+}
+
+// AddPlayer registers the player with the given name in the world.
+// If clr is the zero value (color.RGBA{}), the world derives a color from the name.
+func (c *LocalClient) AddPlayer(name string, clr color.RGBA) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if len(c.player) > 0 {
+		return errors.New("err: player already created")
+	}
+
+	name, err := joinGame(c.world, c.maxPlayerCount, name, clr)
+	if err == nil {
+		c.player = name
+	}
+	return err
+}
+
+// Status copies the current world state into the provided World instance.
+func (c *LocalClient) Status(update *core.World) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if update == nil {
+		return errors.New("world is nil")
+	}
+	return update.FromViewJson(c.world.JsonFor(c.player))
+}
+
+// Country returns a copy of the current state of a single country.
+func (c *LocalClient) Country(name string) (*core.Country, error) {
+	js, err := c.world.CountryJsonFor(name, c.player)
+	if err != nil {
+		return nil, err
+	}
+	return parseCountry(js)
+}
+
+// Ready confirms in the lobby that the player is ready to start (see readyGame).
+func (c *LocalClient) Ready() error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if err := c.checkPlayer(); err != nil {
+		return err
+	}
+	return readyGame(c.world, c.player)
+}
+
+// MyTurn reports whether it is the turn of the player (see core.World.IsTurn).
+func (c *LocalClient) MyTurn() (bool, time.Duration, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if err := c.checkPlayer(); err != nil {
+		return false, 0, err
+	}
+	active, left := c.world.IsTurn(c.player)
+	return active, left, nil
+}
+
+// Pool returns the reinforcement the player can deploy and the pools per continent (see core.World.ReinforcementPool).
+func (c *LocalClient) Pool() (int, map[string]int, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if err := c.checkPlayer(); err != nil {
+		return 0, nil, err
+	}
+	return c.world.ReinforcementPool(c.player)
+}
+
+// PreferContinent sets the preferred start continent in the lobby (see core.World.SetStartContinent).
+func (c *LocalClient) PreferContinent(continent string) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if err := c.checkPlayer(); err != nil {
+		return err
+	}
+	return c.world.SetStartContinent(c.player, continent)
+}
+
+// EndTurn signals that the player has finished their turn.
+func (c *LocalClient) EndTurn() error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if err := c.checkPlayer(); err != nil {
+		return err
+	}
+	_, err := c.world.EndTurn(c.player)
+	return err
+}
+
+// AttackOrMove attacks or moves from one country to another with a specified strength.
+func (c *LocalClient) AttackOrMove(attacker, defender string, strength int) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if err := c.checkPlayer(); err != nil {
+		return err
+	}
+	return c.world.AttackOrMove(attacker, defender, strength, c.player)
+}
+
+// AttackRounds attacks with a limit of dice rounds (see core.World.AttackOrMoveRounds).
+func (c *LocalClient) AttackRounds(attacker, defender string, strength, rounds int) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if err := c.checkPlayer(); err != nil {
+		return err
+	}
+	return c.world.AttackOrMoveRounds(attacker, defender, strength, rounds, c.player)
+}
+
+// Reinforcement reinforces a country with additional strength.
+func (c *LocalClient) Reinforcement(country string, strength int) error {
+	return c.AttackOrMove(country, country, strength)
+}
+
+// ReinforceAll deploys reinforcements in several countries at once and returns one result per entry.
+func (c *LocalClient) ReinforceAll(orders []core.Recruitment) ([]error, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if err := c.checkPlayer(); err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return nil, errors.New("no orders")
+	}
+	return c.world.ReinforceAll(c.player, orders), nil
+}
+
+// Truce offers a truce to another player for the given number of rounds, or accepts the truce offered by that player.
+func (c *LocalClient) Truce(other string, rounds int) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if err := c.checkPlayer(); err != nil {
+		return err
+	}
+	return c.world.RequestTruce(c.player, other, rounds)
+}
+
+// Scout spends one unit of an own neighbor to reveal the strength of a country hidden by the fog of war.
+func (c *LocalClient) Scout(country string) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if err := c.checkPlayer(); err != nil {
+		return err
+	}
+	return c.world.Scout(country, c.player)
+}
+
+// SetCapital moves the capital of the player in the first round (see core.World.SetCapital).
+func (c *LocalClient) SetCapital(country string) error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if err := c.checkPlayer(); err != nil {
+		return err
+	}
+	return c.world.SetCapital(c.player, country)
+}
+
+//---------------- HELPER --------------------------------------------------------------------------------------------//
+
+// checkPlayer returns an error if no player was added yet.
+// The world rejects the empty player as well (see core.World.AdminAttackOrMove); the check keeps the error text
+// of the server.
+func (c *LocalClient) checkPlayer() error {
+	if len(c.player) == 0 {
+		return errors.New("err: no player")
+	}
+	return nil
+}
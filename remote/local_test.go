@@ -0,0 +1,134 @@
+package remote
+
+import (
+	"RISK-CodeConflict/core"
+	"image/color"
+	"testing"
+)
+
+func TestLocalClient(t *testing.T) {
+	world := core.NewWorld()
+	world.Freeze = true
+
+	client := NewLocalClient(world, 2)
+	client2 := NewLocalClient(world, 2)
+
+	// no player
+	if err := client.EndTurn(); err == nil || err.Error() != "err: no player" {
+		t.Fatal(err)
+	}
+	if _, _, err := client.MyTurn(); err == nil || err.Error() != "err: no player" {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Pool(); err == nil || err.Error() != "err: no player" {
+		t.Fatal(err)
+	}
+
+	// add player
+	if err := client.AddPlayer("  Player1  ", color.RGBA{R: 255, A: 255}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddPlayer("Player1", color.RGBA{R: 255, A: 255}); err == nil || err.Error() != "err: player already created" {
+		t.Fatal(err)
+	}
+	if !world.Freeze {
+		t.Fatal("game should not be started")
+	}
+	if err := client2.AddPlayer("Player2", color.RGBA{}); err != nil {
+		t.Fatal(err)
+	}
+	if world.Freeze {
+		t.Fatal("game should be started")
+	}
+	if err := NewLocalClient(world, 2).AddPlayer("Player3", color.RGBA{}); err == nil || err.Error() != "err: game is full" {
+		t.Fatal(err)
+	}
+	if world.Phase != core.PhasePlaying {
+		t.Fatal(world.Phase)
+	}
+	if err := NewLocalClient(world, 3).AddPlayer("Player3", color.RGBA{}); err == nil || err.Error() != "err: game already started" {
+		t.Fatal(err)
+	}
+
+	// status
+	status := new(core.World)
+	if err := client.Status(status); err != nil || len(status.PlayerQueue) != 2 {
+		t.Fatal(err)
+	}
+
+	// my turn
+	turn1, _, err1 := client.MyTurn()
+	turn2, _, err2 := client2.MyTurn()
+	if err1 != nil || err2 != nil || turn1 == turn2 || turn1 != (status.PlayerQueue[0].Name == "Player1") {
+		t.Fatal(turn1, turn2, err1, err2)
+	}
+
+	// reinforcement pool of both players
+	for _, c := range []*LocalClient{client, client2} {
+		total, continents, err := c.Pool()
+		if err != nil || total != world.Player(c.player).Reinforcement || continents != nil {
+			t.Fatal(total, continents, err)
+		}
+	}
+
+	// single country
+	if c, err := client.Country("Alaska"); err != nil || c.Name != "Alaska" || c.Occupier == nil {
+		t.Fatal(c, err)
+	}
+	if _, err := client.Country("Atlantis"); err == nil || err.Error() != "country not found" {
+		t.Fatal(err)
+	}
+
+	// bulk reinforcement (the game has started, but it is not the turn of both clients)
+	if _, err := NewLocalClient(world, 2).ReinforceAll([]core.Recruitment{{Country: "Alaska", Strength: 1}}); err == nil || err.Error() != "err: no player" {
+		t.Fatal(err)
+	}
+
+	// scouting needs fog of war
+	if err := client.Scout("Alaska"); err == nil || err.Error() != "fog of war disabled" {
+		t.Fatal(err)
+	}
+
+	// capitals are disabled by default
+	if err := client.SetCapital("Alaska"); err == nil || err.Error() != "capitals disabled" {
+		t.Fatal(err)
+	}
+
+	// round limits need stepwise attacks
+	if err := client.AttackRounds("Alaska", "Alberta", 1, 1); err == nil {
+		t.Fatal("stepwise attacks are disabled")
+	}
+
+	// play a turn
+	active, other := client, client2
+	if world.PlayerQueue[0].Name != "Player1" {
+		active, other = client2, client
+	}
+	if err := other.EndTurn(); err == nil || err.Error() != "cannot end enemy turn" {
+		t.Fatal(err)
+	}
+	if err := active.EndTurn(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAttachLocalClient(t *testing.T) {
+	world := core.NewWorld()
+	_ = world.AddPlayer("Player1", color.RGBA{})
+	_ = world.AddPlayer("Player2", color.RGBA{})
+	world.InitPopulation()
+
+	if _, err := AttachLocalClient(world, "Nobody"); err == nil || err.Error() != "err: player not found" {
+		t.Fatal(err)
+	}
+	client, err := AttachLocalClient(world, "Player1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if turn, _, err := client.MyTurn(); err != nil || turn != (world.PlayerQueue[0].Name == "Player1") {
+		t.Fatal(turn, err)
+	}
+	if err := client.AddPlayer("Player3", color.RGBA{}); err == nil {
+		t.Fatal("the player is already set")
+	}
+}
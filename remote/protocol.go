@@ -0,0 +1,270 @@
+package remote
+
+import (
+	"RISK-CodeConflict/core"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Optional protocol features, negotiated with the HELLO command (see Client.Hello).
+const (
+	FeatureGzip     = "gzip"     // STATUSGZ returns the world state compressed.
+	FeatureEnvelope = "envelope" // STATUS and STATUSGZ responses are wrapped in an envelope (see envelope).
+	FeatureFrame    = "frame"    // Data responses are sent length-prefixed and may span several lines (see frame).
+	FeatureJSON     = "json"     // Requests and responses are JSON objects instead of text lines (see rpcMethods).
+)
+
+// serverFeatures are the optional protocol features the server supports (see HELLO).
+var serverFeatures = []string{FeatureGzip, FeatureEnvelope, FeatureFrame, FeatureJSON}
+
+// argRule describes the valid arguments of a protocol command.
+type argRule struct {
+	counts  []int // allowed numbers of arguments (without the command keyword)
+	numeric []int // indices of arguments that must be integers (if present)
+	min     int   // minimum number of arguments for commands with a variable argument list (instead of counts)
+}
+
+// commandRules defines the argument rules for every command the server understands.
+var commandRules = map[string]argRule{
+	"PLAYER":      {counts: []int{1, 4}, numeric: []int{1, 2, 3}}, // PLAYER|name or PLAYER|name|r|g|b
+	"STATUS":      {counts: []int{0}},                             // STATUS
+	"STATUSGZ":    {counts: []int{0}},                             // STATUSGZ (after HELLO|gzip)
+	"HELLO":       {counts: []int{0}, min: 1},                     // HELLO or HELLO|feature|feature|...
+	"COUNTRY":     {counts: []int{1}},                             // COUNTRY|name
+	"READY":       {counts: []int{0}},                             // READY
+	"MYTURN":      {counts: []int{0}},                             // MYTURN
+	"TURN":        {counts: []int{0}},                             // TURN
+	"RULES":       {counts: []int{0}},                             // RULES
+	"POOL":        {counts: []int{0}},                             // POOL
+	"PREFER":      {counts: []int{0, 1}},                          // PREFER or PREFER|continent
+	"END":         {counts: []int{0, 1}},                          // END or END|token
+	"MOVE":        {counts: []int{3, 4}, numeric: []int{2}},       // MOVE|attacker|defender|strength or with |token
+	"ATTACK":      {counts: []int{4, 5}, numeric: []int{2, 3}},    // ATTACK|attacker|defender|strength|rounds or with |token
+	"TRUCE":       {counts: []int{2}, numeric: []int{1}},          // TRUCE|player|rounds
+	"SCOUT":       {counts: []int{1}},                             // SCOUT|country (fog of war)
+	"STATS":       {counts: []int{1}},                             // STATS|player
+	"PLAYERS":     {counts: []int{0}},                             // PLAYERS
+	"THUMBNAIL":   {counts: []int{0}},                             // THUMBNAIL
+	"LASTBATTLES": {counts: []int{0, 1}, numeric: []int{0}},       // LASTBATTLES or LASTBATTLES|count
+	"CAPITAL":     {counts: []int{1}},                             // CAPITAL|country
+	"RESIGN":      {counts: []int{0}},                             // RESIGN (hands the seat to a substitute)
+	"RECRUITALL":  {min: 1},                                       // RECRUITALL|country:amount|country:amount|...
+	"ADMIN":       {counts: []int{1}},                             // ADMIN|token
+	"PAUSE":       {counts: []int{0}},                             // PAUSE (admin)
+	"RESUME":      {counts: []int{0}},                             // RESUME (admin)
+	"AUDIT":       {counts: []int{0, 1}, numeric: []int{0}},       // AUDIT or AUDIT|count (admin)
+	"OWNER":       {counts: []int{3}, numeric: []int{2}},          // OWNER|country|player|strength (admin)
+	"START":       {counts: []int{0}},                             // START (admin)
+	"KICK":        {counts: []int{1}},                             // KICK|player (admin)
+	"MAXPLAYERS":  {counts: []int{1}, numeric: []int{0}},          // MAXPLAYERS|seats (admin)
+}
+
+// parseCommand splits a protocol line into the command keyword and its arguments
+// and validates them against commandRules.
+//
+// Parameters:
+//   - line: A single line received from the client, e.g. "MOVE|Alaska|Alberta|3".
+//
+// Returns:
+//   - com: The command keyword (e.g. "MOVE").
+//   - args: The arguments following the keyword (the keyword itself is not included).
+//   - err: An error if the line is not a valid command.
+//
+// Error cases:
+//   - Unknown command keyword ("err: invalid command").
+//   - Wrong number of arguments or an argument that should be a number is not ("err: malformed MOVE command").
+func parseCommand(line string) (com string, args []string, err error) {
+	// Trim any leading/trailing whitespace and split the command into arguments.
+	fields := strings.Split(strings.TrimSpace(line), "|")
+	com, args = fields[0], fields[1:]
+
+	// lookup the rules of the command
+	rule, ok := commandRules[com]
+	if !ok {
+		return com, nil, errors.New("err: invalid command") // ERROR EXIT
+	}
+	malformed := errors.New("err: malformed " + com + " command")
+
+	// check argument count
+	validCount := rule.min > 0 && len(args) >= rule.min
+	for _, c := range rule.counts {
+		if len(args) == c {
+			validCount = true
+			break
+		}
+	}
+	if !validCount {
+		return com, nil, malformed // ERROR EXIT
+	}
+
+	// check numeric arguments
+	for _, i := range rule.numeric {
+		if i < len(args) {
+			if _, e := strconv.Atoi(args[i]); e != nil {
+				return com, nil, malformed // ERROR EXIT
+			}
+		}
+	}
+
+	return com, args, nil // SUCCESS EXIT
+}
+
+// parseRecruitments converts the arguments of a RECRUITALL command ("country:amount") into recruitments.
+// The amount follows the last colon, so country names may contain colons.
+//
+// Error cases:
+//   - An argument without colon or with an amount that is not a number ("err: malformed RECRUITALL command").
+func parseRecruitments(args []string) ([]core.Recruitment, error) {
+	orders := make([]core.Recruitment, 0, len(args))
+	for _, arg := range args {
+		i := strings.LastIndex(arg, ":")
+		if i < 0 {
+			return nil, errors.New("err: malformed RECRUITALL command") // ERROR EXIT
+		}
+		strength, err := strconv.Atoi(arg[i+1:])
+		if err != nil {
+			return nil, errors.New("err: malformed RECRUITALL command") // ERROR EXIT
+		}
+		orders = append(orders, core.Recruitment{Country: arg[:i], Strength: strength})
+	}
+	return orders, nil // SUCCESS EXIT
+}
+
+// parseMyTurn converts the response of the MYTURN command ("YES" or "NO", optionally followed by
+// the remaining time bank in milliseconds, see Server.myTurn). Any other response is returned as error
+// (error text of the server).
+func parseMyTurn(resp string) (bool, time.Duration, error) {
+	parts := strings.Split(resp, "|")
+	if parts[0] != "YES" && parts[0] != "NO" {
+		return false, 0, errors.New(resp) // error text
+	}
+
+	var left time.Duration
+	if len(parts) > 1 {
+		ms, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return false, 0, fmt.Errorf("invalid response: %s", resp)
+		}
+		left = time.Duration(ms) * time.Millisecond
+	}
+	return parts[0] == "YES", left, nil
+}
+
+// parsePlayers converts the response of the PLAYERS command ("{players}|{seats}", see Server.players).
+func parsePlayers(resp string) (players, seats int, err error) {
+	parts := strings.Split(resp, "|")
+	if len(parts) != 2 {
+		return 0, 0, errors.New(resp) // error text
+	}
+	if players, err = strconv.Atoi(parts[0]); err != nil {
+		return 0, 0, fmt.Errorf("invalid response: %s", resp)
+	}
+	if seats, err = strconv.Atoi(parts[1]); err != nil {
+		return 0, 0, fmt.Errorf("invalid response: %s", resp)
+	}
+	return players, seats, nil
+}
+
+// poolText returns the response of the POOL command: the total reinforcement, followed by the pools
+// per continent ("continent:amount", sorted by name) if the world uses local reinforcements, e.g. "5|Asia:3|Europe:2".
+func poolText(total int, continents map[string]int) string {
+	parts := []string{strconv.Itoa(total)}
+	names := make([]string, 0, len(continents))
+	for name := range continents {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s:%d", name, continents[name]))
+	}
+	return strings.Join(parts, "|")
+}
+
+// parsePool reverses poolText. The map is nil if the response has no pools per continent.
+// A response that is not a number is returned as error (error text of the server).
+func parsePool(resp string) (int, map[string]int, error) {
+	parts := strings.Split(resp, "|")
+	total, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, nil, errors.New(resp) // error text
+	}
+	if len(parts) == 1 {
+		return total, nil, nil
+	}
+	orders, err := parseRecruitments(parts[1:])
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid response: %s", resp)
+	}
+	continents := make(map[string]int, len(orders))
+	for _, o := range orders {
+		continents[o.Country] = o.Strength
+	}
+	return total, continents, nil
+}
+
+// hello returns the response of the HELLO handshake: "OK" followed by the requested features
+// the server supports, e.g. "OK|gzip". Unknown features are ignored, so clients can ask for more than the server knows.
+// JSON responses are structured and cannot be compressed, framed or wrapped, so FeatureJSON excludes
+// FeatureGzip, FeatureEnvelope and FeatureFrame.
+func hello(requested []string) (response string, accepted []string) {
+	for _, f := range requested {
+		if slices.Contains(serverFeatures, f) && !slices.Contains(accepted, f) {
+			accepted = append(accepted, f)
+		}
+	}
+	if slices.Contains(accepted, FeatureJSON) {
+		accepted = slices.DeleteFunc(accepted, func(f string) bool { return f != FeatureJSON })
+	}
+	return strings.Join(append([]string{"OK"}, accepted...), "|"), accepted
+}
+
+// envelope wraps a successful STATUS response as "OK|{length}|{payload}" (see FeatureEnvelope).
+// Error responses are not wrapped, so the client can tell a world state from an error text
+// without guessing from the content, and a truncated response is detected by the length.
+func envelope(payload string) string {
+	return fmt.Sprintf("OK|%d|%s", len(payload), payload)
+}
+
+// unwrapEnvelope returns the payload of a response wrapped with envelope.
+//
+// Error cases:
+//   - The response is not wrapped (e.g. an error text, which is returned as error).
+//   - The length does not match the payload (truncated response).
+func unwrapEnvelope(resp string) (string, error) {
+	parts := strings.SplitN(resp, "|", 3)
+	if len(parts) != 3 || parts[0] != "OK" {
+		return "", errors.New(resp) // ERROR EXIT: error text
+	}
+	length, err := strconv.Atoi(parts[1])
+	if err != nil || length != len(parts[2]) {
+		return "", fmt.Errorf("invalid response: expected %s bytes, got %d", parts[1], len(parts[2])) // ERROR EXIT
+	}
+	return parts[2], nil // SUCCESS EXIT
+}
+
+// frame prefixes a successful data response (STATUS, STATUSGZ, COUNTRY, TURN, RULES, STATS, LASTBATTLES,
+// THUMBNAIL, AUDIT) with the header line "DATA|{length}" (see FeatureFrame). The payload follows the header as it is and is terminated by a line break,
+// which comResponse appends. Unlike a single protocol line, the payload may contain line breaks, because the
+// client reads exactly {length} bytes instead of reading up to the next line break (see Client.readData).
+// Error responses are not framed, so they always start with something other than "DATA|".
+func frame(payload string) string {
+	return fmt.Sprintf("DATA|%d\r\n%s", len(payload), payload)
+}
+
+// optArg returns the argument at index i or "" if it is not present.
+func optArg(args []string, i int) string {
+	if i < len(args) {
+		return args[i]
+	}
+	return ""
+}
+
+// atoi converts a validated numeric argument (see parseCommand) into an int.
+func atoi(s string) int {
+	i, _ := strconv.Atoi(s)
+	return i
+}
@@ -0,0 +1,134 @@
+package remote
+
+import (
+	"RISK-CodeConflict/core"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func Test_parseCommand(t *testing.T) {
+	tests := []struct {
+		line    string
+		com     string
+		args    []string
+		wantErr string
+	}{
+		// valid
+		{line: "PLAYER|Bob", com: "PLAYER", args: []string{"Bob"}},
+		{line: "PLAYER|Bob|255|0|0", com: "PLAYER", args: []string{"Bob", "255", "0", "0"}},
+		{line: "PLAYER|", com: "PLAYER", args: []string{""}}, // empty name is rejected by the world
+		{line: "  STATUS  ", com: "STATUS", args: []string{}},
+		{line: "END", com: "END", args: []string{}},
+		{line: "END|t1", com: "END", args: []string{"t1"}},
+		{line: "MOVE|Alaska|Alberta|3", com: "MOVE", args: []string{"Alaska", "Alberta", "3"}},
+		{line: "MOVE|Alaska|Alberta|3|t2", com: "MOVE", args: []string{"Alaska", "Alberta", "3", "t2"}},
+		{line: "TRUCE|Bob|2", com: "TRUCE", args: []string{"Bob", "2"}},
+		{line: "RECRUITALL|Alaska:3|Brazil:1", com: "RECRUITALL", args: []string{"Alaska:3", "Brazil:1"}},
+		// short
+		{line: "PLAYER", wantErr: "err: malformed PLAYER command"},
+		{line: "PLAYER|Bob|255", wantErr: "err: malformed PLAYER command"},
+		{line: "MOVE", wantErr: "err: malformed MOVE command"},
+		{line: "MOVE||", wantErr: "err: malformed MOVE command"},
+		{line: "TRUCE|Bob", wantErr: "err: malformed TRUCE command"},
+		{line: "RECRUITALL", wantErr: "err: malformed RECRUITALL command"},
+		// long
+		{line: "PLAYER|Bob|1|2|3|4", wantErr: "err: malformed PLAYER command"},
+		{line: "STATUS|x", wantErr: "err: malformed STATUS command"},
+		{line: "END|t1|t2", wantErr: "err: malformed END command"},
+		{line: "MOVE|Alaska|Alberta|3|t2|x", wantErr: "err: malformed MOVE command"},
+		{line: "TRUCE|Bob|2|3", wantErr: "err: malformed TRUCE command"},
+		// garbled
+		{line: "", wantErr: "err: invalid command"},
+		{line: "|||", wantErr: "err: invalid command"},
+		{line: "move|Alaska|Alberta|3", wantErr: "err: invalid command"},
+		{line: "MOVE|Alaska|Alberta|three", wantErr: "err: malformed MOVE command"},
+		{line: "PLAYER|Bob|red|0|0", wantErr: "err: malformed PLAYER command"},
+		{line: "TRUCE|Bob|", wantErr: "err: malformed TRUCE command"},
+	}
+	for _, tt := range tests {
+		com, args, err := parseCommand(tt.line)
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("%q: got error %v, want %q", tt.line, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%q: %v", tt.line, err)
+		}
+		if com != tt.com || !slices.Equal(args, tt.args) {
+			t.Fatalf("%q: got %q %q, want %q %q", tt.line, com, args, tt.com, tt.args)
+		}
+	}
+}
+
+func Test_parseRecruitments(t *testing.T) {
+	orders, err := parseRecruitments([]string{"Alaska:3", "Middle East:1", "A:B:2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []core.Recruitment{{Country: "Alaska", Strength: 3}, {Country: "Middle East", Strength: 1}, {Country: "A:B", Strength: 2}}
+	if !slices.Equal(orders, want) {
+		t.Fatal(orders)
+	}
+
+	for _, args := range [][]string{{"Alaska"}, {"Alaska:"}, {"Alaska:x"}, {"Alaska:3", "Brazil"}} {
+		if _, err := parseRecruitments(args); err == nil || err.Error() != "err: malformed RECRUITALL command" {
+			t.Fatal(args, err)
+		}
+	}
+}
+
+func Test_poolText(t *testing.T) {
+	if s := poolText(5, nil); s != "5" {
+		t.Fatal(s)
+	}
+	text := poolText(5, map[string]int{"Europe": 2, "Asia": 3})
+	if text != "5|Asia:3|Europe:2" {
+		t.Fatal(text)
+	}
+
+	// round trip
+	total, continents, err := parsePool(text)
+	if err != nil || total != 5 || len(continents) != 2 || continents["Asia"] != 3 || continents["Europe"] != 2 {
+		t.Fatal(total, continents, err)
+	}
+	if _, continents, err := parsePool("5"); err != nil || continents != nil {
+		t.Fatal(continents, err)
+	}
+
+	// errors
+	if _, _, err := parsePool("err: no player"); err == nil || err.Error() != "err: no player" {
+		t.Fatal(err)
+	}
+	if _, _, err := parsePool("5|Asia"); err == nil || err.Error() != "invalid response: 5|Asia" {
+		t.Fatal(err)
+	}
+}
+
+func Test_envelope(t *testing.T) {
+	js := core.NewWorld().Json()
+	if s, err := unwrapEnvelope(envelope(js)); err != nil || s != js {
+		t.Fatal(err)
+	}
+	if s, err := unwrapEnvelope(envelope("")); err != nil || s != "" {
+		t.Fatal(s, err)
+	}
+
+	tests := []struct {
+		resp    string
+		wantErr string
+	}{
+		{resp: "err: world is frozen", wantErr: "err: world is frozen"},
+		{resp: "OK", wantErr: "OK"},
+		{resp: js, wantErr: js},
+		{resp: envelope(js)[:100], wantErr: fmt.Sprintf("invalid response: expected %d bytes, got %d", len(js), 100-len(fmt.Sprintf("OK|%d|", len(js))))},
+		{resp: "OK|x|{}", wantErr: "invalid response: expected x bytes, got 2"},
+	}
+	for _, tt := range tests {
+		if _, err := unwrapEnvelope(tt.resp); err == nil || err.Error() != tt.wantErr {
+			t.Fatalf("%.20q: got %v, want %.40q", tt.resp, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package remote
+
+// ring is a bounded list that keeps the newest items and discards the oldest ones once it is full
+// (see auditLog and battleLog). It is not thread-safe; the owner guards it with its own mutex.
+// The zero value is ready to use.
+type ring[T any] struct {
+	items []T // the newest items
+	next  int // the index of the next item once the ring is full
+}
+
+// add appends an item to the ring. The ring keeps at most size items (none if size < 1).
+func (r *ring[T]) add(e T, size int) {
+	if size <= 0 {
+		return
+	}
+	if len(r.items) < size {
+		r.items = append(r.items, e)
+		return
+	}
+	r.items[r.next] = e
+	r.next = (r.next + 1) % len(r.items)
+}
+
+// recent returns up to n of the newest items in chronological order (all items if n < 1).
+func (r *ring[T]) recent(n int) []T {
+	list := make([]T, 0, len(r.items))
+	list = append(list, r.items[r.next:]...)
+	list = append(list, r.items[:r.next]...)
+	if n > 0 && n < len(list) {
+		list = list[len(list)-n:]
+	}
+	return list
+}
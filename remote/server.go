@@ -3,32 +3,147 @@ package remote
 import (
 	"RISK-CodeConflict/core"
 	"bufio"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"image/color"
-	"log"
+	"log/slog"
 	"net"
-	"net/textproto"
-	"strconv"
+	"os"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 )
 
-// RunServer initializes and starts a TCP server that listens for incoming connections from clients.
-// The server manages commands received from clients and executes them on the given World object.
+// DefaultMaxConnections is the default limit of simultaneously open connections (see Server.MaxConnections).
+const DefaultMaxConnections = 64
+
+// Server is a TCP game server. It manages commands received from clients and executes them on a shared World.
+// Create it with NewServer, adjust the configuration fields if needed and start it with Run.
+type Server struct {
+	Host           string      // The IP address or hostname on which the server listens (e.g., "0.0.0.0").
+	Port           string      // The port on which the server listens for connections (e.g., "1234").
+	World          *core.World // The World object representing the game state, shared between all connected clients.
+	MaxPlayerCount int         // The number of players required before the game starts.
+
+	// MaxConnections limits the number of simultaneously open connections (players and spectators).
+	// Further connections receive "err: server full" and are closed immediately. 0 means unlimited.
+	MaxConnections int
+
+	// TimeBank enables a chess-clock-style match clock. Every player starts with this amount of thinking time,
+	// which ticks down during their turns (see core.Player.TimeBank). If it runs out, the server ends the turn.
+	// A player with an empty time bank only has TimeIncrement for each following turn. 0 disables the clock.
+	TimeBank time.Duration
+
+	// TimeIncrement is added to the time bank of a player at the end of each of their turns.
+	TimeIncrement time.Duration
+
+	// MaxLineLength limits the length of a received command line in bytes. Longer lines are discarded
+	// and answered with "err: line too long". 0 means unlimited (not recommended for public servers).
+	MaxLineLength int
+
+	// AdminToken enables the admin commands (PAUSE, RESUME). A connection becomes an admin connection
+	// by sending "ADMIN|{token}" with this token. An empty token disables the admin commands.
+	AdminToken string
+
+	// AuditSize is the number of received commands the server keeps in memory for the AUDIT admin command,
+	// including rejected commands and their timing. Older commands are discarded. 0 disables the in-memory log.
+	AuditSize int
+
+	// AuditFile is an optional file to which every received command is appended as a JSON line
+	// (see AuditEntry). Unlike the in-memory log, the file is not bounded. "" disables the file.
+	AuditFile string
+
+	// StatsFile is an optional JSON file with the cumulative statistics of all players across games
+	// (see StatsStore). Every finished game is added to it, and the STATS command queries it. "" disables the statistics.
+	StatsFile string
+
+	// BattleLogSize is the number of battles the server keeps with the dice of every round for the LASTBATTLES command
+	// (see core.BattleEvent), e.g. for spectators that replay the battles. Older battles are discarded.
+	// 0 disables the battle log.
+	BattleLogSize int
+
+	// Substitute takes over the seat of a player who resigns with the RESIGN command, e.g. by starting an AI
+	// in a new goroutine. The client already controls the player in the shared world (see AttachLocalClient),
+	// so the same turn and freeze rules apply. nil disables the RESIGN command.
+	Substitute func(client GameClient, player string)
+
+	// ThumbnailWidth and ThumbnailHeight are the size in pixels of the map preview sent by the THUMBNAIL command
+	// (see render.Thumbnail), e.g. for a server browser. 0 disables the command.
+	ThumbnailWidth, ThumbnailHeight int
+
+	// ThumbnailRefresh is the minimum time between two renderings of the map preview.
+	// Requests in between get the cached image. 0 renders the preview for every request.
+	ThumbnailRefresh time.Duration
+
+	// Clock is the source of time for the match clock, the audit log and the thumbnail cache.
+	// Tests can replace it to advance the time deterministically. nil uses the SystemClock.
+	Clock Clock
+
+	mux         sync.Mutex     // Mutex for the connection counter.
+	connections int            // The number of currently open connections.
+	tokens      tokenCache     // The responses of MOVE and END commands with an idempotency token.
+	clock       matchClock     // The state of the match clock (see TimeBank).
+	paused      bool           // The game was paused with the PAUSE command (guarded by the lobby lock of the world).
+	audit       auditLog       // The audit trail of all received commands (see AuditSize).
+	stats       *StatsStore    // The statistics of all players (see StatsFile), nil if disabled.
+	thumb       thumbnailCache // The last rendered map preview (see ThumbnailRefresh).
+	battles     battleLog      // The newest battles with their dice (see BattleLogSize).
+}
+
+// NewServer creates a new Server with the default configuration.
+//
+// Parameters:
+//   - host: The IP address or hostname on which the server should run (e.g., "0.0.0.0").
+//   - port: The port on which the server should listen for connections (e.g., "1234").
+//   - world: The World object representing the game state, shared between all connected clients.
+//   - maxPlayerCount: The number of players required before the game starts (initializes population and unfreezes the world).
+func NewServer(host, port string, world *core.World, maxPlayerCount int) *Server {
+	return &Server{
+		Host:           host,
+		Port:           port,
+		World:          world,
+		MaxPlayerCount: maxPlayerCount,
+		MaxConnections: DefaultMaxConnections,
+		MaxLineLength:  DefaultMaxLineLength,
+		AuditSize:      DefaultAuditSize,
+		BattleLogSize:  DefaultBattleLogSize,
+		Clock:          SystemClock{},
+
+		ThumbnailWidth:   DefaultThumbnailWidth,
+		ThumbnailHeight:  DefaultThumbnailHeight,
+		ThumbnailRefresh: DefaultThumbnailRefresh,
+	}
+}
+
+// RunServer initializes and starts a TCP server with the default configuration (see NewServer and Server.Run).
 // It remains BLOCKING until stopped manually.
 //
 // Parameters:
 //   - host: The IP address or hostname on which the server should run (e.g., "0.0.0.0").
 //   - port: The port on which the server should listen for connections (e.g., "1234").
 //   - world: The World object representing the game state, shared between all connected clients.
-//   - playerCount: The number of players required before the game starts (initializes population and unfreezes the world).
+//   - maxPlayerCount: The number of players required before the game starts (initializes population and unfreezes the world).
 func RunServer(host, port string, world *core.World, maxPlayerCount int) {
+	NewServer(host, port, world, maxPlayerCount).Run()
+}
+
+// Run starts the server and listens for incoming connections from clients.
+// Each connection is handled in a separate goroutine, as long as the connection limit is not reached.
+// It remains BLOCKING until stopped manually.
+func (s *Server) Run() {
 	// Freeze the world state at the start to prevent any modifications before the game starts.
-	world.Freeze = true
+	s.World.SetFreeze(true)
+
+	// Use the logger of the world for all server messages.
+	logger := s.World.Logger()
 
 	// Set up the server to listen for incoming connections on the specified host and port.
-	l, err := net.Listen("tcp", host+":"+port)
+	l, err := net.Listen("tcp", s.Host+":"+s.Port)
 	if err != nil {
-		log.Fatalf("Failed to start the server: %v\n", err)
+		logger.Error("failed to start the server", "err", err)
+		os.Exit(1)
 	}
 
 	// Ensure that the listener is closed when the server terminates.
@@ -36,25 +151,66 @@ func RunServer(host, port string, world *core.World, maxPlayerCount int) {
 		_ = l.Close()
 	}(l)
 
-	// Print the server start message to the console.
-	fmt.Printf("Server started on [%s:%s]\n", host, port)
+	// Log the server start message.
+	logger.Info("server started", "host", s.Host, "port", s.Port)
+
+	// Open the audit file.
+	if s.AuditFile != "" {
+		if err := s.openAuditFile(); err != nil {
+			logger.Error("failed to open the audit file", "err", err)
+			os.Exit(1)
+		}
+	}
+
+	// Open the player statistics and record every finished game.
+	if s.StatsFile != "" {
+		stats, err := OpenStatsStore(s.StatsFile)
+		if err != nil {
+			logger.Error("failed to open the stats file", "err", err)
+			os.Exit(1)
+		}
+		s.stats = stats
+		s.World.Subscribe(func(e core.Event) {
+			if _, ok := e.(core.GameOverEvent); ok {
+				s.recordStats()
+			}
+		})
+	}
+
+	// Keep the newest battles with their dice.
+	if s.BattleLogSize > 0 {
+		s.recordBattles()
+	}
+
+	// Start the match clock.
+	if s.TimeBank > 0 {
+		go s.runClock()
+	}
 
-	// Track the number of connected players.
-	count := 0
 	for {
 		// Wait for an incoming connection from a client.
 		conn, err := l.Accept()
 		if err != nil {
-			fmt.Printf("Error accepting connection: %v\n", err)
+			logger.Error("accepting connection", "err", err)
+			continue
+		}
+
+		// Reject the connection if the server is full.
+		if !s.acquireConnection() {
+			logger.Warn("server full, connection rejected", "addr", conn.RemoteAddr().String())
+			comResponse(logger, conn, "err: server full")
+			_ = conn.Close()
 			continue
 		}
 
 		// Handle each new connection in a separate goroutine.
-		go handleRequest(conn, world, maxPlayerCount)
-		count++
+		go func() {
+			defer s.releaseConnection()
+			s.handleRequest(conn)
+		}()
 
-		// Print information about the connected player.
-		fmt.Printf("Player connected from %v\n", conn.RemoteAddr())
+		// Log information about the connected player.
+		logger.Info("player connected", "addr", conn.RemoteAddr().String())
 	}
 }
 
@@ -63,14 +219,32 @@ func RunServer(host, port string, world *core.World, maxPlayerCount int) {
 //
 // Parameters:
 //   - conn: The network connection object representing the client connection.
-//   - w: The World object representing the game state.
-func handleRequest(conn net.Conn, w *core.World, maxPlayerCount int) {
+func (s *Server) handleRequest(conn net.Conn) {
+	w := s.World
+	maxPlayerCount := s.MaxPlayerCount
+
 	// Store the name of the player associated with this connection.
 	var player string
 
-	// Create a buffered reader to read client input line by line.
+	// Store whether the connection is authorized for admin commands (see AdminToken).
+	var admin bool
+
+	// Store the optional protocol features negotiated with HELLO.
+	var features []string
+
+	// Store whether the player has handed over their seat (RESIGN command).
+	var resigned bool
+
+	// Use the logger of the world for all connection messages.
+	logger := w.Logger()
+
+	// Remember the responses for the audit log and convert them into JSON if negotiated (see FeatureJSON).
+	ac := &auditConn{Conn: conn}
+	rc := &rpcConn{Conn: ac}
+	conn = rc
+
+	// Create a buffered reader to read client input line by line (see MaxLineLength).
 	reader := bufio.NewReader(conn)
-	tp := textproto.NewReader(reader)
 
 	// Ensure the connection is closed when the function exits.
 	defer func(conn net.Conn) {
@@ -80,18 +254,33 @@ func handleRequest(conn net.Conn, w *core.World, maxPlayerCount int) {
 	// Continuously listen for commands from the client.
 	for {
 		// Read a line of input from the client.
-		line, err := tp.ReadLine()
+		line, err := readLine(reader, s.MaxLineLength)
+		start := s.timeSource().Now()
+		rc.id, rc.method = nil, rpcMethod{}
+		if errors.Is(err, errLineTooLong) {
+			comResponse(logger, conn, "err: line too long")
+			s.record(ac, start, player, "")
+			continue
+		}
 		if err != nil {
 			break // Exit loop if an error occurs (e.g., client disconnect).
 		}
 
-		// Trim any leading/trailing whitespace and split the command into arguments.
-		args := strings.Split(strings.TrimSpace(line), "|")
+		// Translate a request of the JSON protocol into a command line.
+		if rc.active {
+			if line, rc.method, rc.id, err = decodeRPC(line); err != nil {
+				comResponseErr(logger, conn, err)
+				s.record(ac, start, player, "")
+				continue
+			}
+		}
 
-		// Extract the command keyword (the first element).
-		var com string
-		if len(args) > 0 {
-			com = args[0]
+		// Parse and validate the command keyword and its arguments.
+		com, args, err := parseCommand(line)
+		if err != nil {
+			comResponseErr(logger, conn, err)
+			s.record(ac, start, player, line)
+			continue
 		}
 
 		// Handle different commands based on the command keyword.
@@ -100,89 +289,379 @@ func handleRequest(conn net.Conn, w *core.World, maxPlayerCount int) {
 			// Create or validate a player for the connection.
 			if len(player) > 0 {
 				// If the player is already set, send an error response.
-				comResponse(conn, "err: player already created")
+				comResponse(logger, conn, "err: player already created")
 			} else {
 				// Extract player information from command arguments and create the player.
-				name, r, g, b := saveArgs(args)
-				ri, _ := strconv.Atoi(r)
-				gi, _ := strconv.Atoi(g)
-				bi, _ := strconv.Atoi(b)
-				col := color.RGBA{R: uint8(ri), G: uint8(gi), B: uint8(bi), A: 255}
-
-				// Try adding the player to the world.
-				e := w.AddPlayer(name, col)
+				name := args[0]
+				col := color.RGBA{} // no color: the world derives one from the name
+				if len(args) == 4 {
+					col = color.RGBA{R: uint8(atoi(args[1])), G: uint8(atoi(args[2])), B: uint8(atoi(args[3])), A: 255}
+				}
+
+				// Try adding the player to the world (starts the game if it is full).
+				name, e := joinGame(w, maxPlayerCount, name, col)
 				if e == nil {
 					player = name // Set player name for this connection if successful.
-					println("add player", name)
-				}
-				comResponseErr(conn, e)
-
-				// Check if the number of players matches the required count.
-				// If yes, initialize the world population and unfreeze the world to allow actions.
-				if len(w.PlayerQueue) == maxPlayerCount {
-					println("last player added")
-					w.InitPopulation()
-					w.Freeze = false
 				}
+				comResponseErr(logger, conn, e)
 			}
 		case "STATUS":
-			// Send the current world state as a JSON string.
-			comResponse(conn, w.Json())
+			// Send the current world state as a JSON string (in an envelope if negotiated).
+			// With fog of war, the player only sees what they are allowed to see (see core.World.JsonFor).
+			comResponse(logger, conn, wrapStatus(features, w.JsonFor(player)))
+		case "STATUSGZ":
+			// Send the current world state as compressed JSON string (see HELLO).
+			if !slices.Contains(features, FeatureGzip) {
+				comResponse(logger, conn, "err: gzip not negotiated")
+			} else if gz, e := compress(w.JsonFor(player)); e != nil {
+				comResponseErr(logger, conn, e)
+			} else {
+				comResponse(logger, conn, wrapStatus(features, gz))
+			}
+		case "HELLO":
+			// Negotiate optional protocol features and answer with the accepted ones.
+			var resp string
+			resp, features = hello(args)
+			comResponse(logger, conn, resp)
+			rc.active = slices.Contains(features, FeatureJSON)
+		case "COUNTRY":
+			// Send a single country as a JSON string.
+			if js, e := w.CountryJsonFor(args[0], player); e != nil {
+				comResponseErr(logger, conn, e)
+			} else {
+				comResponse(logger, conn, wrapData(features, js))
+			}
+		case "READY":
+			// Confirm in the lobby that the game can start.
+			if len(player) == 0 {
+				comResponse(logger, conn, "err: no player")
+			} else {
+				comResponseErr(logger, conn, readyGame(w, player))
+			}
+		case "MYTURN":
+			// Tell the player whether it is their turn (with the remaining time bank if the match clock is on).
+			if len(player) == 0 {
+				comResponse(logger, conn, "err: no player")
+			} else {
+				comResponse(logger, conn, s.myTurn(player))
+			}
+		case "TURN":
+			// Send the round, the active player and the order of the players as JSON object.
+			comResponse(logger, conn, wrapData(features, s.turnJson()))
+		case "RULES":
+			// Send the combat and reinforcement rules as JSON object.
+			comResponse(logger, conn, wrapData(features, s.rulesJson()))
+		case "POOL":
+			// Send the reinforcement of the player (with the pools per continent if the rule is enabled).
+			if len(player) == 0 {
+				comResponse(logger, conn, "err: no player")
+			} else if total, continents, e := w.ReinforcementPool(player); e != nil {
+				comResponseErr(logger, conn, e)
+			} else {
+				comResponse(logger, conn, poolText(total, continents))
+			}
+		case "PREFER":
+			// Set (or remove) the preferred start continent in the lobby.
+			if len(player) == 0 {
+				comResponse(logger, conn, "err: no player")
+			} else {
+				comResponseErr(logger, conn, w.SetStartContinent(player, optArg(args, 0)))
+			}
 		case "END":
-			// Handle the end of the turn for the player.
-			comResponseErr(conn, w.EndTurn(player))
+			// Handle the end of the turn for the player (END|token is answered only once).
+			if len(player) == 0 {
+				comResponse(logger, conn, "err: no player")
+			} else {
+				comResponse(logger, conn, s.tokens.idempotent(player, optArg(args, 0), true, func() string {
+					_, err := w.EndTurn(player)
+					return errText(err)
+				}))
+			}
 		case "MOVE":
 			// Handle troop movements or attacks.
-			attacker, defender, strength, _ := saveArgs(args)
-			strengthInt, _ := strconv.Atoi(strength)
-			comResponseErr(conn, w.AttackOrMove(attacker, defender, strengthInt, player))
+			// The optional token makes a repeated MOVE return the first response instead of executing it again.
+			if len(player) == 0 {
+				comResponse(logger, conn, "err: no player")
+			} else {
+				comResponse(logger, conn, s.tokens.idempotent(player, optArg(args, 3), false, func() string {
+					return errText(w.AttackOrMove(args[0], args[1], atoi(args[2]), player))
+				}))
+			}
+		case "ATTACK":
+			// Handle attacks with a round limit (see core.World.AttackOrMoveRounds), with an optional token like MOVE.
+			if len(player) == 0 {
+				comResponse(logger, conn, "err: no player")
+			} else {
+				comResponse(logger, conn, s.tokens.idempotent(player, optArg(args, 4), false, func() string {
+					return errText(w.AttackOrMoveRounds(args[0], args[1], atoi(args[2]), atoi(args[3]), player))
+				}))
+			}
+		case "RECRUITALL":
+			// Deploy reinforcements in several countries at once, with one result per entry.
+			if len(player) == 0 {
+				comResponse(logger, conn, "err: no player")
+			} else if orders, e := parseRecruitments(args); e != nil {
+				comResponseErr(logger, conn, e)
+			} else {
+				results := w.ReinforceAll(player, orders)
+				texts := make([]string, len(results))
+				for i, r := range results {
+					texts[i] = errText(r)
+				}
+				comResponse(logger, conn, strings.Join(texts, "|"))
+			}
+		case "TRUCE":
+			// Offer or accept a truce with another player.
+			if len(player) == 0 {
+				comResponse(logger, conn, "err: no player")
+			} else {
+				comResponseErr(logger, conn, w.RequestTruce(player, args[0], atoi(args[1])))
+			}
+		case "SCOUT":
+			// Spend one unit to reveal a fogged neighbor country (fog of war only).
+			if len(player) == 0 {
+				comResponse(logger, conn, "err: no player")
+			} else {
+				comResponseErr(logger, conn, w.Scout(args[0], player))
+			}
+		case "ADMIN":
+			// Authorize the connection for admin commands.
+			e := s.authorize(args[0])
+			admin = e == nil
+			comResponseErr(logger, conn, e)
+		case "PAUSE", "RESUME":
+			// Halt or continue the game (admin only).
+			if !admin {
+				comResponse(logger, conn, "err: not authorized")
+			} else if com == "PAUSE" {
+				comResponseErr(logger, conn, s.pause())
+			} else {
+				comResponseErr(logger, conn, s.resume())
+			}
+		case "START":
+			// Close the lobby and start the game (admin only).
+			if !admin {
+				comResponse(logger, conn, "err: not authorized")
+			} else {
+				comResponseErr(logger, conn, s.start())
+			}
+		case "OWNER":
+			// Give a country to a player while the game is frozen (admin only).
+			if !admin {
+				comResponse(logger, conn, "err: not authorized")
+			} else {
+				comResponseErr(logger, conn, s.setOwner(args[0], args[1], atoi(args[2])))
+			}
+		case "MAXPLAYERS":
+			// Change the number of seats in the lobby (admin only).
+			if !admin {
+				comResponse(logger, conn, "err: not authorized")
+			} else {
+				comResponseErr(logger, conn, s.setMaxPlayers(atoi(args[0])))
+			}
+		case "KICK":
+			// Remove a player from the game (admin only).
+			if !admin {
+				comResponse(logger, conn, "err: not authorized")
+			} else {
+				comResponseErr(logger, conn, s.kick(args[0]))
+			}
+		case "CAPITAL":
+			// Move the capital of the player in the first round (see core.World.SetCapital).
+			if len(player) == 0 {
+				comResponse(logger, conn, "err: no player")
+			} else {
+				comResponseErr(logger, conn, w.SetCapital(player, args[0]))
+			}
+		case "RESIGN":
+			// Hand the seat of the player to a substitute and close the connection (see Substitute).
+			if len(player) == 0 {
+				comResponse(logger, conn, "err: no player")
+			} else if err := s.resign(player); err != nil {
+				comResponseErr(logger, conn, err)
+			} else {
+				comResponse(logger, conn, "OK")
+				resigned = true
+			}
+		case "PLAYERS":
+			// Send the number of joined players and seats in the lobby, e.g. "2|4".
+			comResponse(logger, conn, s.players())
+		case "STATS":
+			// Send the cumulative statistics of a player as JSON object (see StatsFile).
+			if js, e := s.statsJson(args[0]); e != nil {
+				comResponseErr(logger, conn, e)
+			} else {
+				comResponse(logger, conn, wrapData(features, js))
+			}
+		case "LASTBATTLES":
+			// Send the newest battles with the dice of every round as JSON array (see BattleLogSize).
+			if js, e := s.battlesJson(atoi(optArg(args, 0))); e != nil {
+				comResponseErr(logger, conn, e)
+			} else {
+				comResponse(logger, conn, wrapData(features, js))
+			}
+		case "THUMBNAIL":
+			// Send a small preview of the map as base64 encoded PNG image (see ThumbnailWidth).
+			if png, e := s.thumbnail(); e != nil {
+				comResponseErr(logger, conn, e)
+			} else {
+				comResponse(logger, conn, wrapData(features, png))
+			}
+		case "AUDIT":
+			// Send the newest received commands as JSON array (admin only).
+			if !admin {
+				comResponse(logger, conn, "err: not authorized")
+			} else {
+				comResponse(logger, conn, wrapData(features, s.auditJson(atoi(optArg(args, 0)))))
+			}
 		default:
 			// If the command is invalid, send an error response.
-			comResponse(conn, "err: invalid command")
+			comResponse(logger, conn, "err: invalid command")
+		}
+
+		// Record the command in the audit log.
+		s.record(ac, start, player, line)
+
+		// The seat has been handed over, so the connection is closed.
+		if resigned {
+			break
 		}
 	}
 
 	// Log the player's departure when the connection is closed.
-	fmt.Printf("Player %s has disconnected\n", player)
+	logger.Info("player disconnected", "player", player)
+
+	// A player who leaves the lobby frees the slot.
+	if len(player) > 0 {
+		leaveGame(w, player)
+	}
+}
+
+// acquireConnection reserves a slot for a new connection.
+// It returns false if the connection limit (MaxConnections) is reached.
+func (s *Server) acquireConnection() bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.MaxConnections > 0 && s.connections >= s.MaxConnections {
+		return false
+	}
+	s.connections++
+	return true
+}
+
+// releaseConnection frees the slot of a closed connection.
+func (s *Server) releaseConnection() {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.connections--
 }
 
 // comResponse is a helper function that sends a formatted response message back to the client.
 //
 // Parameters:
+//   - logger: The logger for write errors.
 //   - conn: The network connection object representing the client connection.
 //   - s: The response message to send.
-func comResponse(conn net.Conn, s string) {
+func comResponse(logger *slog.Logger, conn net.Conn, s string) {
 	_, err := conn.Write([]byte(fmt.Sprintf("%s\r\n", s)))
 	if err != nil {
-		fmt.Printf("err: %v\n", err)
+		logger.Error("write response", "err", err)
 	}
 }
 
 // comResponseErr is a helper function that sends an error message (if any) or "OK" back to the client.
 //
 // Parameters:
+//   - logger: The logger for write errors.
 //   - conn: The network connection object representing the client connection.
 //   - err: The error object (if any) to send as a response.
-func comResponseErr(conn net.Conn, err error) {
+func comResponseErr(logger *slog.Logger, conn net.Conn, err error) {
+	comResponse(logger, conn, errText(err))
+}
+
+// wrapStatus frames a world state if the connection has negotiated FeatureFrame
+// or wraps it in an envelope if it has negotiated FeatureEnvelope. The frame already contains the length,
+// so it takes precedence over the envelope.
+func wrapStatus(features []string, payload string) string {
+	if slices.Contains(features, FeatureEnvelope) && !slices.Contains(features, FeatureFrame) {
+		return envelope(payload)
+	}
+	return wrapData(features, payload)
+}
+
+// wrapData frames a data response if the connection has negotiated FeatureFrame.
+func wrapData(features []string, payload string) string {
+	if slices.Contains(features, FeatureFrame) {
+		return frame(payload)
+	}
+	return payload
+}
+
+// myTurn returns the response of the MYTURN command: "YES" or "NO", followed by the remaining
+// time bank of the player in milliseconds if the match clock is enabled (see TimeBank), e.g. "YES|12500".
+func (s *Server) myTurn(player string) string {
+	active, left := s.World.IsTurn(player)
+	resp := "NO"
+	if active {
+		resp = "YES"
+	}
+	if s.TimeBank > 0 {
+		resp += fmt.Sprintf("|%d", left.Milliseconds())
+	}
+	return resp
+}
+
+// turnJson returns the response of the TURN command: the current turn as JSON object (see core.World.TurnInfo).
+func (s *Server) turnJson() string {
+	b, err := json.Marshal(s.World.TurnInfo())
 	if err != nil {
-		comResponse(conn, err.Error())
-	} else {
-		comResponse(conn, "OK")
+		return errText(err)
 	}
+	return string(b)
 }
 
-// saveArgs is a helper function that extracts and returns up to four string arguments from a client command.
-//
-// Parameters:
-//   - args: A list of command arguments received from the client.
-//
-// Returns:
-//   - a1: The first argument as a string.
-//   - a2: The second argument as a string.
-//   - a3: The third argument as a string.
-//   - a4: The fourth argument as a string.
-func saveArgs(args []string) (a1, a2, a3, a4 string) {
-	sArgs := make([]string, 5)
-	copy(sArgs, args)
-	return sArgs[1], sArgs[2], sArgs[3], sArgs[4]
+// rulesJson returns the response of the RULES command: the combat and reinforcement rules as JSON object
+// (see core.World.Rules).
+func (s *Server) rulesJson() string {
+	b, err := json.Marshal(s.World.Rules())
+	if err != nil {
+		return errText(err)
+	}
+	return string(b)
+}
+
+// players returns the response of the PLAYERS command: the number of players who have joined
+// and the number of seats in the lobby, e.g. "2|4".
+func (s *Server) players() string {
+	s.World.LobbyLock().Lock()
+	defer s.World.LobbyLock().Unlock()
+
+	return fmt.Sprintf("%d|%d", s.World.PlayerCount(), seats(s.World, s.MaxPlayerCount))
+}
+
+// resign hands the seat of a player in a running game to the Substitute (RESIGN command).
+func (s *Server) resign(player string) error {
+	if s.Substitute == nil {
+		return errors.New("err: resign disabled")
+	}
+	if !s.World.Started() || s.World.Winner() != "" {
+		return errors.New("err: game not running")
+	}
+	client, err := AttachLocalClient(s.World, player)
+	if err != nil {
+		return err
+	}
+	s.World.Logger().Info("player resigned", "player", player)
+	s.Substitute(client, player)
+	return nil
+}
+
+// errText returns the response text for the result of a command: the error message or "OK".
+func errText(err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return "OK"
 }
@@ -0,0 +1,665 @@
+package remote
+
+import (
+	"RISK-CodeConflict/core"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"io"
+	"log/slog"
+	"net"
+	"net/textproto"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestServer_MaxConnections(t *testing.T) {
+	world := core.NewWorld()
+
+	server := NewServer("127.0.0.1", "5555", world, 2)
+	server.MaxConnections = 1
+	go server.Run()
+	time.Sleep(100 * time.Millisecond) // wait for the listener
+
+	client, err := NewClient("127.0.0.1", "5555")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client2, err := NewClient("127.0.0.1", "5555")
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------
+
+	if err := client.AddPlayer("Player1", color.RGBA{R: 255, A: 255}); err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := readLine(client2.reader, 0); err != nil || resp != "err: server full" {
+		t.Fatal(resp, err)
+	}
+}
+
+func TestServer_GameFull(t *testing.T) {
+	world := core.NewWorld()
+
+	go RunServer("127.0.0.1", "5556", world, 1)
+	time.Sleep(100 * time.Millisecond) // wait for the listener
+
+	client, err := NewClient("127.0.0.1", "5556")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spectator, err := NewClient("127.0.0.1", "5556")
+	if err != nil {
+		t.Fatal(err)
+	}
+	//------------------------------------------
+
+	if err := client.AddPlayer("Player1", color.RGBA{R: 255, A: 255}); err != nil {
+		t.Fatal(err)
+	}
+	if err := spectator.AddPlayer("Player2", color.RGBA{G: 255, A: 255}); err == nil || err.Error() != "err: game is full" {
+		t.Fatal(err)
+	}
+	if err := spectator.Status(new(core.World)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestServer_handleRequest(t *testing.T) {
+	world := core.NewWorld()
+	world.Freeze = true
+	server := NewServer("127.0.0.1", "0", world, 2)
+
+	conn, serverConn := net.Pipe()
+	defer func() { _ = conn.Close() }()
+	go server.handleRequest(serverConn)
+	tp := textproto.NewReader(bufio.NewReader(conn))
+
+	tests := []struct {
+		line string
+		want string
+	}{
+		{line: "HI", want: "err: invalid command"},
+		{line: "STATUSGZ", want: "err: gzip not negotiated"},
+		{line: "HELLO", want: "OK"},
+		{line: "HELLO|zstd|gzip|gzip", want: "OK|gzip"},
+		{line: "HELLO|envelope", want: "OK|envelope"},
+		{line: "HELLO|frame", want: "OK|frame"},
+		{line: "COUNTRY|Atlantis", want: "country not found"},
+		{line: "MYTURN", want: "err: no player"},
+		{line: "MYTURN|x", want: "err: malformed MYTURN command"},
+		{line: "TURN|x", want: "err: malformed TURN command"},
+		{line: "LASTBATTLES|x", want: "err: malformed LASTBATTLES command"},
+		{line: "SCOUT", want: "err: malformed SCOUT command"},
+		{line: "SCOUT|Alaska", want: "err: no player"},
+		{line: "POOL", want: "err: no player"},
+		{line: "CAPITAL|Alaska", want: "err: no player"},
+		{line: "CAPITAL", want: "err: malformed CAPITAL command"},
+		{line: "RESIGN", want: "err: no player"},
+		{line: "RESIGN|x", want: "err: malformed RESIGN command"},
+		{line: "POOL|x", want: "err: malformed POOL command"},
+		{line: "STATUSGZ", want: "err: gzip not negotiated"},
+		{line: "STATUSGZ|1", want: "err: malformed STATUSGZ command"},
+		{line: "MOVE||", want: "err: malformed MOVE command"},
+		{line: "MOVE|Alaska|Alberta|x", want: "err: malformed MOVE command"},
+		{line: "ATTACK|Alaska|Alberta|3", want: "err: malformed ATTACK command"},
+		{line: "ATTACK|Alaska|Alberta|3|x", want: "err: malformed ATTACK command"},
+		{line: "END|t1|t2", want: "err: malformed END command"},
+		{line: "END", want: "err: no player"},
+		{line: "MOVE|Alaska|Alberta|3", want: "err: no player"},
+		{line: "ATTACK|Alaska|Alberta|3|1", want: "err: no player"},
+		{line: "RECRUITALL|Alaska:3", want: "err: no player"},
+		{line: "TRUCE|Player1|2", want: "err: no player"},
+		{line: "PLAYER", want: "err: malformed PLAYER command"},
+		{line: "COUNTRY", want: "err: malformed COUNTRY command"},
+		{line: "COUNTRY|Atlantis", want: "country not found"},
+		{line: "PLAYER|Player1|255|0|0", want: "OK"},
+		{line: "PLAYER|Player1", want: "err: player already created"},
+		{line: "TRUCE|Player1|2", want: "world is frozen"},
+		{line: "SCOUT|Alaska", want: "world is frozen"},
+		{line: "POOL", want: "0"},
+		{line: "CAPITAL|Alaska", want: "capitals disabled"},
+		{line: "RESIGN", want: "err: resign disabled"},
+		{line: "MOVE|Alaska|Alberta|3", want: "world is frozen"},
+		{line: "ATTACK|Alaska|Alberta|3|1|t1", want: "world is frozen"},
+		{line: "RECRUITALL|Alaska:x", want: "err: malformed RECRUITALL command"},
+		{line: "RECRUITALL|Alaska:3|Brazil:1", want: "world is frozen|world is frozen"},
+	}
+	for _, tt := range tests {
+		if _, err := conn.Write([]byte(tt.line + "\n")); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := tp.ReadLine()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp != tt.want {
+			t.Fatalf("%q: got %q, want %q", tt.line, resp, tt.want)
+		}
+	}
+}
+
+func TestServer_admin(t *testing.T) {
+	world := core.NewWorld()
+	world.Freeze = true
+	server := NewServer("127.0.0.1", "0", world, 1)
+
+	send := pipeServer(t, server)
+
+	// disabled
+	send("ADMIN|secret", "err: admin commands disabled")
+	server.AdminToken = "secret"
+
+	// not authorized
+	send("PAUSE", "err: not authorized")
+	send("OWNER|Alaska|Player1|3", "err: not authorized")
+	send("KICK|Player1", "err: not authorized")
+	send("ADMIN|wrong", "err: invalid admin token")
+	send("RESUME", "err: not authorized")
+	send("ADMIN", "err: malformed ADMIN command")
+
+	// before the game start
+	send("ADMIN|secret", "OK")
+	send("RESUME", "err: game is not paused")
+	send("PAUSE", "err: game is not running")
+
+	// start the game
+	send("PLAYER|Player1", "OK")
+	if world.Freeze {
+		t.Fatal("game not started")
+	}
+
+	// pause and resume
+	send("PAUSE", "OK")
+	if !world.Freeze {
+		t.Fatal("not paused")
+	}
+	send("END", "world is frozen")
+	send("OWNER|Alaska|Player1|x", "err: malformed OWNER command")
+	send("OWNER|Alaska|Nobody|3", "player not found")
+	send("OWNER|Alaska|Player1|3", "OK")
+	if a := world.Country("Alaska").Occupier; a.Player != "Player1" || a.Strength != 3 {
+		t.Fatal(a)
+	}
+	send("PAUSE", "err: game is not running")
+	send("RESUME", "OK")
+	if world.Freeze {
+		t.Fatal("not resumed")
+	}
+	send("OWNER|Alaska|Player1|3", "err: game is running (PAUSE first)")
+	send("RESUME", "err: game is not paused")
+}
+
+func TestServer_lobby(t *testing.T) {
+	world := core.NewWorld()
+	world.RequireReady = true
+	server := NewServer("127.0.0.1", "0", world, 3)
+	server.AdminToken = "secret"
+	server.World.Freeze = true
+
+	connect := func() (net.Conn, func(line, want string)) {
+		conn, serverConn := net.Pipe()
+		go server.handleRequest(serverConn)
+		tp := textproto.NewReader(bufio.NewReader(conn))
+		return conn, func(line, want string) {
+			t.Helper()
+			if _, err := conn.Write([]byte(line + "\n")); err != nil {
+				t.Fatal(err)
+			}
+			if resp, err := tp.ReadLine(); err != nil || resp != want {
+				t.Fatalf("%q: got %q (%v), want %q", line, resp, err, want)
+			}
+		}
+	}
+	_, send1 := connect()
+	conn2, send2 := connect()
+	_, send3 := connect()
+
+	send1("READY", "err: no player")
+	send1("PREFER|Europe", "err: no player")
+	send1("PLAYER|Player1", "OK")
+	send1("PREFER|Atlantis", "continent not found")
+	send1("PREFER|Europe", "OK")
+	send1("PREFER", "OK")
+	send1("READY", "OK")
+	send2("PLAYER|Player2", "OK")
+	send3("PLAYER|Player3", "OK")
+	if world.Started() {
+		t.Fatal("the game is full, but not all players are ready")
+	}
+
+	// a player leaves the lobby
+	_ = conn2.Close()
+	for i := 0; i < 100 && world.PlayerCount() > 2; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(world.PlayerQueue) != 2 || world.Started() {
+		t.Fatal(len(world.PlayerQueue), world.Phase)
+	}
+
+	// the last player is ready
+	send3("READY", "OK")
+	if !world.Started() || world.Freeze {
+		t.Fatal("game not started")
+	}
+	send3("READY", "game already started")
+	send3("PREFER|Europe", "game already started")
+	send1("ADMIN|secret", "OK")
+	send1("START", "err: game already started")
+}
+
+func TestServer_start(t *testing.T) {
+	world := core.NewWorld()
+	world.RequireReady = true
+	server := NewServer("127.0.0.1", "0", world, 4)
+	server.AdminToken = "secret"
+	server.World.Freeze = true
+
+	send := pipeServer(t, server)
+
+	send("START", "err: not authorized")
+	send("ADMIN|secret", "OK")
+	send("START", "err: not enough players")
+	send("PLAYER|Player1", "OK")
+	if err := NewLocalClient(world, 4).AddPlayer("Player2", color.RGBA{}); err != nil {
+		t.Fatal(err)
+	}
+	send("START", "OK")
+	if !world.Started() || world.Freeze || len(world.PlayerQueue) != 2 {
+		t.Fatal("game not started")
+	}
+
+	// kick a player from the running game
+	send("KICK|Player2", "game already started")
+	world.DeparturePolicy = core.DepartureNeutral
+	send("KICK", "err: malformed KICK command")
+	send("KICK|Nobody", "player not found")
+	send("KICK|Player2", "OK")
+	if len(world.PlayerQueue) != 1 || world.Phase != core.PhaseFinished {
+		t.Fatal(len(world.PlayerQueue), world.Phase)
+	}
+}
+
+func TestServer_maxPlayers(t *testing.T) {
+	world := core.NewWorld()
+	server := NewServer("127.0.0.1", "0", world, 4)
+	server.AdminToken = "secret"
+	server.World.Freeze = true
+
+	send := pipeServer(t, server)
+
+	send("PLAYERS", "0|4")
+	send("MAXPLAYERS|5", "err: not authorized")
+	send("ADMIN|secret", "OK")
+	send("MAXPLAYERS|x", "err: malformed MAXPLAYERS command")
+	send("MAXPLAYERS|1", "at least two seats needed")
+
+	// open a seat: the local clients follow the new size
+	send("MAXPLAYERS|5", "OK")
+	for _, name := range []string{"Player1", "Player2", "Player3", "Player4"} {
+		if err := NewLocalClient(world, 4).AddPlayer(name, color.RGBA{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	send("PLAYERS", "4|5")
+	if world.Started() {
+		t.Fatal("game started with a free seat")
+	}
+
+	// close seats: not below the joined players, and a full lobby starts the game
+	send("MAXPLAYERS|3", "more players have already joined")
+	send("MAXPLAYERS|4", "OK")
+	if !world.Started() || world.Freeze {
+		t.Fatal("game not started")
+	}
+	send("PLAYERS", "4|4")
+	send("MAXPLAYERS|6", "game already started")
+}
+
+func TestServer_turn(t *testing.T) {
+	world := core.NewWorld()
+	server := NewServer("127.0.0.1", "0", world, 4)
+	server.World.Freeze = true
+
+	send := pipeServer(t, server)
+
+	// lobby
+	send("TURN", `{"Round":0,"SubRound":0,"Active":"","Queue":[]}`)
+	send("PLAYER|P1", "OK")
+	if err := world.AddPlayer("P2", color.RGBA{A: 255}); err != nil {
+		t.Fatal(err)
+	}
+	send("TURN", `{"Round":0,"SubRound":0,"Active":"","Queue":["P1","P2"]}`)
+
+	// running game
+	world.Freeze = false
+	send("TURN", `{"Round":0,"SubRound":0,"Active":"P1","Queue":["P1","P2"]}`)
+}
+
+func TestServer_latePlayer(t *testing.T) {
+	world := core.NewWorld()
+	world.RequireReady = true
+	server := NewServer("127.0.0.1", "0", world, 3)
+	server.World.Freeze = true
+
+	send := pipeServer(t, server)
+
+	// the host starts the game with two of three players
+	for _, name := range []string{"Player1", "Player2"} {
+		if err := NewLocalClient(world, 3).AddPlayer(name, color.RGBA{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := server.start(); err != nil {
+		t.Fatal(err)
+	}
+	order := slices.Clone(world.PlayerQueue)
+
+	// the game is not full, but a late player must not change the turn order
+	send("PLAYER|Player3", "err: game already started")
+	if !slices.Equal(world.PlayerQueue, order) {
+		t.Fatal("turn order changed")
+	}
+}
+
+func TestServer_MaxLineLength(t *testing.T) {
+	server := NewServer("127.0.0.1", "0", core.NewWorld(), 4)
+	server.MaxLineLength = 100
+
+	send := pipeServer(t, server)
+
+	send("PLAYER|"+strings.Repeat("x", 10000), "err: line too long")
+	send("PLAYER|Player1", "OK") // the connection is still usable
+}
+
+func TestServer_resign(t *testing.T) {
+	world := core.NewWorld()
+	server := NewServer("127.0.0.1", "0", world, 2)
+
+	var substitute GameClient
+	var seat string
+	server.Substitute = func(client GameClient, player string) {
+		substitute, seat = client, player
+	}
+
+	send, tp := pipeConn(t, server)
+
+	// lobby
+	send("PLAYER|Player1", "OK")
+	send("RESIGN", "err: game not running")
+
+	// running game
+	if err := NewLocalClient(world, 2).AddPlayer("Player2", color.RGBA{}); err != nil {
+		t.Fatal(err)
+	}
+	send("RESIGN", "OK")
+	if _, err := tp.ReadLine(); err == nil {
+		t.Fatal("connection not closed")
+	}
+
+	// the substitute controls the seat
+	if seat != "Player1" || substitute == nil {
+		t.Fatal(seat, substitute)
+	}
+	if _, _, err := substitute.MyTurn(); err != nil {
+		t.Fatal(err)
+	}
+	if !world.HasPlayer("Player1") {
+		t.Fatal("player removed")
+	}
+}
+
+func TestServer_rulesJson(t *testing.T) {
+	server := NewServer("127.0.0.1", "0", core.NewWorld(), 2)
+	server.World.MinReinforcementPerTurn = 3
+
+	var rules core.Rules
+	if err := json.Unmarshal([]byte(server.rulesJson()), &rules); err != nil {
+		t.Fatal(err)
+	}
+	if rules.AttackDice != core.AttackDice || rules.MinReinforcementPerTurn != 3 || rules.ContinentPoints["Asia"] != server.World.Continent("Asia").Points {
+		t.Fatal(rules)
+	}
+}
+
+// TestServer_soak plays a game with many concurrent connections: players who give orders on their turn,
+// spectators who poll and an admin who pauses and resumes the game. Run it with -race to find locking gaps.
+func TestServer_soak(t *testing.T) {
+	if testing.Short() {
+		t.Skip("soak test")
+	}
+	const players, spectators, rounds = 4, 4, 15
+
+	world := core.NewWorld()
+	world.SetSeed(1)
+	world.SetLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	server := NewServer("127.0.0.1", "0", world, players)
+	server.AdminToken = "secret"
+	server.recordBattles()
+
+	// connect opens a connection to the server and returns a function that sends a line and reads the response.
+	var conns []net.Conn
+	connect := func() func(line string) string {
+		conn, serverConn := net.Pipe()
+		conns = append(conns, conn)
+		go server.handleRequest(serverConn)
+		tp := textproto.NewReader(bufio.NewReader(conn))
+		return func(line string) string {
+			if _, err := conn.Write([]byte(line + "\n")); err != nil {
+				return ""
+			}
+			resp, _ := tp.ReadLine()
+			return resp
+		}
+	}
+	defer func() {
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	}()
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	var errs = make(chan string, players+spectators+1)
+	var accepted atomic.Int64 // the orders the game has accepted
+	running := func() bool {
+		select {
+		case <-done:
+			return false
+		default:
+			return true
+		}
+	}
+
+	// players: all orders of their turn, then END
+	for i := 0; i < players; i++ {
+		name := fmt.Sprintf("P%d", i+1)
+		send := connect()
+		if resp := send("PLAYER|" + name); resp != "OK" {
+			t.Fatal(name, resp)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for running() {
+				if send("MYTURN") != "YES" {
+					time.Sleep(time.Millisecond)
+					continue
+				}
+				for n, m := range world.LegalMoves(name) {
+					if n >= 5 {
+						break
+					}
+					if send(fmt.Sprintf("MOVE|%s|%s|1", m.Attacker, m.Defender)) == "OK" {
+						accepted.Add(1)
+					}
+				}
+				if resp := send("END"); resp == "" {
+					errs <- "END: no response"
+					return
+				}
+			}
+		}()
+	}
+
+	// spectators: poll the state and check the invariants
+	for i := 0; i < spectators; i++ {
+		send := connect()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for running() {
+				for _, line := range []string{"STATUS", "TURN", "PLAYERS", "RULES", "LASTBATTLES|5"} {
+					if resp := send(line); resp == "" {
+						errs <- line + ": no response"
+						return
+					}
+				}
+				if err := soakInvariants(world.DeepCopy(), players); err != nil {
+					errs <- err.Error()
+					return
+				}
+			}
+		}()
+	}
+
+	// admin: pause and resume
+	admin := connect()
+	if resp := admin("ADMIN|secret"); resp != "OK" {
+		t.Fatal(resp)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for running() {
+			admin("PAUSE")
+			time.Sleep(time.Millisecond)
+			admin("RESUME")
+			time.Sleep(5 * time.Millisecond)
+		}
+	}()
+
+	// play until the round limit, the end of the game or the timeout
+	deadline := time.Now().Add(20 * time.Second)
+	for {
+		_, round, _ := world.Turn()
+		if round >= rounds || world.Winner() != "" || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(done)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	if _, round, _ := world.Turn(); round < 1 {
+		t.Fatal("no round played")
+	}
+	if accepted.Load() == 0 || len(server.battles.recent(0)) == 0 {
+		t.Fatal("no battles fought", accepted.Load())
+	}
+	if err := soakInvariants(world.DeepCopy(), players); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// soakInvariants checks a snapshot of the world: every country is occupied with at least one unit
+// by a player who is still in the game, and every player is either in the queue or eliminated.
+func soakInvariants(w *core.World, players int) error {
+	alive := make(map[string]bool)
+	for _, p := range w.PlayerQueue {
+		alive[p.Name] = true
+	}
+	for _, p := range w.Eliminated {
+		if alive[p.Name] {
+			return fmt.Errorf("player %s is eliminated and alive", p.Name)
+		}
+	}
+	if len(alive)+len(w.Eliminated) != players {
+		return fmt.Errorf("%d players alive, %d eliminated", len(alive), len(w.Eliminated))
+	}
+	for _, c := range w.Countries {
+		if c.Occupier == nil || c.Occupier.Strength < 1 || !alive[c.Occupier.Player] {
+			return fmt.Errorf("country %s: invalid occupier %v", c.Name, c.Occupier)
+		}
+	}
+	return nil
+}
+
+// TestServer_concurrentJoin lets more players join at once than the game has seats (run it with -race):
+// the game starts exactly once with a full lobby, and all others are rejected.
+func TestServer_concurrentJoin(t *testing.T) {
+	const seats, players = 4, 12
+	world := core.NewWorld()
+	world.SetLogger(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	world.SetFreeze(true)
+
+	var wg sync.WaitGroup
+	var mux sync.Mutex
+	joined, full := 0, 0
+	for i := 0; i < players; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := joinGame(world, seats, fmt.Sprintf("P%d", i+1), color.RGBA{})
+			mux.Lock()
+			defer mux.Unlock()
+			switch {
+			case err == nil:
+				joined++
+			case err.Error() == "err: game is full" || err.Error() == "err: game already started":
+				full++
+			default:
+				t.Error(err)
+			}
+		}()
+		go func() {
+			_, _, _ = world.Turn() // concurrent readers
+			_ = world.Frozen()
+		}()
+	}
+	wg.Wait()
+
+	if joined != seats || full != players-seats || world.PlayerCount() != seats {
+		t.Fatal(joined, full, world.PlayerCount())
+	}
+	if !world.Started() || world.Frozen() {
+		t.Fatal("game not started")
+	}
+}
+
+// pipeServer connects to the server through an in-memory pipe and returns a function that sends a line
+// and fails the test unless the response is want. The connection is closed at the end of the test.
+func pipeServer(t *testing.T, server *Server) (send func(line, want string)) {
+	t.Helper()
+	send, _ = pipeConn(t, server)
+	return send
+}
+
+// pipeConn is pipeServer that also returns the reader of the connection, e.g. to check that the server closed it.
+func pipeConn(t *testing.T, server *Server) (send func(line, want string), tp *textproto.Reader) {
+	t.Helper()
+	conn, serverConn := net.Pipe()
+	t.Cleanup(func() { _ = conn.Close() })
+	go server.handleRequest(serverConn)
+	tp = textproto.NewReader(bufio.NewReader(conn))
+
+	send = func(line, want string) {
+		t.Helper()
+		if _, err := conn.Write([]byte(line + "\n")); err != nil {
+			t.Fatal(err)
+		}
+		if resp, err := tp.ReadLine(); err != nil || resp != want {
+			t.Fatalf("%q: got %q (%v), want %q", line, resp, err, want)
+		}
+	}
+	return send, tp
+}
@@ -0,0 +1,139 @@
+package remote
+
+import (
+	"RISK-CodeConflict/core"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+)
+
+// PlayerStats are the cumulative results of a player over all games recorded by a StatsStore.
+type PlayerStats struct {
+	Games       int     // The number of finished games
+	Wins        int     // The number of games won (see core.World.Winner)
+	RankSum     int     // The sum of the final ranks of all games (see core.World.FinalRanking)
+	AverageRank float64 // The average final rank (RankSum / Games)
+	Conquered   int     // The total number of countries captured (see core.Player.Conquered)
+}
+
+// StatsStore keeps the statistics of all players across games in a JSON file, keyed by player name
+// (see Server.StatsFile). Every recorded game is written to the file immediately, so the statistics
+// survive a restart of the server. All methods are thread-safe.
+type StatsStore struct {
+	mux     sync.Mutex
+	path    string
+	players map[string]*PlayerStats // Key: Player.Name
+}
+
+// OpenStatsStore loads the statistics from the JSON file. A missing file is an empty store;
+// it is created when the first game is recorded.
+//
+// Parameters:
+//   - path: The path of the JSON file.
+//
+// Returns:
+//   - The store.
+//   - An error if the file exists but cannot be read or parsed.
+func OpenStatsStore(path string) (*StatsStore, error) {
+	s := &StatsStore{path: path, players: make(map[string]*PlayerStats)}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil // SUCCESS EXIT: new store
+	}
+	if err != nil {
+		return nil, err // ERROR EXIT
+	}
+	if err := json.Unmarshal(b, &s.players); err != nil {
+		return nil, err // ERROR EXIT
+	}
+	return s, nil // SUCCESS EXIT
+}
+
+// Record adds the result of a finished game to the statistics of all its players and saves the file.
+// The file is replaced atomically, so a crash never leaves a half-written file behind.
+//
+// Parameters:
+//   - summary: The result of the game (see core.World.MatchSummary).
+//
+// Returns:
+//   - An error if the file cannot be written. The statistics in memory are updated anyway.
+func (s *StatsStore) Record(summary core.MatchSummary) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, p := range summary.Players {
+		st := s.players[p.Name]
+		if st == nil {
+			st = new(PlayerStats)
+			s.players[p.Name] = st
+		}
+		st.Games++
+		if p.Name == summary.Winner {
+			st.Wins++
+		}
+		st.RankSum += p.Rank
+		st.AverageRank = float64(st.RankSum) / float64(st.Games)
+		st.Conquered += p.Conquered
+	}
+	return s.save()
+}
+
+// Stats returns the statistics of a player.
+//
+// Returns:
+//   - A copy of the statistics.
+//   - False if no game of the player has been recorded.
+func (s *StatsStore) Stats(player string) (PlayerStats, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	st := s.players[player]
+	if st == nil {
+		return PlayerStats{}, false
+	}
+	return *st, true
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// save writes all statistics to a temporary file and renames it to the path of the store.
+// The caller must hold the mutex.
+func (s *StatsStore) save() error {
+	b, err := json.MarshalIndent(s.players, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// statsJson returns the statistics of a player as JSON object (STATS command).
+// Errors are returned separately, so only the JSON is framed (see FeatureFrame).
+func (s *Server) statsJson(player string) (string, error) {
+	if s.stats == nil {
+		return "", errors.New("err: stats disabled") // ERROR EXIT
+	}
+	st, ok := s.stats.Stats(player)
+	if !ok {
+		return "", errors.New("err: no stats for player") // ERROR EXIT
+	}
+	b, err := json.Marshal(st)
+	if err != nil {
+		return "", err // ERROR EXIT
+	}
+	return string(b), nil // SUCCESS EXIT
+}
+
+// recordStats adds the finished game of the server to the statistics (see core.GameOverEvent and Run).
+func (s *Server) recordStats() {
+	if err := s.stats.Record(s.World.MatchSummary()); err != nil {
+		s.World.Logger().Error("failed to save the stats", "err", err)
+		return
+	}
+	s.World.Logger().Info("stats recorded", "file", s.StatsFile)
+}
@@ -0,0 +1,74 @@
+package remote
+
+import (
+	"RISK-CodeConflict/core"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStatsStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.json")
+
+	// a missing file is an empty store
+	s, err := OpenStatsStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Stats("P1"); ok {
+		t.Fatal("empty store")
+	}
+
+	// two games
+	games := []core.MatchSummary{
+		{Winner: "P1", Players: []core.PlayerSummary{{Name: "P1", Rank: 1, Conquered: 5}, {Name: "P2", Rank: 2, Conquered: 2}}},
+		{Winner: "P2", Players: []core.PlayerSummary{{Name: "P2", Rank: 1, Conquered: 4}, {Name: "P1", Rank: 2, Conquered: 1}}},
+	}
+	for _, g := range games {
+		if err := s.Record(g); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the statistics survive a restart
+	s, err = OpenStatsStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := PlayerStats{Games: 2, Wins: 1, RankSum: 3, AverageRank: 1.5, Conquered: 6}
+	if st, ok := s.Stats("P1"); !ok || st != want {
+		t.Fatal(st)
+	}
+
+	// broken file
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenStatsStore(path); err == nil {
+		t.Fatal("broken file must fail")
+	}
+}
+
+func TestServer_stats(t *testing.T) {
+	server := NewServer("127.0.0.1", "0", core.NewWorld(), 2)
+
+	send := pipeServer(t, server)
+
+	send("STATS|P1", "err: stats disabled")
+
+	stats, err := OpenStatsStore(filepath.Join(t.TempDir(), "stats.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = stats.Record(core.MatchSummary{Winner: "P1", Players: []core.PlayerSummary{{Name: "P1", Rank: 1, Conquered: 3}}})
+	server.stats = stats
+
+	send("STATS", "err: malformed STATS command")
+	send("STATS|P2", "err: no stats for player")
+	send("STATS|P1", `{"Games":1,"Wins":1,"RankSum":1,"AverageRank":1,"Conquered":3}`)
+
+	// with frame, only the JSON is framed and errors stay single lines
+	send("HELLO|frame", "OK|frame")
+	send("STATS|P2", "err: no stats for player")
+	send("STATS|P1", "DATA|62")
+}
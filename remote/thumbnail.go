@@ -0,0 +1,53 @@
+package remote
+
+import (
+	"RISK-CodeConflict/render"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"time"
+)
+
+// The default size of the map preview (see Server.ThumbnailWidth and Server.ThumbnailHeight).
+const (
+	DefaultThumbnailWidth  = 160
+	DefaultThumbnailHeight = 90
+)
+
+// DefaultThumbnailRefresh is the default minimum time between two renderings of the map preview
+// (see Server.ThumbnailRefresh).
+const DefaultThumbnailRefresh = 5 * time.Second
+
+// thumbnailCache keeps the last rendered map preview, so frequent THUMBNAIL requests
+// (e.g. of a server browser polling many games) do not render the world every time.
+type thumbnailCache struct {
+	mux      sync.Mutex
+	png      string    // The base64 encoded PNG image.
+	rendered time.Time // The time of the rendering.
+}
+
+// thumbnail returns the map preview as base64 encoded PNG image (THUMBNAIL command, see render.Thumbnail).
+// The image is rendered again if it is older than ThumbnailRefresh.
+// Errors are returned separately, so only the image is framed (see FeatureFrame).
+func (s *Server) thumbnail() (string, error) {
+	if s.ThumbnailWidth <= 0 || s.ThumbnailHeight <= 0 {
+		return "", errors.New("err: thumbnail disabled") // ERROR EXIT
+	}
+
+	s.thumb.mux.Lock()
+	defer s.thumb.mux.Unlock()
+
+	if s.thumb.png != "" && s.timeSource().Now().Sub(s.thumb.rendered) < s.ThumbnailRefresh {
+		return s.thumb.png, nil // SUCCESS EXIT: cached
+	}
+
+	// the renderer reads the world without locking, so it gets a copy
+	buf := new(bytes.Buffer)
+	if err := render.WritePNG(buf, s.World.DeepCopy(), s.ThumbnailWidth, s.ThumbnailHeight); err != nil {
+		return "", err // ERROR EXIT
+	}
+	s.thumb.png = base64.StdEncoding.EncodeToString(buf.Bytes())
+	s.thumb.rendered = s.timeSource().Now()
+	return s.thumb.png, nil // SUCCESS EXIT
+}
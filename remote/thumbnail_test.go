@@ -0,0 +1,73 @@
+package remote
+
+import (
+	"RISK-CodeConflict/core"
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"image/png"
+	"net"
+	"net/textproto"
+	"testing"
+	"time"
+)
+
+func TestServer_thumbnail(t *testing.T) {
+	clock := newFakeClock()
+	server := NewServer("127.0.0.1", "0", core.NewWorld(), 2)
+	server.ThumbnailRefresh = time.Hour
+	server.Clock = clock
+
+	conn, serverConn := net.Pipe()
+	defer func() { _ = conn.Close() }()
+	go server.handleRequest(serverConn)
+	tp := textproto.NewReader(bufio.NewReader(conn))
+
+	thumbnail := func() string {
+		t.Helper()
+		if _, err := conn.Write([]byte("THUMBNAIL\n")); err != nil {
+			t.Fatal(err)
+		}
+		resp, err := tp.ReadLine()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	// a PNG image with the configured size
+	resp := thumbnail()
+	b, err := base64.StdEncoding.DecodeString(resp)
+	if err != nil {
+		t.Fatal(resp, err)
+	}
+	img, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds().Dx() != DefaultThumbnailWidth || img.Bounds().Dy() != DefaultThumbnailHeight {
+		t.Fatal(img.Bounds())
+	}
+
+	// cached until the refresh time has passed
+	server.ThumbnailWidth = 20
+	clock.Advance(59 * time.Minute)
+	if thumbnail() != resp {
+		t.Fatal("not cached")
+	}
+	clock.Advance(time.Minute)
+	if thumbnail() == resp {
+		t.Fatal("not rendered again")
+	}
+
+	// disabled
+	server.ThumbnailWidth = 0
+	if resp := thumbnail(); resp != "err: thumbnail disabled" {
+		t.Fatal(resp)
+	}
+
+	// with frame, errors stay single lines
+	send := pipeServer(t, server)
+	send("HELLO|frame", "OK|frame")
+	send("THUMBNAIL", "err: thumbnail disabled")
+}
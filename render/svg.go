@@ -0,0 +1,157 @@
+// Package render draws schematic views of the game world without any graphics library,
+// so it can be used headless (e.g. on a server, in documentation builds or in tests).
+package render
+
+import (
+	"RISK-CodeConflict/core"
+	"bytes"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+	"sort"
+)
+
+// ContinentColors are the fill colors of the countries, assigned to the continents in alphabetical order.
+var ContinentColors = []color.RGBA{
+	{R: 230, G: 159, B: 0, A: 255},   // orange
+	{R: 86, G: 180, B: 233, A: 255},  // sky blue
+	{R: 0, G: 158, B: 115, A: 255},   // bluish green
+	{R: 240, G: 228, B: 66, A: 255},  // yellow
+	{R: 0, G: 114, B: 178, A: 255},   // blue
+	{R: 213, G: 94, B: 0, A: 255},    // vermilion
+	{R: 204, G: 121, B: 167, A: 255}, // reddish purple
+}
+
+// nodeRadius is the radius of a country node in the SVG (in map coordinates).
+const nodeRadius = 18
+
+// WriteSVG writes a schematic map of the world as SVG image.
+// Each country is drawn as a labeled circle at its Position, colored by its continent,
+// and neighboring countries are connected by lines. Countries with an occupier get a border
+// in the color of the occupying player and show the army strength.
+// The image has the size CountryPosScaleWidth x CountryPosScaleHeight.
+//
+// Parameters:
+//   - w: The writer for the SVG document.
+//   - world: The world to draw.
+//
+// Returns:
+//   - An error if writing fails.
+func WriteSVG(w io.Writer, world *core.World) error {
+	buf := new(bytes.Buffer)
+	width, height := core.CountryPosScaleWidth, core.CountryPosScaleHeight
+
+	fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, height, width, height)
+	fmt.Fprintf(buf, `<rect width="%d" height="%d" fill="white"/>`+"\n", width, height)
+
+	// edges (every pair only once)
+	names := sortedCountries(world)
+	fmt.Fprintln(buf, `<g stroke="#999" stroke-width="2">`)
+	for _, name := range names {
+		c := world.Country(name)
+		for _, n := range c.NeighborsObj() {
+			if n.Name < c.Name {
+				continue // drawn from the other side
+			}
+			writeEdge(buf, c.Position, n.Position, width)
+		}
+	}
+	fmt.Fprintln(buf, `</g>`)
+
+	// continent colors
+	fill := continentColors(world)
+
+	// nodes
+	fmt.Fprintln(buf, `<g font-family="sans-serif" font-size="14" text-anchor="middle">`)
+	for _, name := range names {
+		c := world.Country(name)
+		x, y := c.Position[0], c.Position[1]
+
+		clr, ok := fill[c.Continent]
+		if !ok {
+			clr = "#ccc" // unknown continent
+		}
+		stroke, strokeWidth := "#333", 1
+		if c.Occupier != nil {
+			stroke, strokeWidth = hex(c.Occupier.PlayerObj().Color), 5
+		}
+		fmt.Fprintf(buf, `<g><title>%s (%s)</title>`, html.EscapeString(c.Name), html.EscapeString(c.Continent))
+		fmt.Fprintf(buf, `<circle cx="%d" cy="%d" r="%d" fill="%s" stroke="%s" stroke-width="%d"/>`, x, y, nodeRadius, clr, stroke, strokeWidth)
+		if c.Occupier != nil {
+			fmt.Fprintf(buf, `<text x="%d" y="%d">%d</text>`, x, y+5, c.Occupier.Strength)
+		}
+		fmt.Fprintf(buf, `<text x="%d" y="%d">%s</text></g>`+"\n", x, y+nodeRadius+16, html.EscapeString(c.Name))
+	}
+	fmt.Fprintln(buf, `</g>`)
+	fmt.Fprintln(buf, `</svg>`)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// writeEdge writes the line between two neighboring countries.
+// Connections across the map border (e.g. Alaska - Kamchatka) are drawn as two lines leaving the map.
+func writeEdge(buf *bytes.Buffer, a, b [2]int, width int) {
+	if abs(a[0]-b[0]) <= width/2 {
+		fmt.Fprintf(buf, `<line x1="%d" y1="%d" x2="%d" y2="%d"/>`+"\n", a[0], a[1], b[0], b[1])
+		return
+	}
+
+	// wrap around: left point connects to the left border, right point to the right border
+	if a[0] > b[0] {
+		a, b = b, a
+	}
+	midY := (a[1] + b[1]) / 2
+	fmt.Fprintf(buf, `<line x1="%d" y1="%d" x2="%d" y2="%d"/>`+"\n", a[0], a[1], 0, midY)
+	fmt.Fprintf(buf, `<line x1="%d" y1="%d" x2="%d" y2="%d"/>`+"\n", b[0], b[1], width, midY)
+}
+
+// sortedCountries returns the names of all countries in alphabetical order, so the output is deterministic.
+func sortedCountries(world *core.World) []string {
+	names := make([]string, 0, len(world.Countries))
+	for name := range world.Countries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// continentColors assigns a color of ContinentColors to every continent (alphabetical order).
+func continentColors(world *core.World) map[string]string {
+	colors := make(map[string]string, len(world.Continents))
+	for name, clr := range continentRGBA(world) {
+		colors[name] = hex(clr)
+	}
+	return colors
+}
+
+// continentRGBA is continentColors without the SVG notation.
+func continentRGBA(world *core.World) map[string]color.RGBA {
+	names := make([]string, 0, len(world.Continents))
+	for name := range world.Continents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	colors := make(map[string]color.RGBA, len(names))
+	for i, name := range names {
+		colors[name] = ContinentColors[i%len(ContinentColors)]
+	}
+	return colors
+}
+
+// hex returns the color in the SVG notation #rrggbb.
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,66 @@
+package render
+
+import (
+	"RISK-CodeConflict/core"
+	"bytes"
+	"encoding/xml"
+	"image/color"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWriteSVG(t *testing.T) {
+	world := core.NewWorld()
+	_ = world.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	_ = world.AddPlayer("P2", color.RGBA{G: 255, A: 255})
+	world.InitPopulation()
+
+	buf := new(bytes.Buffer)
+	if err := WriteSVG(buf, world); err != nil {
+		t.Fatal(err)
+	}
+
+	// well-formed XML
+	circles, lines := 0, 0
+	dec := xml.NewDecoder(bytes.NewReader(buf.Bytes()))
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if el, ok := tok.(xml.StartElement); ok {
+			switch el.Name.Local {
+			case "circle":
+				circles++
+			case "line":
+				lines++
+			}
+		}
+	}
+
+	// one node per country, at least one line per neighbor pair
+	if circles != len(world.Countries) {
+		t.Fatal(circles)
+	}
+	edges := 0
+	for _, c := range world.Countries {
+		edges += len(c.Neighbors)
+	}
+	if lines < edges/2 {
+		t.Fatal(lines, edges)
+	}
+
+	// deterministic
+	buf2 := new(bytes.Buffer)
+	_ = WriteSVG(buf2, world)
+	if buf.String() != buf2.String() {
+		t.Fatal("output is not deterministic")
+	}
+	if !strings.Contains(buf.String(), ">Alaska<") || !strings.Contains(buf.String(), "#ff0000") {
+		t.Fatal("missing label or player color")
+	}
+}
@@ -0,0 +1,120 @@
+package render
+
+import (
+	"RISK-CodeConflict/core"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// Thumbnail draws a small preview of the world, e.g. for a server browser that lists running games.
+// It is the raster counterpart of WriteSVG without any text: every country is a dot at its scaled Position
+// in the color of the occupying player with a center in the color of its continent,
+// and neighboring countries are connected by lines.
+//
+// Parameters:
+//   - world: The world to draw. It is read without locking, so pass a copy of a shared world (see core.World.DeepCopy).
+//   - width: The width of the image in pixels.
+//   - height: The height of the image in pixels.
+//
+// Returns:
+//   - The image. A size below 1x1 results in an empty image.
+func Thumbnail(world *core.World, width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, max(0, width), max(0, height)))
+	if width < 1 || height < 1 {
+		return img
+	}
+	fillRect(img, img.Bounds(), color.RGBA{R: 255, G: 255, B: 255, A: 255})
+
+	scale := func(pos [2]int) [2]int {
+		return [2]int{pos[0] * width / core.CountryPosScaleWidth, pos[1] * height / core.CountryPosScaleHeight}
+	}
+
+	// edges (every pair only once)
+	names := sortedCountries(world)
+	edge := color.RGBA{R: 0x99, G: 0x99, B: 0x99, A: 255}
+	for _, name := range names {
+		c := world.Country(name)
+		for _, n := range c.NeighborsObj() {
+			if n.Name < c.Name {
+				continue // drawn from the other side
+			}
+			a, b := scale(c.Position), scale(n.Position)
+			if abs(a[0]-b[0]) <= width/2 {
+				drawLine(img, a, b, edge)
+				continue
+			}
+			// wrap around (see writeEdge)
+			if a[0] > b[0] {
+				a, b = b, a
+			}
+			midY := (a[1] + b[1]) / 2
+			drawLine(img, a, [2]int{0, midY}, edge)
+			drawLine(img, b, [2]int{width - 1, midY}, edge)
+		}
+	}
+
+	// nodes
+	fill := continentRGBA(world)
+	radius := max(1, min(width, height)/60)
+	for _, name := range names {
+		c := world.Country(name)
+		pos := scale(c.Position)
+
+		owner := color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 255}
+		if c.Occupier != nil {
+			owner = c.Occupier.PlayerObj().Color
+			owner.A = 255
+		}
+		drawDisc(img, pos, radius, owner)
+		if clr, ok := fill[c.Continent]; ok && radius >= 3 {
+			drawDisc(img, pos, radius/2, clr)
+		}
+	}
+	return img
+}
+
+// WritePNG writes the Thumbnail of the world as PNG image.
+//
+// Returns:
+//   - An error if encoding or writing fails.
+func WritePNG(w io.Writer, world *core.World, width, height int) error {
+	return png.Encode(w, Thumbnail(world, width, height))
+}
+
+//--------  HELPER  --------------------------------------------------------------------------------------------------//
+
+// fillRect fills the rectangle with a color.
+func fillRect(img *image.RGBA, r image.Rectangle, clr color.RGBA) {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.SetRGBA(x, y, clr)
+		}
+	}
+}
+
+// drawLine draws a one pixel wide line between two points. Points outside the image are clipped.
+func drawLine(img *image.RGBA, a, b [2]int, clr color.RGBA) {
+	steps := max(abs(b[0]-a[0]), abs(b[1]-a[1]))
+	if steps == 0 {
+		img.SetRGBA(a[0], a[1], clr)
+		return
+	}
+	for i := 0; i <= steps; i++ {
+		x := a[0] + (b[0]-a[0])*i/steps
+		y := a[1] + (b[1]-a[1])*i/steps
+		img.SetRGBA(x, y, clr)
+	}
+}
+
+// drawDisc draws a filled circle. Parts outside the image are clipped.
+func drawDisc(img *image.RGBA, center [2]int, radius int, clr color.RGBA) {
+	for dy := -radius; dy <= radius; dy++ {
+		for dx := -radius; dx <= radius; dx++ {
+			if dx*dx+dy*dy <= radius*radius {
+				img.SetRGBA(center[0]+dx, center[1]+dy, clr)
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package render
+
+import (
+	"RISK-CodeConflict/core"
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestThumbnail(t *testing.T) {
+	world := core.NewWorld()
+	_ = world.AddPlayer("P1", color.RGBA{R: 255, A: 255})
+	_ = world.AddPlayer("P2", color.RGBA{G: 255, A: 255})
+	world.InitPopulation()
+
+	img := Thumbnail(world, 160, 90)
+	if b := img.Bounds(); b.Dx() != 160 || b.Dy() != 90 {
+		t.Fatal(b)
+	}
+
+	// the dot of every country has the color of its occupier
+	for _, c := range world.Countries {
+		x := c.Position[0] * 160 / core.CountryPosScaleWidth
+		y := c.Position[1] * 90 / core.CountryPosScaleHeight
+		if got, want := img.RGBAAt(x, y), c.Occupier.PlayerObj().Color; got != want {
+			t.Fatalf("%s: got %v, want %v", c.Name, got, want)
+		}
+	}
+
+	// empty image
+	if b := Thumbnail(world, 0, -1).Bounds(); !b.Empty() {
+		t.Fatal(b)
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	world := core.NewWorld()
+
+	buf := new(bytes.Buffer)
+	if err := WritePNG(buf, world, 64, 36); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != 64 || b.Dy() != 36 {
+		t.Fatal(b)
+	}
+}